@@ -0,0 +1,37 @@
+package authorization
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "platform/config"
+    "platform/http/actionresults"
+    "platform/services"
+)
+
+type policyActionFilter struct {}
+
+func (f *policyActionFilter) FilterAction(ctx context.Context, handler interface{}, 
+        handlerName, methodName string) actionresults.ActionResult {
+    policy, found := ActionPolicy(handler, methodName)
+    if !found {
+        return nil
+    }
+    var authorizer Authorizer
+    var cfg config.Configuration
+    if err := services.GetServiceForContext(ctx, &authorizer); err != nil {
+        return actionresults.NewErrorAction(err)
+    } else if authorizer.Authorize(policy) {
+        return nil
+    }
+    if !authorizer.User().IsAuthenticated() {
+        services.GetServiceForContext(ctx, &cfg)
+        if failUrl, found := cfg.GetString("authorization:failUrl"); found {
+            return actionresults.NewRedirectAction(failUrl)
+        }
+        return actionresults.NewStatusErrorAction(http.StatusUnauthorized, 
+            fmt.Errorf("Authentication required for %v.%v", handlerName, methodName))
+    }
+    return actionresults.NewStatusErrorAction(http.StatusForbidden, 
+        fmt.Errorf("Access denied to %v.%v", handlerName, methodName))
+}
@@ -0,0 +1,41 @@
+package authorization
+
+import (
+    "net/http"
+    "platform/authorization/identity"
+    "platform/config"
+    "platform/pipeline"
+)
+
+func NewAuthorizationComponent(
+        condition identity.AuthorizationCondition) *AuthorizationComponent {
+    return &AuthorizationComponent{ condition: condition }
+}
+
+type AuthorizationComponent struct {
+    condition identity.AuthorizationCondition
+    config.Configuration
+    authFailURL string
+}
+
+func (c *AuthorizationComponent) Init() {
+    c.authFailURL, _ = c.Configuration.GetString("authorization:failUrl")
+}
+
+func (*AuthorizationComponent) ImplementsProcessRequestWithServices() {}
+
+func (c *AuthorizationComponent) ProcessRequestWithServices(
+        context *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext),
+        user identity.User) {
+    if c.condition.Validate(user) {
+        next(context)
+    } else if c.authFailURL != "" {
+        http.Redirect(context.ResponseWriter, context.Request, 
+            c.authFailURL, http.StatusSeeOther)
+    } else if user.IsAuthenticated() {
+        context.ResponseWriter.WriteHeader(http.StatusForbidden)
+    } else {
+        context.ResponseWriter.WriteHeader(http.StatusUnauthorized)
+    }
+}
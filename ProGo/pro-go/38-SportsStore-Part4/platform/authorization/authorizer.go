@@ -0,0 +1,57 @@
+package authorization
+
+import (
+    "platform/authorization/identity"
+    "platform/logging"
+)
+
+type Authorizer interface {
+
+    User() identity.User
+
+    Authorize(policy string) bool
+
+    AuthorizeResource(policy string, resource interface{}) bool
+
+    AuthorizeAction(handler interface{}, methodName string) bool
+}
+
+func NewAuthorizer(user identity.User, policies PolicySet, 
+        logger logging.Logger) Authorizer {
+    return &policyAuthorizer{ user: user, policies: policies, logger: logger }
+}
+
+type policyAuthorizer struct {
+    user identity.User
+    policies PolicySet
+    logger logging.Logger
+}
+
+func (a *policyAuthorizer) User() identity.User {
+    return a.user
+}
+
+func (a *policyAuthorizer) Authorize(policy string) bool {
+    return a.AuthorizeResource(policy, nil)
+}
+
+func (a *policyAuthorizer) AuthorizeResource(policy string, 
+        resource interface{}) bool {
+    if policy == "" {
+        return true
+    }
+    condition, found := a.policies.Get(policy)
+    if !found {
+        a.logger.Warnf("Unknown authorization policy: %v", policy)
+        return false
+    }
+    return ValidateResource(condition, a.user, resource)
+}
+
+func (a *policyAuthorizer) AuthorizeAction(handler interface{}, 
+        methodName string) bool {
+    if policy, found := ActionPolicy(handler, methodName); found {
+        return a.Authorize(policy)
+    }
+    return true
+}
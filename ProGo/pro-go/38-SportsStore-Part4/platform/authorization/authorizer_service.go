@@ -0,0 +1,43 @@
+package authorization
+
+import (
+    "platform/authorization/identity"
+    "platform/http/handling"
+    "platform/logging"
+    "platform/services"
+    "platform/templates"
+)
+
+func RegisterAuthorizerService(policies PolicySet) {
+    err := services.AddSingleton(func() PolicySet {
+        return policies
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddScoped(func(user identity.User, policies PolicySet,
+            logger logging.Logger) Authorizer {
+        return NewAuthorizer(user, policies, logger)
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddSingleton(func() handling.ActionFilter {
+        return &policyActionFilter{}
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = templates.AddContextFunc("can", 
+        func(authorizer Authorizer) func(string, ...interface{}) bool {
+            return func(policy string, resource ...interface{}) bool {
+                if len(resource) > 0 {
+                    return authorizer.AuthorizeResource(policy, resource[0])
+                }
+                return authorizer.Authorize(policy)
+            }
+        })
+    if (err != nil) {
+        panic(err)
+    }
+}
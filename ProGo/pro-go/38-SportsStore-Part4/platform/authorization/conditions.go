@@ -0,0 +1,97 @@
+package authorization
+
+import (
+    "platform/authorization/identity"
+)
+
+func AllOf(conditions ...identity.AuthorizationCondition) identity.AuthorizationCondition {
+    return &compositeCondition{ conditions: conditions, all: true }
+}
+
+func AnyOf(conditions ...identity.AuthorizationCondition) identity.AuthorizationCondition {
+    return &compositeCondition{ conditions: conditions }
+}
+
+func Not(condition identity.AuthorizationCondition) identity.AuthorizationCondition {
+    return &notCondition{ condition: condition }
+}
+
+func NewAuthenticatedCondition() identity.AuthorizationCondition {
+    return NewFuncCondition(func(user identity.User) bool {
+        return user.IsAuthenticated()
+    })
+}
+
+func NewClaimCondition(claimType string, 
+        values ...string) identity.AuthorizationCondition {
+    return NewFuncCondition(func(user identity.User) bool {
+        return identity.HasClaim(user, claimType, values...)
+    })
+}
+
+func NewFuncCondition(
+        validate func(identity.User) bool) identity.AuthorizationCondition {
+    return funcCondition(validate)
+}
+
+func NewResourceCondition(validate func(user identity.User, 
+        resource interface{}) bool) identity.AuthorizationCondition {
+    return resourceCondition(validate)
+}
+
+func ValidateResource(condition identity.AuthorizationCondition, 
+        user identity.User, resource interface{}) bool {
+    if resCondition, ok := condition.(identity.ResourceCondition); ok {
+        return resCondition.ValidateResource(user, resource)
+    }
+    return condition.Validate(user)
+}
+
+type compositeCondition struct {
+    conditions []identity.AuthorizationCondition
+    all bool
+}
+
+func (c *compositeCondition) Validate(user identity.User) bool {
+    return c.ValidateResource(user, nil)
+}
+
+func (c *compositeCondition) ValidateResource(user identity.User, 
+        resource interface{}) bool {
+    for _, condition := range c.conditions {
+        if ValidateResource(condition, user, resource) != c.all {
+            return !c.all
+        }
+    }
+    return c.all
+}
+
+type notCondition struct {
+    condition identity.AuthorizationCondition
+}
+
+func (c *notCondition) Validate(user identity.User) bool {
+    return c.ValidateResource(user, nil)
+}
+
+func (c *notCondition) ValidateResource(user identity.User, 
+        resource interface{}) bool {
+    return !ValidateResource(c.condition, user, resource)
+}
+
+type funcCondition func(identity.User) bool
+
+func (f funcCondition) Validate(user identity.User) bool {
+    return f(user)
+}
+
+type resourceCondition func(identity.User, interface{}) bool
+
+func (f resourceCondition) Validate(user identity.User) bool {
+    return f(user, nil)
+}
+
+func (f resourceCondition) ValidateResource(user identity.User, 
+        resource interface{}) bool {
+    return f(user, resource)
+}
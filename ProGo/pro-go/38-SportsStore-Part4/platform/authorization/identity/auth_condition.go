@@ -4,3 +4,8 @@ type AuthorizationCondition interface {
 
     Validate(user User) bool
 }
+
+type ResourceCondition interface {
+
+    ValidateResource(user User, resource interface{}) bool
+}
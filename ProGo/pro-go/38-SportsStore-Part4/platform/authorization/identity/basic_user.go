@@ -13,10 +13,21 @@ func NewBasicUser(id int, name string, roles ...string) User {
     }
 }
 
+func NewClaimsUser(id int, name string, roles []string, claims ...Claim) User {
+    return &basicUser {
+        Id: id,
+        Name: name,
+        Roles: roles,
+        Claims: claims,
+        Authenticated: true,
+    }
+}
+
 type basicUser struct {
     Id int
     Name string
     Roles []string
+    Claims []Claim
     Authenticated bool
 }
 
@@ -40,3 +51,7 @@ func (user *basicUser) InRole(role string) bool {
 func (user *basicUser) IsAuthenticated() bool {
     return user.Authenticated
 }
+
+func (user *basicUser) GetClaims() []Claim {
+    return user.Claims
+}
@@ -0,0 +1,33 @@
+package identity
+
+import "strings"
+
+type Claim struct {
+    Type string
+    Value string
+}
+
+func HasClaim(user User, claimType string, values ...string) bool {
+    for _, claim := range user.GetClaims() {
+        if strings.EqualFold(claim.Type, claimType) {
+            if len(values) == 0 {
+                return true
+            }
+            for _, val := range values {
+                if claim.Value == val {
+                    return true
+                }
+            }
+        }
+    }
+    return false
+}
+
+func GetClaimValue(user User, claimType string) (value string, found bool) {
+    for _, claim := range user.GetClaims() {
+        if strings.EqualFold(claim.Type, claimType) {
+            return claim.Value, true
+        }
+    }
+    return
+}
@@ -9,4 +9,6 @@ type User interface {
     InRole(name string) bool
 
     IsAuthenticated() bool
+
+    GetClaims() []Claim
 }
@@ -0,0 +1,79 @@
+package authorization
+
+import (
+    "crypto/hmac"
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/subtle"
+    "encoding/base64"
+    "encoding/binary"
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+const (
+    passwordScheme = "pbkdf2-sha256"
+    passwordIterations = 100000
+    passwordSaltLength = 16
+    passwordKeyLength = 32
+)
+
+func HashPassword(password string) (string, error) {
+    salt := make([]byte, passwordSaltLength)
+    if _, err := rand.Read(salt); err != nil {
+        return "", err
+    }
+    key := pbkdf2([]byte(password), salt, passwordIterations, passwordKeyLength)
+    return fmt.Sprintf("%v$%v$%v$%v", passwordScheme, passwordIterations,
+        base64.RawStdEncoding.EncodeToString(salt),
+        base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func CheckPassword(hash, password string) bool {
+    parts := strings.Split(hash, "$")
+    if len(parts) != 4 || parts[0] != passwordScheme {
+        return false
+    }
+    iterations, err := strconv.Atoi(parts[1])
+    if err != nil || iterations < 1 {
+        return false
+    }
+    salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+    if err != nil {
+        return false
+    }
+    expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+    if err != nil {
+        return false
+    }
+    key := pbkdf2([]byte(password), salt, iterations, len(expected))
+    return subtle.ConstantTimeCompare(key, expected) == 1
+}
+
+func pbkdf2(password, salt []byte, iterations, keyLength int) []byte {
+    prf := hmac.New(sha256.New, password)
+    hashLength := prf.Size()
+    blocks := (keyLength + hashLength - 1) / hashLength
+    key := make([]byte, 0, blocks * hashLength)
+    counter := make([]byte, 4)
+    for block := 1; block <= blocks; block++ {
+        binary.BigEndian.PutUint32(counter, uint32(block))
+        prf.Reset()
+        prf.Write(salt)
+        prf.Write(counter)
+        u := prf.Sum(nil)
+        t := make([]byte, len(u))
+        copy(t, u)
+        for i := 1; i < iterations; i++ {
+            prf.Reset()
+            prf.Write(u)
+            u = prf.Sum(u[:0])
+            for j := range t {
+                t[j] ^= u[j]
+            }
+        }
+        key = append(key, t...)
+    }
+    return key[:keyLength]
+}
@@ -0,0 +1,57 @@
+package authorization
+
+import (
+    "encoding/hex"
+    "strings"
+    "testing"
+)
+
+func TestPbkdf2KnownVector(t *testing.T) {
+    key := pbkdf2([]byte("password"), []byte("salt"), 1, 32)
+    expected := "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"
+    if hex.EncodeToString(key) != expected {
+        t.Fatalf("Unexpected key: %x", key)
+    }
+}
+
+func TestHashPassword(t *testing.T) {
+    first, err := HashPassword("mysecret")
+    if err != nil {
+        t.Fatal(err)
+    }
+    second, err := HashPassword("mysecret")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if first == second {
+        t.Fatal("Expected a different salt for each hash")
+    }
+    if !strings.HasPrefix(first, passwordScheme + "$") {
+        t.Fatalf("Unexpected hash format: %v", first)
+    }
+    if !CheckPassword(first, "mysecret") || !CheckPassword(second, "mysecret") {
+        t.Fatal("Expected password to match hash")
+    }
+    if CheckPassword(first, "MySecret") || CheckPassword(first, "") {
+        t.Fatal("Expected wrong password to be rejected")
+    }
+}
+
+func TestCheckPasswordMalformed(t *testing.T) {
+    hash, _ := HashPassword("mysecret")
+    parts := strings.Split(hash, "$")
+    for _, malformed := range []string {
+        "",
+        "mysecret",
+        strings.Join(append([]string { "md5" }, parts[1:]...), "$"),
+        strings.Join([]string { parts[0], "0", parts[2], parts[3] }, "$"),
+        strings.Join([]string { parts[0], "x", parts[2], parts[3] }, "$"),
+        strings.Join([]string { parts[0], parts[1], "!!", parts[3] }, "$"),
+        strings.Join([]string { parts[0], parts[1], parts[2], "!!" }, "$"),
+        hash + "$extra",
+    } {
+        if CheckPassword(malformed, "mysecret") {
+            t.Fatalf("Expected malformed hash to be rejected: %v", malformed)
+        }
+    }
+}
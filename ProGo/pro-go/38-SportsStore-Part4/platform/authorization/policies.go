@@ -0,0 +1,50 @@
+package authorization
+
+import (
+    "platform/authorization/identity"
+    "platform/services"
+    "strings"
+)
+
+const DefaultActionPolicy = "*"
+
+type PolicyProvider interface {
+
+    Policies() map[string]string
+}
+
+type PolicySet map[string]identity.AuthorizationCondition
+
+func (set PolicySet) Get(name string) (condition identity.AuthorizationCondition, 
+        found bool) {
+    condition, found = set[name]
+    return
+}
+
+func ActionPolicy(handler interface{}, methodName string) (policy string, 
+        found bool) {
+    provider, ok := handler.(PolicyProvider)
+    if !ok {
+        return
+    }
+    policies := provider.Policies()
+    for method, name := range policies {
+        if strings.EqualFold(method, methodName) {
+            return name, true
+        }
+    }
+    policy, found = policies[DefaultActionPolicy]
+    return
+}
+
+func NewPolicyCondition(name string) identity.AuthorizationCondition {
+    return NewResourceCondition(func(user identity.User, resource interface{}) bool {
+        var policies PolicySet
+        if services.GetService(&policies) == nil {
+            if condition, found := policies.Get(name); found {
+                return ValidateResource(condition, user, resource)
+            }
+        }
+        return false
+    })
+}
@@ -0,0 +1,229 @@
+package cache
+
+import (
+    "container/list"
+    "context"
+    "errors"
+    "strings"
+    "sync"
+    "time"
+)
+
+var ErrLoadFailed = errors.New("Cache load did not complete")
+
+const DefaultLoadTimeout = 30 * time.Second
+
+type Stats struct {
+    Hits, Misses, Shared uint64
+    Evictions, Expirations uint64
+    Entries, Capacity int
+}
+
+func (s Stats) HitPercent() float64 {
+    if total := s.Hits + s.Misses; total > 0 {
+        return float64(s.Hits) * 100 / float64(total)
+    }
+    return 0
+}
+
+type entry struct {
+    key string
+    value interface{}
+    expires time.Time
+}
+
+type call struct {
+    done chan struct{}
+    value interface{}
+    err error
+}
+
+type Cache struct {
+    mutex sync.Mutex
+    capacity int
+    entries map[string]*list.Element
+    order *list.List
+    calls map[string]*call
+    generation uint64
+    loadTimeout time.Duration
+    stats Stats
+}
+
+func New(capacity int) *Cache {
+    if capacity < 1 {
+        capacity = 1
+    }
+    return &Cache{
+        capacity: capacity,
+        entries: map[string]*list.Element {},
+        order: list.New(),
+        calls: map[string]*call {},
+        loadTimeout: DefaultLoadTimeout,
+    }
+}
+
+func (c *Cache) SetLoadTimeout(timeout time.Duration) {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    if timeout <= 0 {
+        timeout = DefaultLoadTimeout
+    }
+    c.loadTimeout = timeout
+}
+
+func (c *Cache) Get(key string) (value interface{}, found bool) {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    if value, found = c.lookup(key); found {
+        c.stats.Hits++
+    } else {
+        c.stats.Misses++
+    }
+    return
+}
+
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    c.store(key, value, ttl)
+}
+
+func (c *Cache) GetOrLoad(ctx context.Context, key string, ttl time.Duration,
+        load func(context.Context) (interface{}, error)) (interface{}, error) {
+    c.mutex.Lock()
+    if value, found := c.lookup(key); found {
+        c.stats.Hits++
+        c.mutex.Unlock()
+        return value, nil
+    }
+    c.stats.Misses++
+    pending, found := c.calls[key]
+    if found {
+        c.stats.Shared++
+    } else {
+        pending = &call{ done: make(chan struct{}), err: ErrLoadFailed }
+        c.calls[key] = pending
+        loadCtx, cancel := context.WithCancel(detach(ctx))
+        go c.run(loadCtx, cancel, c.loadTimeout, key, pending, c.generation, ttl, 
+            load)
+    }
+    c.mutex.Unlock()
+    select {
+        case <- pending.done:
+            return pending.value, pending.err
+        case <- ctx.Done():
+            return nil, ctx.Err()
+    }
+}
+
+func (c *Cache) run(ctx context.Context, cancel context.CancelFunc, 
+        timeout time.Duration, key string, pending *call, generation uint64, 
+        ttl time.Duration, load func(context.Context) (interface{}, error)) {
+    // The sqlite driver watches the context from a goroutine that can still see 
+    // a cancellation after the rows are closed and the connection is back in the 
+    // pool, where it interrupts the next query. So the context is only cancelled 
+    // when the load times out and the timer is stopped once the load returns.
+    timer := time.AfterFunc(timeout, cancel)
+    defer c.finish(key, pending, generation, ttl)
+    defer func() {
+        if !timer.Stop() && errors.Is(pending.err, context.Canceled) {
+            pending.err = context.DeadlineExceeded
+        }
+    }()
+    defer func() {
+        if recover() != nil {
+            pending.value, pending.err = nil, ErrLoadFailed
+        }
+    }()
+    pending.value, pending.err = load(ctx)
+}
+
+func (c *Cache) finish(key string, pending *call, generation uint64,
+        ttl time.Duration) {
+    c.mutex.Lock()
+    delete(c.calls, key)
+    if pending.err == nil && generation == c.generation {
+        c.store(key, pending.value, ttl)
+    }
+    c.mutex.Unlock()
+    close(pending.done)
+}
+
+func (c *Cache) Invalidate(prefixes ...string) {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    c.generation++
+    for key, elem := range c.entries {
+        for _, prefix := range prefixes {
+            if strings.HasPrefix(key, prefix) {
+                c.remove(elem)
+                break
+            }
+        }
+    }
+}
+
+func (c *Cache) Clear() {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    c.generation++
+    c.entries = map[string]*list.Element {}
+    c.order.Init()
+}
+
+func (c *Cache) Stats() Stats {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    stats := c.stats
+    stats.Entries, stats.Capacity = c.order.Len(), c.capacity
+    return stats
+}
+
+func (c *Cache) lookup(key string) (interface{}, bool) {
+    if elem, found := c.entries[key]; found {
+        e := elem.Value.(*entry)
+        if time.Now().Before(e.expires) {
+            c.order.MoveToFront(elem)
+            return e.value, true
+        }
+        c.remove(elem)
+        c.stats.Expirations++
+    }
+    return nil, false
+}
+
+func (c *Cache) store(key string, value interface{}, ttl time.Duration) {
+    if ttl <= 0 {
+        return
+    }
+    expires := time.Now().Add(ttl)
+    if elem, found := c.entries[key]; found {
+        e := elem.Value.(*entry)
+        e.value, e.expires = value, expires
+        c.order.MoveToFront(elem)
+        return
+    }
+    c.entries[key] = c.order.PushFront(&entry{ key: key, value: value,
+        expires: expires })
+    for c.order.Len() > c.capacity {
+        c.remove(c.order.Back())
+        c.stats.Evictions++
+    }
+}
+
+func (c *Cache) remove(elem *list.Element) {
+    c.order.Remove(elem)
+    delete(c.entries, elem.Value.(*entry).key)
+}
+
+type detachedContext struct {
+    context.Context
+}
+
+func detach(ctx context.Context) context.Context {
+    return detachedContext{ Context: ctx }
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) { return }
+func (detachedContext) Done() <-chan struct{} { return nil }
+func (detachedContext) Err() error { return nil }
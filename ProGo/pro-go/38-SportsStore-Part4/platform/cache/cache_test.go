@@ -0,0 +1,165 @@
+package cache
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestCacheEntries(t *testing.T) {
+    c := New(2)
+    c.Set("a", 1, time.Minute)
+    c.Set("b", 2, time.Minute)
+    if val, found := c.Get("a"); !found || val != 1 {
+        t.Fatalf("Expected cached value, got %v %v", val, found)
+    }
+    c.Set("c", 3, time.Minute)
+    if _, found := c.Get("b"); found {
+        t.Fatal("Expected least recently used entry to be evicted")
+    }
+    c.Set("d", 4, time.Nanosecond)
+    time.Sleep(time.Millisecond)
+    if _, found := c.Get("d"); found {
+        t.Fatal("Expected entry to expire")
+    }
+    c.Set("e", 5, 0)
+    if _, found := c.Get("e"); found {
+        t.Fatal("Expected zero TTL not to be cached")
+    }
+    c.Set("prefix:a", 6, time.Minute)
+    c.Invalidate("prefix:")
+    if _, found := c.Get("prefix:a"); found {
+        t.Fatal("Expected prefix to be invalidated")
+    }
+    if stats := c.Stats(); stats.Evictions < 1 || stats.Expirations != 1 ||
+            stats.Capacity != 2 {
+        t.Fatalf("Unexpected stats: %+v", stats)
+    }
+}
+
+func TestGetOrLoadShared(t *testing.T) {
+    c := New(10)
+    release := make(chan struct{})
+    var mutex sync.Mutex
+    loads := 0
+    load := func(ctx context.Context) (interface{}, error) {
+        mutex.Lock()
+        loads++
+        mutex.Unlock()
+        <- release
+        return "value", nil
+    }
+    var wg sync.WaitGroup
+    for i := 0; i < 5; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if val, err := c.GetOrLoad(context.Background(), "key", time.Minute,
+                    load); err != nil || val != "value" {
+                t.Errorf("Unexpected result: %v %v", val, err)
+            }
+        }()
+    }
+    time.Sleep(10 * time.Millisecond)
+    close(release)
+    wg.Wait()
+    if loads != 1 {
+        t.Fatalf("Expected one shared load, got %v", loads)
+    }
+    if val, found := c.Get("key"); !found || val != "value" {
+        t.Fatal("Expected loaded value to be cached")
+    }
+}
+
+func TestGetOrLoadDetachedFromCaller(t *testing.T) {
+    c := New(10)
+    started, release := make(chan struct{}), make(chan struct{})
+    var loadErr error
+    load := func(ctx context.Context) (interface{}, error) {
+        close(started)
+        <- release
+        loadErr = ctx.Err()
+        return "value", nil
+    }
+    leaderCtx, cancel := context.WithCancel(context.Background())
+    leaderDone := make(chan error)
+    go func() {
+        _, err := c.GetOrLoad(leaderCtx, "key", time.Minute, load)
+        leaderDone <- err
+    }()
+    <- started
+    followerDone := make(chan interface{})
+    go func() {
+        val, _ := c.GetOrLoad(context.Background(), "key", time.Minute, load)
+        followerDone <- val
+    }()
+    cancel()
+    if err := <- leaderDone; !errors.Is(err, context.Canceled) {
+        t.Fatalf("Expected leader to see its own cancellation, got %v", err)
+    }
+    close(release)
+    if val := <- followerDone; val != "value" {
+        t.Fatalf("Expected follower to receive loaded value, got %v", val)
+    }
+    if loadErr != nil {
+        t.Fatalf("Expected load context to outlive the caller, got %v", loadErr)
+    }
+}
+
+func TestGetOrLoadTimeout(t *testing.T) {
+    c := New(10)
+    c.SetLoadTimeout(10 * time.Millisecond)
+    val, err := c.GetOrLoad(context.Background(), "key", time.Minute,
+        func(ctx context.Context) (interface{}, error) {
+            <- ctx.Done()
+            return nil, ctx.Err()
+        })
+    if !errors.Is(err, context.DeadlineExceeded) || val != nil {
+        t.Fatalf("Expected load timeout, got %v %v", val, err)
+    }
+    if _, found := c.Get("key"); found {
+        t.Fatal("Expected failed load not to be cached")
+    }
+}
+
+func TestGetOrLoadStopsTimeout(t *testing.T) {
+    c := New(10)
+    c.SetLoadTimeout(10 * time.Millisecond)
+    var loadCtx context.Context
+    if _, err := c.GetOrLoad(context.Background(), "key", time.Minute,
+            func(ctx context.Context) (interface{}, error) {
+                loadCtx = ctx
+                return "value", nil
+            }); err != nil {
+        t.Fatal(err)
+    }
+    select {
+        case <- loadCtx.Done():
+            t.Fatalf("Expected completed load not to be cancelled: %v", loadCtx.Err())
+        case <- time.After(50 * time.Millisecond):
+    }
+}
+
+func TestGetOrLoadInvalidatedDuringLoad(t *testing.T) {
+    c := New(10)
+    val, err := c.GetOrLoad(context.Background(), "key", time.Minute,
+        func(ctx context.Context) (interface{}, error) {
+            c.Invalidate("key")
+            return "stale", nil
+        })
+    if err != nil || val != "stale" {
+        t.Fatalf("Unexpected result: %v %v", val, err)
+    }
+    if _, found := c.Get("key"); found {
+        t.Fatal("Expected value loaded before invalidation not to be cached")
+    }
+    _, err = c.GetOrLoad(context.Background(), "panic", time.Minute,
+        func(ctx context.Context) (interface{}, error) {
+            panic("load failed")
+        })
+    if err != ErrLoadFailed {
+        t.Fatalf("Expected ErrLoadFailed, got %v", err)
+    }
+}
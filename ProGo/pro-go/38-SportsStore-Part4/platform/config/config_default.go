@@ -1,6 +1,9 @@
 package config
 
-import "strings"
+import (
+    "sort"
+    "strings"
+)
 
 type DefaultConfig struct {
     configData map[string]interface{}
@@ -52,3 +55,15 @@ func (c *DefaultConfig) GetFloat(name string) (result float64, found bool) {
     if (found) { result = value.(float64) }
     return
 }
+
+func (c *DefaultConfig) Keys() (keys []string) {
+    for key := range c.configData {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return
+}
+
+func NewMapConfig(data map[string]interface{}) Configuration {
+    return &DefaultConfig{ configData: data }
+}
@@ -0,0 +1,30 @@
+package config
+
+func Flatten(c Configuration) map[string]interface{} {
+    values := map[string]interface{} {}
+    flatten(c, "", values)
+    return values
+}
+
+func flatten(c Configuration, prefix string, values map[string]interface{}) {
+    for _, key := range c.Keys() {
+        if section, found := c.GetSection(key); found && section != nil {
+            flatten(section, prefix + key + ":", values)
+        } else if val, found := rawValue(c, key); found {
+            values[prefix + key] = val
+        }
+    }
+}
+
+func rawValue(c Configuration, name string) (interface{}, bool) {
+    switch typed := c.(type) {
+        case *DefaultConfig:
+            return typed.get(name)
+        case *OverlayConfig:
+            if val, found := rawValue(typed.overlay, name); found {
+                return val, true
+            }
+            return rawValue(typed.base, name)
+    }
+    return nil, false
+}
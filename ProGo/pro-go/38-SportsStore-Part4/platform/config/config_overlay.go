@@ -0,0 +1,94 @@
+package config
+
+import "sort"
+
+type OverlayConfig struct {
+    base Configuration
+    overlay Configuration
+}
+
+func NewOverlayConfig(base, overlay Configuration) Configuration {
+    if overlay == nil {
+        return base
+    }
+    return &OverlayConfig{ base: base, overlay: overlay }
+}
+
+func (c *OverlayConfig) GetString(name string) (string, bool) {
+    if val, found := c.overlay.GetString(name); found {
+        return val, true
+    }
+    return c.base.GetString(name)
+}
+
+func (c *OverlayConfig) GetInt(name string) (int, bool) {
+    if val, found := c.overlay.GetInt(name); found {
+        return val, true
+    }
+    return c.base.GetInt(name)
+}
+
+func (c *OverlayConfig) GetBool(name string) (bool, bool) {
+    if val, found := c.overlay.GetBool(name); found {
+        return val, true
+    }
+    return c.base.GetBool(name)
+}
+
+func (c *OverlayConfig) GetFloat(name string) (float64, bool) {
+    if val, found := c.overlay.GetFloat(name); found {
+        return val, true
+    }
+    return c.base.GetFloat(name)
+}
+
+func (c *OverlayConfig) GetStringDefault(name, val string) string {
+    if result, found := c.GetString(name); found {
+        return result
+    }
+    return val
+}
+
+func (c *OverlayConfig) GetIntDefault(name string, val int) int {
+    if result, found := c.GetInt(name); found {
+        return result
+    }
+    return val
+}
+
+func (c *OverlayConfig) GetBoolDefault(name string, val bool) bool {
+    if result, found := c.GetBool(name); found {
+        return result
+    }
+    return val
+}
+
+func (c *OverlayConfig) GetFloatDefault(name string, val float64) float64 {
+    if result, found := c.GetFloat(name); found {
+        return result
+    }
+    return val
+}
+
+func (c *OverlayConfig) GetSection(name string) (Configuration, bool) {
+    baseSection, baseFound := c.base.GetSection(name)
+    overlaySection, overlayFound := c.overlay.GetSection(name)
+    if baseSection != nil && overlaySection != nil {
+        return NewOverlayConfig(baseSection, overlaySection), true
+    } else if overlaySection != nil {
+        return overlaySection, overlayFound
+    }
+    return baseSection, baseFound
+}
+
+func (c *OverlayConfig) Keys() (keys []string) {
+    seen := map[string]bool {}
+    for _, key := range append(c.base.Keys(), c.overlay.Keys()...) {
+        if !seen[key] {
+            seen[key] = true
+            keys = append(keys, key)
+        }
+    }
+    sort.Strings(keys)
+    return
+}
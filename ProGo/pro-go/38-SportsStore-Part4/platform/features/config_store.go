@@ -0,0 +1,94 @@
+package features
+
+import (
+    "context"
+    "platform/config"
+    "sort"
+    "sync"
+)
+
+type ConfigFlagStore struct {
+    config config.Configuration
+    mutex sync.RWMutex
+    overrides map[string]Flag
+}
+
+func NewConfigFlagStore(cfg config.Configuration) *ConfigFlagStore {
+    return &ConfigFlagStore{ config: cfg, overrides: map[string]Flag {} }
+}
+
+func (store *ConfigFlagStore) GetFlags(ctx context.Context) ([]Flag, error) {
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+    flags := map[string]Flag {}
+    if section, ok := store.config.GetSection("features:flags"); ok {
+        for _, name := range section.Keys() {
+            if flagSection, ok := section.GetSection(name); ok {
+                flags[name] = Flag{
+                    Name: name,
+                    Description: flagSection.GetStringDefault("description", ""),
+                    Enabled: flagSection.GetBoolDefault("enabled", false),
+                    Percentage: flagSection.GetIntDefault("percentage", 100),
+                    KeyBy: flagSection.GetStringDefault("keyBy", KeyBySession),
+                }.Normalize()
+            }
+        }
+    }
+    store.mutex.RLock()
+    for name, flag := range store.overrides {
+        flags[name] = flag
+    }
+    store.mutex.RUnlock()
+    return sortFlags(flags), nil
+}
+
+func (store *ConfigFlagStore) SaveFlag(ctx context.Context, flag Flag) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    store.mutex.Lock()
+    defer store.mutex.Unlock()
+    store.overrides[flag.Name] = flag.Normalize()
+    return nil
+}
+
+type OverlayFlagStore struct {
+    Defaults FlagStore
+    Overrides FlagStore
+}
+
+func (store *OverlayFlagStore) GetFlags(ctx context.Context) ([]Flag, error) {
+    defaults, err := store.Defaults.GetFlags(ctx)
+    if err != nil {
+        return nil, err
+    }
+    overrides, err := store.Overrides.GetFlags(ctx)
+    if err != nil {
+        return nil, err
+    }
+    flags := map[string]Flag {}
+    for _, flag := range defaults {
+        flags[flag.Name] = flag
+    }
+    for _, flag := range overrides {
+        if def, ok := flags[flag.Name]; ok && flag.Description == "" {
+            flag.Description = def.Description
+        }
+        flags[flag.Name] = flag
+    }
+    return sortFlags(flags), nil
+}
+
+func (store *OverlayFlagStore) SaveFlag(ctx context.Context, flag Flag) error {
+    return store.Overrides.SaveFlag(ctx, flag.Normalize())
+}
+
+func sortFlags(flags map[string]Flag) []Flag {
+    sorted := make([]Flag, 0, len(flags))
+    for _, flag := range flags {
+        sorted = append(sorted, flag)
+    }
+    sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+    return sorted
+}
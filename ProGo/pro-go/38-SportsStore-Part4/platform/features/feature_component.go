@@ -0,0 +1,22 @@
+package features
+
+import (
+    "platform/pipeline"
+    "platform/services"
+    "platform/sessions"
+)
+
+type FeatureComponent struct {}
+
+func (c *FeatureComponent) Init() {}
+
+func (c *FeatureComponent) ProcessRequest(ctx *pipeline.ComponentContext,
+        next func(*pipeline.ComponentContext)) {
+    if ctx.Request.Context().Value(sessions.SESSION__CONTEXT_KEY) != nil {
+        var session sessions.Session
+        if services.GetServiceForContext(ctx.Request.Context(), &session) == nil {
+            SessionKey(session)
+        }
+    }
+    next(ctx)
+}
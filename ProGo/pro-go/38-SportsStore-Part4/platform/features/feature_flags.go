@@ -0,0 +1,135 @@
+package features
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "fmt"
+    "hash/fnv"
+    "platform/authorization/identity"
+    "platform/logging"
+    "platform/services"
+    "platform/sessions"
+    "sync"
+)
+
+const SESSION_KEY = "feature_key"
+
+type FeatureFlags interface {
+    IsEnabled(name string) bool
+    Flags() []Flag
+}
+
+type requestFeatureFlags struct {
+    context.Context
+    store FlagStore
+    logger logging.Logger
+    logEvaluations bool
+    mutex sync.Mutex
+    flags []Flag
+    loaded bool
+    results map[string]bool
+}
+
+func NewFeatureFlags(ctx context.Context, store FlagStore, logger logging.Logger,
+        logEvaluations bool) FeatureFlags {
+    return &requestFeatureFlags{ Context: ctx, store: store, logger: logger,
+        logEvaluations: logEvaluations, results: map[string]bool {} }
+}
+
+func (ff *requestFeatureFlags) Flags() []Flag {
+    ff.mutex.Lock()
+    defer ff.mutex.Unlock()
+    return append([]Flag {}, ff.load()...)
+}
+
+func (ff *requestFeatureFlags) IsEnabled(name string) bool {
+    ff.mutex.Lock()
+    defer ff.mutex.Unlock()
+    if result, ok := ff.results[name]; ok {
+        return result
+    }
+    flag, found := Flag{}, false
+    for _, f := range ff.load() {
+        if f.Name == name {
+            flag, found = f, true
+            break
+        }
+    }
+    result, reason := false, "unknown flag"
+    if found {
+        result, reason = ff.evaluate(flag)
+    }
+    ff.results[name] = result
+    logf := ff.logger.Debugf
+    if ff.logEvaluations {
+        logf = ff.logger.Infof
+    }
+    logf("Feature %v evaluated to %v (%v)", name, result, reason)
+    return result
+}
+
+func (ff *requestFeatureFlags) load() []Flag {
+    if !ff.loaded {
+        flags, err := ff.store.GetFlags(ff.Context)
+        if err != nil {
+            ff.logger.Warnf("Cannot load feature flags: %v", err.Error())
+        }
+        ff.flags, ff.loaded = flags, true
+    }
+    return ff.flags
+}
+
+func (ff *requestFeatureFlags) evaluate(flag Flag) (bool, string) {
+    if !flag.Enabled {
+        return false, "disabled"
+    } else if flag.Percentage >= 100 {
+        return true, "enabled"
+    } else if flag.Percentage <= 0 {
+        return false, "0% rollout"
+    }
+    key := ff.rolloutKey(flag.KeyBy)
+    if key == "" {
+        return false, "no rollout key"
+    }
+    bucket := Bucket(flag.Name, key)
+    return bucket < flag.Percentage, 
+        fmt.Sprintf("bucket %v for %v, %v%% rollout", bucket, key, flag.Percentage)
+}
+
+func (ff *requestFeatureFlags) rolloutKey(keyBy string) string {
+    if ff.Context == nil || ff.Context.Value(sessions.SESSION__CONTEXT_KEY) == nil {
+        return ""
+    }
+    if keyBy == KeyByUser {
+        var user identity.User
+        if services.GetServiceForContext(ff.Context, &user) == nil && 
+                user.IsAuthenticated() {
+            return fmt.Sprintf("user:%v", user.GetID())
+        }
+    }
+    var session sessions.Session
+    if services.GetServiceForContext(ff.Context, &session) != nil {
+        return ""
+    }
+    return "session:" + SessionKey(session)
+}
+
+func SessionKey(session sessions.Session) string {
+    key, _ := session.GetValue(SESSION_KEY).(string)
+    if key == "" {
+        data := make([]byte, 8)
+        if _, err := rand.Read(data); err != nil {
+            return ""
+        }
+        key = hex.EncodeToString(data)
+        session.SetValue(SESSION_KEY, key)
+    }
+    return key
+}
+
+func Bucket(name, key string) int {
+    hash := fnv.New32a()
+    hash.Write([]byte(name + ":" + key))
+    return int(hash.Sum32() % 100)
+}
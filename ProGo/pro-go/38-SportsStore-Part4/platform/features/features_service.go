@@ -0,0 +1,43 @@
+package features
+
+import (
+    "context"
+    "platform/config"
+    "platform/logging"
+    "platform/services"
+    "platform/templates"
+    "sync"
+)
+
+func RegisterFeatureServices() {
+    stores := map[config.Configuration]FlagStore {}
+    lock := sync.Mutex {}
+    err := services.AddScoped(func(cfg config.Configuration) FlagStore {
+        lock.Lock()
+        defer lock.Unlock()
+        store, found := stores[cfg]
+        if !found {
+            store = NewConfigFlagStore(cfg)
+            stores[cfg] = store
+        }
+        return store
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddScoped(func(ctx context.Context, store FlagStore,
+            logger logging.Logger, cfg config.Configuration) FeatureFlags {
+        return NewFeatureFlags(ctx, store, logger, 
+            cfg.GetBoolDefault("features:logEvaluations", false))
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = templates.AddContextFunc("feature", 
+        func(flags FeatureFlags) func(string) bool {
+            return flags.IsEnabled
+        })
+    if (err != nil) {
+        panic(err)
+    }
+}
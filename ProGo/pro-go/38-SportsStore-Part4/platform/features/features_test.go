@@ -0,0 +1,233 @@
+package features
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "platform/authorization/identity"
+    "platform/config"
+    "platform/logging"
+    "platform/platformtest"
+    "platform/services"
+    "platform/sessions"
+    "testing"
+    gorilla "github.com/gorilla/sessions"
+)
+
+type stubFlagStore struct {
+    flags []Flag
+    err error
+    loads int
+    saved []Flag
+}
+
+func (store *stubFlagStore) GetFlags(ctx context.Context) ([]Flag, error) {
+    store.loads++
+    return store.flags, store.err
+}
+
+func (store *stubFlagStore) SaveFlag(ctx context.Context, flag Flag) error {
+    store.saved = append(store.saved, flag)
+    return store.err
+}
+
+type warningLogger struct {
+    logging.Logger
+    warnings []string
+}
+
+func (logger *warningLogger) Warnf(msg string, args ...interface{}) {
+    logger.warnings = append(logger.warnings, fmt.Sprintf(msg, args...))
+}
+
+func testLogger() logging.Logger {
+    return logging.NewDefaultLogger(platformtest.ConfigValues(
+        map[string]interface{} { "logging:level": "none" }))
+}
+
+func flagsFor(ctx context.Context, flags ...Flag) FeatureFlags {
+    return NewFeatureFlags(ctx, &stubFlagStore{ flags: flags }, testLogger(), false)
+}
+
+func sessionContext(t *testing.T, user identity.User) context.Context {
+    platformtest.New(t, config.NewMapConfig(map[string]interface{} {}))
+    sessions.RegisterSessionService()
+    services.AddScoped(func() identity.User { return user })
+    ctx := context.WithValue(context.Background(), sessions.SESSION__CONTEXT_KEY,
+        gorilla.NewSession(nil, "test"))
+    return services.NewServiceContext(ctx)
+}
+
+func TestNormalize(t *testing.T) {
+    tests := []struct { flag, expected Flag } {
+        { Flag{ Percentage: -5 }, Flag{ Percentage: 0, KeyBy: KeyBySession } },
+        { Flag{ Percentage: 150, KeyBy: "other" },
+            Flag{ Percentage: 100, KeyBy: KeyBySession } },
+        { Flag{ Percentage: 25, KeyBy: KeyByUser },
+            Flag{ Percentage: 25, KeyBy: KeyByUser } },
+    }
+    for _, test := range tests {
+        if flag := test.flag.Normalize(); flag != test.expected {
+            t.Fatalf("Expected %+v, got %+v", test.expected, flag)
+        }
+    }
+}
+
+func TestConfigFlagStore(t *testing.T) {
+    store := NewConfigFlagStore(platformtest.ConfigValues(map[string]interface{} {
+        "features:flags:beta:description": "Beta pages",
+        "features:flags:beta:enabled": true,
+        "features:flags:beta:percentage": 150,
+        "features:flags:alpha:enabled": true,
+        "features:flags:alpha:percentage": 10,
+        "features:flags:alpha:keyBy": "user",
+        "features:flags:off:description": "Disabled",
+    }))
+    flags, err := store.GetFlags(context.Background())
+    if err != nil {
+        t.Fatal(err)
+    }
+    expected := []Flag {
+        { Name: "alpha", Enabled: true, Percentage: 10, KeyBy: KeyByUser },
+        { Name: "beta", Description: "Beta pages", Enabled: true, Percentage: 100,
+            KeyBy: KeyBySession },
+        { Name: "off", Description: "Disabled", Percentage: 100,
+            KeyBy: KeyBySession },
+    }
+    if fmt.Sprint(flags) != fmt.Sprint(expected) {
+        t.Fatalf("Unexpected flags: %+v", flags)
+    }
+    store.SaveFlag(context.Background(), Flag{ Name: "off", Enabled: true,
+        Percentage: 200 })
+    flags, _ = store.GetFlags(context.Background())
+    if flags[2] != (Flag{ Name: "off", Enabled: true, Percentage: 100,
+            KeyBy: KeyBySession }) {
+        t.Fatalf("Expected override to replace configured flag: %+v", flags[2])
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if _, err := store.GetFlags(ctx); err == nil {
+        t.Fatal("Expected cancelled context to be rejected")
+    }
+    if err := store.SaveFlag(ctx, Flag{ Name: "new" }); err == nil {
+        t.Fatal("Expected cancelled context to be rejected")
+    }
+}
+
+func TestOverlayFlagStore(t *testing.T) {
+    defaults := &stubFlagStore{ flags: []Flag {
+        { Name: "beta", Description: "Beta pages", Enabled: true, Percentage: 100 },
+        { Name: "alpha", Description: "Alpha pages" },
+    }}
+    overrides := &stubFlagStore{ flags: []Flag {
+        { Name: "beta", Percentage: 50 },
+        { Name: "gamma", Enabled: true },
+    }}
+    store := &OverlayFlagStore{ Defaults: defaults, Overrides: overrides }
+    flags, err := store.GetFlags(context.Background())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(flags) != 3 || flags[0].Name != "alpha" ||
+            flags[1] != (Flag{ Name: "beta", Description: "Beta pages",
+                Percentage: 50 }) || flags[2].Name != "gamma" {
+        t.Fatalf("Unexpected flags: %+v", flags)
+    }
+    store.SaveFlag(context.Background(), Flag{ Name: "alpha", Percentage: -1 })
+    if len(overrides.saved) != 1 || overrides.saved[0] != (Flag{ Name: "alpha",
+            KeyBy: KeyBySession }) {
+        t.Fatalf("Expected normalized flag to be saved as override: %+v",
+            overrides.saved)
+    }
+    overrides.err = errors.New("unavailable")
+    if _, err := store.GetFlags(context.Background()); err == nil {
+        t.Fatal("Expected override error to be returned")
+    }
+}
+
+func TestIsEnabled(t *testing.T) {
+    store := &stubFlagStore{ flags: []Flag {
+        { Name: "on", Enabled: true, Percentage: 100 },
+        { Name: "disabled", Percentage: 100 },
+        { Name: "none", Enabled: true, Percentage: 0 },
+        { Name: "partial", Enabled: true, Percentage: 50, KeyBy: KeyBySession },
+    }}
+    flags := NewFeatureFlags(context.Background(), store, testLogger(), true)
+    tests := map[string]bool { "on": true, "disabled": false, "none": false,
+        "partial": false, "unknown": false }
+    for name, expected := range tests {
+        if result := flags.IsEnabled(name); result != expected {
+            t.Fatalf("%v: expected %v, got %v", name, expected, result)
+        }
+    }
+    flags.IsEnabled("on")
+    if store.loads != 1 || len(flags.Flags()) != 4 {
+        t.Fatalf("Expected flags to be loaded once, loaded %v", store.loads)
+    }
+}
+
+func TestSessionRollout(t *testing.T) {
+    ctx := sessionContext(t, identity.UnauthenticatedUser)
+    var session sessions.Session
+    services.GetServiceForContext(ctx, &session)
+    key := SessionKey(session)
+    if key == "" || SessionKey(session) != key ||
+            session.GetValue(SESSION_KEY) != key {
+        t.Fatalf("Expected session key to be created once: %v", key)
+    }
+    flag := Flag{ Name: "partial", Enabled: true, KeyBy: KeyBySession,
+        Percentage: Bucket("partial", "session:" + key) + 1 }
+    if !flagsFor(ctx, flag).IsEnabled("partial") {
+        t.Fatal("Expected session in rollout bucket to be enabled")
+    }
+    flag.Percentage--
+    if flagsFor(ctx, flag).IsEnabled("partial") {
+        t.Fatal("Expected session outside rollout bucket to be disabled")
+    }
+    flag.KeyBy = KeyByUser
+    flag.Percentage++
+    if !flagsFor(ctx, flag).IsEnabled("partial") {
+        t.Fatal("Expected anonymous user to fall back to the session key")
+    }
+}
+
+func TestUserRollout(t *testing.T) {
+    ctx := sessionContext(t, identity.NewBasicUser(7, "Alice"))
+    flag := Flag{ Name: "partial", Enabled: true, KeyBy: KeyByUser,
+        Percentage: Bucket("partial", "user:7") + 1 }
+    if !flagsFor(ctx, flag).IsEnabled("partial") {
+        t.Fatal("Expected user in rollout bucket to be enabled")
+    }
+    flag.Percentage--
+    if flagsFor(ctx, flag).IsEnabled("partial") {
+        t.Fatal("Expected user outside rollout bucket to be disabled")
+    }
+}
+
+func TestBucket(t *testing.T) {
+    seen := map[int]bool {}
+    for i := 0; i < 1000; i++ {
+        key := fmt.Sprintf("session:%v", i)
+        bucket := Bucket("beta", key)
+        if bucket < 0 || bucket > 99 || bucket != Bucket("beta", key) {
+            t.Fatalf("Unexpected bucket %v for %v", bucket, key)
+        }
+        seen[bucket] = true
+    }
+    if len(seen) < 90 {
+        t.Fatalf("Expected keys to be spread across buckets, got %v", len(seen))
+    }
+}
+
+func TestStoreError(t *testing.T) {
+    logger := &warningLogger{ Logger: testLogger() }
+    store := &stubFlagStore{ err: errors.New("unavailable") }
+    flags := NewFeatureFlags(context.Background(), store, logger, false)
+    if flags.IsEnabled("beta") || len(flags.Flags()) != 0 || store.loads != 1 {
+        t.Fatal("Expected store error to disable flags")
+    }
+    if len(logger.warnings) != 1 ||
+            logger.warnings[0] != "Cannot load feature flags: unavailable" {
+        t.Fatalf("Unexpected warnings: %v", logger.warnings)
+    }
+}
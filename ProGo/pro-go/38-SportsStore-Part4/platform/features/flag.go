@@ -0,0 +1,38 @@
+package features
+
+import (
+    "context"
+    "errors"
+)
+
+const (
+    KeyBySession = "session"
+    KeyByUser = "user"
+)
+
+var ErrFlagNotFound = errors.New("Feature flag not found")
+
+type Flag struct {
+    Name string
+    Description string
+    Enabled bool
+    Percentage int
+    KeyBy string
+}
+
+func (flag Flag) Normalize() Flag {
+    if flag.Percentage < 0 {
+        flag.Percentage = 0
+    } else if flag.Percentage > 100 {
+        flag.Percentage = 100
+    }
+    if flag.KeyBy != KeyByUser {
+        flag.KeyBy = KeyBySession
+    }
+    return flag
+}
+
+type FlagStore interface {
+    GetFlags(ctx context.Context) ([]Flag, error)
+    SaveFlag(ctx context.Context, flag Flag) error
+}
@@ -0,0 +1,51 @@
+package health
+
+import (
+    "fmt"
+    "reflect"
+    "sync"
+)
+
+type Kind int
+
+const (
+    Liveness Kind = iota
+    Readiness
+)
+
+type Check struct {
+    Name string
+    Kind Kind
+    function interface{}
+}
+
+var checks = []Check {}
+var checksLock = sync.RWMutex{}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func AddCheck(name string, kind Kind, function interface{}) error {
+    fType := reflect.TypeOf(function)
+    if fType == nil || fType.Kind() != reflect.Func || fType.NumOut() != 1 ||
+            !fType.Out(0).Implements(errorType) {
+        return fmt.Errorf("Health check %v must be a function that returns an error",
+            name)
+    }
+    checksLock.Lock()
+    defer checksLock.Unlock()
+    check := Check{ Name: name, Kind: kind, function: function }
+    for i := range checks {
+        if checks[i].Name == name {
+            checks[i] = check
+            return nil
+        }
+    }
+    checks = append(checks, check)
+    return nil
+}
+
+func Checks() []Check {
+    checksLock.RLock()
+    defer checksLock.RUnlock()
+    return append([]Check {}, checks...)
+}
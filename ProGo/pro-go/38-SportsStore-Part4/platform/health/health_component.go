@@ -0,0 +1,52 @@
+package health
+
+import (
+    "encoding/json"
+    "net/http"
+    "platform/config"
+    "platform/pipeline"
+)
+
+type HealthComponent struct {
+    config.Configuration
+    Monitor *Monitor
+    livenessPath string
+    readinessPath string
+}
+
+func (c *HealthComponent) Init() {
+    c.livenessPath = c.Configuration.GetStringDefault("health:livenessPath",
+        "/healthz")
+    c.readinessPath = c.Configuration.GetStringDefault("health:readinessPath",
+        "/readyz")
+}
+
+func (c *HealthComponent) ProcessRequest(ctx *pipeline.ComponentContext,
+        next func(*pipeline.ComponentContext)) {
+    if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+        next(ctx)
+        return
+    }
+    var kind Kind
+    switch ctx.URL.Path {
+        case c.livenessPath:
+            kind = Liveness
+        case c.readinessPath:
+            kind = Readiness
+        default:
+            next(ctx)
+            return
+    }
+    report := c.Monitor.Run(ctx.Context(), kind)
+    status := http.StatusOK
+    if !report.Healthy() {
+        status = http.StatusServiceUnavailable
+    }
+    header := ctx.ResponseWriter.Header()
+    header.Set("Content-Type", "application/json")
+    header.Set("Cache-Control", "no-store")
+    ctx.ResponseWriter.WriteHeader(status)
+    if ctx.Request.Method == http.MethodGet {
+        json.NewEncoder(ctx.ResponseWriter).Encode(report)
+    }
+}
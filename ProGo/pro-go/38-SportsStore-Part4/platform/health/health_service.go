@@ -0,0 +1,39 @@
+package health
+
+import (
+    "platform/config"
+    "platform/http"
+    "platform/logging"
+    "platform/services"
+    "platform/sessions"
+    "platform/templates"
+    "sync"
+)
+
+var shutdownHook = sync.Once{}
+
+func RegisterHealthServices() {
+    err := services.AddSingleton(func(cfg config.Configuration,
+            logger logging.Logger) *Monitor {
+        return NewMonitor(cfg, logger)
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = AddCheck("templates", Readiness, templates.CheckTemplates)
+    if (err != nil) {
+        panic(err)
+    }
+    err = AddCheck("sessions", Liveness, sessions.CheckStore)
+    if (err != nil) {
+        panic(err)
+    }
+    shutdownHook.Do(func() {
+        http.OnShutdown(func() {
+            var monitor *Monitor
+            if services.GetService(&monitor) == nil {
+                monitor.SetReady(false)
+            }
+        })
+    })
+}
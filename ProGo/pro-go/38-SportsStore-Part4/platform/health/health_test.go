@@ -0,0 +1,203 @@
+package health
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "platform/config"
+    "platform/logging"
+    "platform/platformtest"
+    "platform/services"
+    "strings"
+    "testing"
+    "time"
+)
+
+type counter struct {
+    count int
+}
+
+func useChecks(t *testing.T, selected ...Check) {
+    checksLock.Lock()
+    previous := checks
+    checks = []Check {}
+    checksLock.Unlock()
+    t.Cleanup(func() {
+        checksLock.Lock()
+        checks = previous
+        checksLock.Unlock()
+    })
+    for _, check := range selected {
+        if err := AddCheck(check.Name, check.Kind, check.function); err != nil {
+            t.Fatal(err)
+        }
+    }
+}
+
+func newTestMonitor(values map[string]interface{}) *Monitor {
+    values["logging:level"] = "none"
+    cfg := platformtest.ConfigValues(values)
+    return NewMonitor(cfg, logging.NewDefaultLogger(cfg))
+}
+
+func resultFor(report Report, name string) (result CheckResult) {
+    for _, result = range report.Checks {
+        if result.Name == name {
+            return
+        }
+    }
+    return CheckResult{}
+}
+
+func TestAddCheck(t *testing.T) {
+    useChecks(t)
+    for _, invalid := range []interface{} { nil, "check", func() {},
+            func() string { return "" }, func() (error, error) { return nil, nil } } {
+        if err := AddCheck("invalid", Liveness, invalid); err == nil {
+            t.Fatalf("Expected %T to be rejected", invalid)
+        }
+    }
+    AddCheck("db", Liveness, func() error { return nil })
+    AddCheck("db", Readiness, func() error { return nil })
+    if registered := Checks(); len(registered) != 1 ||
+            registered[0].Kind != Readiness {
+        t.Fatalf("Expected check to be replaced: %+v", registered)
+    }
+}
+
+func TestMonitorRun(t *testing.T) {
+    useChecks(t,
+        Check{ Name: "live", Kind: Liveness, function: func() error { return nil } },
+        Check{ Name: "ready", Kind: Readiness,
+            function: func() error { return errors.New("not ready") } },
+        Check{ Name: "panics", Kind: Readiness,
+            function: func() error { panic("broken") } })
+    monitor := newTestMonitor(map[string]interface{} {})
+    live := monitor.Run(context.Background(), Liveness)
+    if !live.Healthy() || len(live.Checks) != 1 || live.Checks[0].Name != "live" {
+        t.Fatalf("Unexpected liveness report: %+v", live)
+    }
+    ready := monitor.Run(context.Background(), Readiness)
+    if ready.Healthy() || len(ready.Checks) != 3 ||
+            resultFor(ready, "live").Status != StatusUp ||
+            resultFor(ready, "ready").Error != "not ready" ||
+            resultFor(ready, "panics").Error != "broken" {
+        t.Fatalf("Unexpected readiness report: %+v", ready)
+    }
+    useChecks(t)
+    monitor.SetReady(false)
+    if report := monitor.Run(context.Background(), Readiness); report.Healthy() ||
+            resultFor(report, "shutdown").Status != StatusDown {
+        t.Fatalf("Expected shutdown to fail readiness: %+v", report)
+    }
+    if report := monitor.Run(context.Background(), Liveness); !report.Healthy() {
+        t.Fatalf("Expected shutdown not to fail liveness: %+v", report)
+    }
+}
+
+func TestCheckTimeout(t *testing.T) {
+    release := make(chan struct{})
+    defer close(release)
+    useChecks(t,
+        Check{ Name: "slow", Kind: Liveness, function: func() error {
+            <- release
+            return nil
+        }},
+        Check{ Name: "cancelled", Kind: Liveness,
+            function: func(ctx context.Context) error {
+                <- ctx.Done()
+                return ctx.Err()
+            }},
+        Check{ Name: "fast", Kind: Liveness, function: func() error { return nil } })
+    monitor := newTestMonitor(map[string]interface{} {
+        "health:timeout": "50ms",
+        "health:checks:cancelled:timeout": "10ms",
+    })
+    start := time.Now()
+    report := monitor.Run(context.Background(), Liveness)
+    if elapsed := time.Since(start); elapsed > time.Second {
+        t.Fatalf("Expected checks to time out, took %v", elapsed)
+    }
+    if report.Healthy() || resultFor(report, "fast").Status != StatusUp ||
+            !strings.Contains(resultFor(report, "slow").Error, "timed out after 50ms") ||
+            !strings.Contains(resultFor(report, "cancelled").Error, "10ms") {
+        t.Fatalf("Unexpected report: %+v", report)
+    }
+    useChecks(t)
+    monitor = newTestMonitor(map[string]interface{} {
+        "health:checks:fast:timeout": "soon" })
+    AddCheck("fast", Liveness, func() error { return nil })
+    if report := monitor.Run(context.Background(), Liveness); report.Healthy() {
+        t.Fatal("Expected invalid timeout to fail the check")
+    }
+}
+
+func TestChecksUseOwnServiceScope(t *testing.T) {
+    platformtest.New(t, config.NewMapConfig(map[string]interface{} {}))
+    services.AddScoped(func() *counter { return &counter{} })
+    requestCtx := services.NewServiceContext(context.Background())
+    var requestCounter *counter
+    services.GetServiceForContext(requestCtx, &requestCounter)
+    seen := make(chan *counter, 20)
+    selected := []Check {}
+    for _, name := range []string { "a", "b", "c", "d", "e", "f", "g", "h" } {
+        selected = append(selected, Check{ Name: name, Kind: Liveness,
+            function: func(c *counter) error {
+                c.count++
+                seen <- c
+                return nil
+            }})
+    }
+    useChecks(t, selected...)
+    monitor := newTestMonitor(map[string]interface{} {})
+    for i := 0; i < 2; i++ {
+        if report := monitor.Run(requestCtx, Liveness); !report.Healthy() {
+            t.Fatalf("Unexpected report: %+v", report)
+        }
+    }
+    close(seen)
+    for c := range seen {
+        if c == requestCounter || c.count != 1 {
+            t.Fatal("Expected each check to resolve services in its own scope")
+        }
+    }
+}
+
+func TestHealthComponent(t *testing.T) {
+    useChecks(t,
+        Check{ Name: "live", Kind: Liveness, function: func() error { return nil } },
+        Check{ Name: "ready", Kind: Readiness,
+            function: func() error { return errors.New("not ready") } })
+    monitor := newTestMonitor(map[string]interface{} {})
+    cfg := platformtest.ConfigValues(map[string]interface{} {
+        "health:livenessPath": "/live" })
+    h := platformtest.New(t, cfg).
+        Singleton(func() *Monitor { return monitor }).
+        Build(&HealthComponent{})
+    client := h.NewClient()
+    live := client.Get("/live")
+    platformtest.AssertStatus(t, live, http.StatusOK)
+    var report Report
+    if err := json.NewDecoder(live.Body).Decode(&report); err != nil ||
+            !report.Healthy() || live.Header().Get("Cache-Control") != "no-store" {
+        t.Fatalf("Unexpected liveness response: %+v %v", report, err)
+    }
+    ready := client.Get("/readyz")
+    platformtest.AssertStatus(t, ready, http.StatusServiceUnavailable)
+    if !strings.Contains(ready.Body.String(), `"error":"not ready"`) {
+        t.Fatalf("Unexpected readiness response: %v", ready.Body.String())
+    }
+    head := client.Do(httptest.NewRequest(http.MethodHead, "/live", nil))
+    platformtest.AssertStatus(t, head, http.StatusOK)
+    if head.Body.Len() != 0 {
+        t.Fatal("Expected no body for HEAD request")
+    }
+    for _, other := range []*platformtest.Response { client.Get("/healthz"),
+            client.PostForm("/live", nil) } {
+        if other.Body.Len() != 0 || other.Header().Get("Cache-Control") != "" {
+            t.Fatal("Expected other requests to be passed along the pipeline")
+        }
+    }
+}
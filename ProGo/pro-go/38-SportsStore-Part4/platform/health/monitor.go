@@ -0,0 +1,139 @@
+package health
+
+import (
+    "context"
+    "fmt"
+    "platform/config"
+    "platform/logging"
+    "platform/services"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+const (
+    StatusUp = "up"
+    StatusDown = "down"
+)
+
+type CheckResult struct {
+    Name string `json:"name"`
+    Status string `json:"status"`
+    Error string `json:"error,omitempty"`
+    Duration string `json:"duration"`
+}
+
+type Report struct {
+    Status string `json:"status"`
+    Checks []CheckResult `json:"checks"`
+}
+
+func (r Report) Healthy() bool {
+    return r.Status == StatusUp
+}
+
+type Monitor struct {
+    config.Configuration
+    logging.Logger
+    timeout time.Duration
+    ready int32
+}
+
+func NewMonitor(cfg config.Configuration, logger logging.Logger) *Monitor {
+    timeout, err := time.ParseDuration(cfg.GetStringDefault("health:timeout", "2s"))
+    if err != nil {
+        panic(err)
+    }
+    return &Monitor{ Configuration: cfg, Logger: logger, timeout: timeout, ready: 1 }
+}
+
+func (m *Monitor) Ready() bool {
+    return atomic.LoadInt32(&m.ready) == 1
+}
+
+func (m *Monitor) SetReady(ready bool) {
+    var val int32
+    if ready {
+        val = 1
+    }
+    atomic.StoreInt32(&m.ready, val)
+}
+
+func (m *Monitor) Run(ctx context.Context, kind Kind) Report {
+    selected := []Check {}
+    for _, check := range Checks() {
+        if check.Kind <= kind {
+            selected = append(selected, check)
+        }
+    }
+    results := make([]CheckResult, len(selected))
+    wg := sync.WaitGroup{}
+    for i, check := range selected {
+        wg.Add(1)
+        go func(i int, check Check) {
+            defer wg.Done()
+            results[i] = m.runCheck(ctx, check)
+        }(i, check)
+    }
+    wg.Wait()
+    if kind == Readiness && !m.Ready() {
+        results = append(results, CheckResult{ Name: "shutdown", Status: StatusDown,
+            Error: "Server is shutting down", Duration: "0s" })
+    }
+    report := Report{ Status: StatusUp, Checks: results }
+    for _, result := range results {
+        if result.Status != StatusUp {
+            report.Status = StatusDown
+        }
+    }
+    return report
+}
+
+func (m *Monitor) checkTimeout(name string) (time.Duration, error) {
+    if val, found := m.Configuration.GetString("health:checks:" + name +
+            ":timeout"); found {
+        return time.ParseDuration(val)
+    }
+    return m.timeout, nil
+}
+
+func (m *Monitor) runCheck(ctx context.Context, check Check) CheckResult {
+    start := time.Now()
+    err := m.invokeCheck(ctx, check)
+    result := CheckResult{ Name: check.Name, Status: StatusUp,
+        Duration: time.Since(start).Round(time.Microsecond).String() }
+    if err != nil {
+        result.Status = StatusDown
+        result.Error = err.Error()
+        m.Logger.Warnf("Health check %v failed: %v", check.Name, err)
+    }
+    return result
+}
+
+func (m *Monitor) invokeCheck(ctx context.Context, check Check) error {
+    timeout, err := m.checkTimeout(check.Name)
+    if err != nil {
+        return err
+    }
+    checkCtx, cancel := context.WithTimeout(services.NewServiceScope(ctx), timeout)
+    defer cancel()
+    done := make(chan error, 1)
+    go func() {
+        defer func() {
+            if r := recover(); r != nil {
+                done <- fmt.Errorf("%v", r)
+            }
+        }()
+        results, err := services.CallForContext(checkCtx, check.function)
+        if err == nil && results[0] != nil {
+            err = results[0].(error)
+        }
+        done <- err
+    }()
+    select {
+        case err = <- done:
+            return err
+        case <- checkCtx.Done():
+            return fmt.Errorf("Check timed out after %v", timeout)
+    }
+}
@@ -0,0 +1,32 @@
+package actionresults
+
+import (
+    "fmt"
+    "io"
+)
+
+func NewDownloadAction(fileName, contentType string, 
+        writer func(io.Writer) error) ActionResult {
+    return &DownloadActionResult{ fileName: fileName, contentType: contentType, 
+        writer: writer }
+}
+
+type DownloadActionResult struct {
+    fileName, contentType string
+    writer func(io.Writer) error
+}
+
+func (action *DownloadActionResult) Execute(ctx *ActionContext) error {
+    ctx.ResponseWriter.Header().Set("Content-Type", action.contentType)
+    ctx.ResponseWriter.Header().Set("Content-Disposition", 
+        fmt.Sprintf("attachment; filename=%q", action.fileName))
+    return action.writer(ctx.ResponseWriter)
+}
+
+func (action *DownloadActionResult) FileName() string {
+    return action.fileName
+}
+
+func (action *DownloadActionResult) ContentType() string {
+    return action.contentType
+}
@@ -4,6 +4,10 @@ func NewErrorAction(err error) ActionResult {
 	return &ErrorActionResult{err}
 }
 
+func NewStatusErrorAction(status int, err error) ActionResult {
+	return &ErrorActionResult{ &StatusError{ Status: status, Err: err }}
+}
+
 type ErrorActionResult struct {
 	error
 }
@@ -11,3 +15,24 @@ type ErrorActionResult struct {
 func (action *ErrorActionResult) Execute(*ActionContext) error {
 	return action.error
 }
+
+type StatusError struct {
+	Status int
+	Err error
+}
+
+func (err *StatusError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *StatusError) Unwrap() error {
+	return err.Err
+}
+
+func (err *StatusError) StatusCode() int {
+	return err.Status
+}
+
+func (action *ErrorActionResult) Err() error {
+	return action.error
+}
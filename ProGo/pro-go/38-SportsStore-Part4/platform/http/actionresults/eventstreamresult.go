@@ -0,0 +1,135 @@
+package actionresults
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "html/template"
+    "net/http"
+    "platform/pipeline"
+    "strings"
+    "sync"
+    "time"
+)
+
+type Event struct {
+    ID string
+    Name string
+    Data interface{}
+}
+
+type EventSender func(Event) error
+
+func NewEventStreamAction(heartbeat time.Duration,
+        source func(context.Context, EventSender) error) ActionResult {
+    return &EventStreamActionResult{ heartbeat: heartbeat, source: source }
+}
+
+type EventStreamActionResult struct {
+    heartbeat time.Duration
+    source func(context.Context, EventSender) error
+}
+
+func (action *EventStreamActionResult) Execute(ctx *ActionContext) error {
+    header := ctx.ResponseWriter.Header()
+    header.Set("Content-Type", "text/event-stream")
+    header.Set("Cache-Control", "no-cache")
+    header.Set("Connection", "keep-alive")
+    header.Set("X-Accel-Buffering", "no")
+    writer := ctx.ResponseWriter
+    if streamer, ok := writer.(pipeline.StreamingResponseWriter); ok {
+        writer = streamer.Stream(http.StatusOK)
+    } else {
+        writer.WriteHeader(http.StatusOK)
+    }
+    stream := &eventStream{ writer: writer }
+    stream.flusher, _ = writer.(http.Flusher)
+    if err := stream.write(": connected\n\n"); err != nil {
+        return nil
+    }
+
+    streamCtx, cancel := context.WithCancel(ctx.Context)
+    defer cancel()
+    if shutdown := pipeline.ShutdownFromContext(ctx.Context); shutdown != nil {
+        go func() {
+            select {
+                case <-shutdown:
+                    cancel()
+                case <-streamCtx.Done():
+            }
+        }()
+    }
+    if action.heartbeat > 0 {
+        go stream.keepAlive(streamCtx, cancel, action.heartbeat)
+    }
+    err := action.source(streamCtx, stream.send)
+    if streamCtx.Err() != nil {
+        return nil
+    }
+    return err
+}
+
+type eventStream struct {
+    writer http.ResponseWriter
+    flusher http.Flusher
+    mutex sync.Mutex
+}
+
+func (s *eventStream) send(event Event) error {
+    var sb strings.Builder
+    if event.ID != "" {
+        fmt.Fprintf(&sb, "id: %v\n", event.ID)
+    }
+    if event.Name != "" {
+        fmt.Fprintf(&sb, "event: %v\n", event.Name)
+    }
+    data, err := eventData(event.Data)
+    if err != nil {
+        return err
+    }
+    for _, line := range strings.Split(data, "\n") {
+        fmt.Fprintf(&sb, "data: %v\n", strings.TrimSuffix(line, "\r"))
+    }
+    sb.WriteString("\n")
+    return s.write(sb.String())
+}
+
+func (s *eventStream) write(text string) (err error) {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    if _, err = s.writer.Write([]byte(text)); err == nil && s.flusher != nil {
+        s.flusher.Flush()
+    }
+    return
+}
+
+func (s *eventStream) keepAlive(ctx context.Context, cancel func(),
+        interval time.Duration) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    for {
+        select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+                if err := s.write(": ping\n\n"); err != nil {
+                    cancel()
+                    return
+                }
+        }
+    }
+}
+
+func eventData(data interface{}) (string, error) {
+    switch val := data.(type) {
+        case string:
+            return val, nil
+        case template.HTML:
+            return string(val), nil
+        case fmt.Stringer:
+            return val.String(), nil
+        default:
+            bytes, err := json.Marshal(data)
+            return string(bytes), err
+    }
+}
@@ -0,0 +1,58 @@
+package actionresults
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "platform/pipeline"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestEventStream(t *testing.T) {
+    recorder := httptest.NewRecorder()
+    writer := &pipeline.DeferredResponseWriter{ ResponseWriter: recorder }
+    action := NewEventStreamAction(0, func(ctx context.Context, 
+            send EventSender) error {
+        send(Event{ ID: "1", Name: "update", Data: "line one\nline two" })
+        return send(Event{ Data: map[string]int { "count": 2 } })
+    })
+    if err := action.Execute(&ActionContext{ context.Background(), writer }); err != nil {
+        t.Fatal(err)
+    }
+    expected := ": connected\n\nid: 1\nevent: update\ndata: line one\n" + 
+        "data: line two\n\ndata: {\"count\":2}\n\n"
+    if recorder.Body.String() != expected || !recorder.Flushed ||
+            recorder.Header().Get("Content-Type") != "text/event-stream" {
+        t.Fatalf("Unexpected stream: %q", recorder.Body.String())
+    }
+}
+
+func TestEventStreamEndsOnShutdown(t *testing.T) {
+    shutdown := make(chan struct{})
+    ctx := pipeline.WithShutdown(context.Background(), shutdown)
+    recorder := httptest.NewRecorder()
+    done := make(chan error)
+    action := NewEventStreamAction(0, func(ctx context.Context, 
+            send EventSender) error {
+        <- ctx.Done()
+        return ctx.Err()
+    })
+    go func() {
+        done <- action.Execute(&ActionContext{ ctx, recorder })
+    }()
+    close(shutdown)
+    select {
+        case err := <- done:
+            if err != nil {
+                t.Fatalf("Expected stream to end cleanly, got %v", err)
+            }
+        case <- time.After(time.Second):
+            t.Fatal("Expected stream to end on shutdown")
+    }
+    if recorder.Code != http.StatusOK || 
+            !strings.HasPrefix(recorder.Body.String(), ": connected") {
+        t.Fatalf("Unexpected response: %v %q", recorder.Code, recorder.Body.String())
+    }
+}
@@ -15,3 +15,7 @@ func (action *JsonActionResult) Execute(ctx *ActionContext) error {
     encoder := json.NewEncoder(ctx.ResponseWriter)
     return encoder.Encode(action.data)
 }
+
+func (action *JsonActionResult) Data() interface{} {
+    return action.data
+}
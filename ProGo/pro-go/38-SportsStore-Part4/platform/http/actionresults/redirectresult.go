@@ -15,3 +15,7 @@ func (action *RedirectActionResult) Execute(ctx *ActionContext) error {
     ctx.ResponseWriter.WriteHeader(http.StatusSeeOther)
     return nil
 }
+
+func (action *RedirectActionResult) URL() string {
+    return action.url
+}
@@ -19,3 +19,11 @@ func (action *TemplateActionResult) Execute(ctx *ActionContext) error {
     return action.TemplateExecutor.ExecTemplateWithFunc(ctx.ResponseWriter, 
         action.templateName, action.data, action.InvokeHandlerFunc)
 }
+
+func (action *TemplateActionResult) TemplateName() string {
+    return action.templateName
+}
+
+func (action *TemplateActionResult) Data() interface{} {
+    return action.data
+}
@@ -0,0 +1,24 @@
+package handling
+
+import (
+    "context"
+    "platform/http/actionresults"
+    "platform/services"
+    "reflect"
+)
+
+type ActionFilter interface {
+
+    FilterAction(ctx context.Context, handler interface{}, handlerName, 
+        methodName string) actionresults.ActionResult
+}
+
+func filterAction(ctx context.Context, route Route, 
+        handler reflect.Value) actionresults.ActionResult {
+    var filter ActionFilter
+    if services.GetServiceForContext(ctx, &filter) == nil {
+        return filter.FilterAction(ctx, handler.Interface(), route.handlerName, 
+            route.handlerMethod.Name)
+    }
+    return nil
+}
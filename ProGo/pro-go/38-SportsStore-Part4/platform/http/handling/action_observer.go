@@ -0,0 +1,12 @@
+package handling
+
+import (
+    "context"
+    "platform/http/actionresults"
+)
+
+type ActionObserver interface {
+
+    ObserveAction(ctx context.Context, handlerName, methodName string, 
+        action actionresults.ActionResult)
+}
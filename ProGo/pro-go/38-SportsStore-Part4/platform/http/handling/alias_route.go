@@ -35,6 +35,7 @@ func (rc *RouterComponent) AddUrlAlias(srcUrl string,
             Type: reflect.TypeOf(aliasFunc),
             Func: reflect.ValueOf(aliasFunc),
         },
+        target: targetUrl,
     }
     rc.routes = append([]Route { alias},  rc.routes... )
     return rc
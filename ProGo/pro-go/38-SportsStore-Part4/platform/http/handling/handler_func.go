@@ -27,6 +27,9 @@ func createInvokehandlerFunc(ctx context.Context,
                 services.PopulateForContext(ctx, structVal.Interface())
                 paramVals = append([]reflect.Value { structVal.Elem() }, 
                     paramVals...)
+                if filterAction(ctx, route, structVal.Elem()) != nil {
+                    return template.HTML("")
+                }
                 result := route.handlerMethod.Func.Call(paramVals)  
                 if action, ok := result[0].Interface().
                         (*actionresults.TemplateActionResult); ok {
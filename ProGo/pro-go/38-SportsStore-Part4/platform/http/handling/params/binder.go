@@ -0,0 +1,370 @@
+package params
+
+import (
+    "fmt"
+    "mime/multipart"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+var fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader {})
+
+const (
+    formSource = "form"
+    querySource = "query"
+)
+
+type valueSet struct {
+    values map[string][]string
+    keys map[string]string
+}
+
+func newValueSet(vals map[string][]string) valueSet {
+    set := valueSet{ values: map[string][]string {}, keys: map[string]string {} }
+    for key, v := range vals {
+        lower := strings.ToLower(key)
+        set.values[lower] = append(set.values[lower], v...)
+        set.keys[lower] = key
+    }
+    return set
+}
+
+func (set valueSet) get(key string) []string {
+    if vals, ok := set.values[key]; ok {
+        return vals
+    }
+    return set.values[key + "[]"]
+}
+
+func (set valueSet) hasPrefix(prefix string) bool {
+    for key := range set.values {
+        if strings.HasPrefix(key, prefix) {
+            return true
+        }
+    }
+    return false
+}
+
+func (set valueSet) subscripts(prefix string) (result []string) {
+    seen := map[string]bool {}
+    for lower, original := range set.keys {
+        if !strings.HasPrefix(lower, prefix + "[") {
+            continue
+        }
+        rest := original[len(prefix) + 1:]
+        if end := strings.Index(rest, "]"); end > 0 && !seen[rest[:end]] {
+            seen[rest[:end]] = true
+            result = append(result, rest[:end])
+        }
+    }
+    sort.Strings(result)
+    return
+}
+
+type binder struct {
+    sources map[string]valueSet
+    files map[string][]*multipart.FileHeader
+    jsonBound bool
+    maxIndex int
+    embedding map[embeddedKey]bool
+}
+
+type embeddedKey struct {
+    embeddedType reflect.Type
+    prefix string
+}
+
+func newBinder(form, query map[string][]string, 
+        files map[string][]*multipart.FileHeader, jsonBound bool, 
+        maxIndex int) *binder {
+    b := &binder{ 
+        sources: map[string]valueSet { 
+            formSource: newValueSet(form), 
+            querySource: newValueSet(query),
+        },
+        files: map[string][]*multipart.FileHeader {},
+        jsonBound: jsonBound,
+        maxIndex: maxIndex,
+        embedding: map[embeddedKey]bool {},
+    }
+    for key, headers := range files {
+        lower := strings.ToLower(key)
+        b.files[lower] = append(b.files[lower], headers...)
+    }
+    return b
+}
+
+func (b *binder) bindStruct(structVal reflect.Value, prefix, 
+        source string, shadowed map[string]bool) (bound bool, err error) {
+    structType := structVal.Type()
+    names := map[string]bool {}
+    for name := range shadowed {
+        names[name] = true
+    }
+    for i := 0; i < structType.NumField(); i++ {
+        if field := structType.Field(i); !field.Anonymous {
+            name, _, _ := fieldKey(field, source)
+            names[name] = true
+        }
+    }
+    for i := 0; i < structType.NumField(); i++ {
+        field := structType.Field(i)
+        if field.PkgPath != "" && !field.Anonymous {
+            continue
+        }
+        name, fieldSource, tagged := fieldKey(field, source)
+        if name == "-" || shadowed[name] || 
+                (b.jsonBound && fieldSource != querySource) {
+            continue
+        }
+        valField := structVal.Field(i)
+        if !valField.CanSet() {
+            continue
+        }
+        var fieldBound bool
+        if field.Anonymous && !tagged && isStructType(field.Type) {
+            fieldBound, err = b.bindEmbedded(valField, prefix, fieldSource, names)
+        } else {
+            fieldBound, err = b.bindValue(valField, prefix + name, fieldSource)
+        }
+        if bound = bound || fieldBound; err != nil {
+            return
+        }
+    }
+    return
+}
+
+func (b *binder) bindEmbedded(valField reflect.Value, prefix, source string, 
+        shadowed map[string]bool) (bound bool, err error) {
+    embedded := embeddedKey{ indirectType(valField.Type()), prefix }
+    if b.embedding[embedded] {
+        return
+    }
+    b.embedding[embedded] = true
+    defer delete(b.embedding, embedded)
+    typeName := strings.ToLower(embedded.embeddedType.Name())
+    prefixes := []struct { prefix string; shadowed map[string]bool } {
+        { prefix, shadowed }, { prefix + typeName + ".", nil },
+    }
+    for _, p := range prefixes {
+        if !b.hasKeys(p.prefix, source) {
+            continue
+        }
+        names := p.shadowed
+        bindFunc := func(v reflect.Value, key string) (bool, error) {
+            return b.bindStruct(v, key, source, names)
+        }
+        var pBound bool
+        if valField.Kind() == reflect.Ptr {
+            pBound, err = b.bindPointer(valField, p.prefix, bindFunc)
+        } else {
+            pBound, err = bindFunc(valField, p.prefix)
+        }
+        if bound = bound || pBound; err != nil {
+            return
+        }
+    }
+    return
+}
+
+func (b *binder) bindValue(valField reflect.Value, key, 
+        source string) (bound bool, err error) {
+    valType := valField.Type()
+    switch {
+        case valType == fileHeaderType:
+            if headers := b.files[key]; len(headers) > 0 {
+                valField.Set(reflect.ValueOf(headers[0]))
+                bound = true
+            }
+        case valType == fileHeaderSliceType:
+            if headers := b.files[key]; len(headers) > 0 {
+                valField.Set(reflect.ValueOf(headers))
+                bound = true
+            }
+        case isParseable(valType):
+            if vals := b.sources[source].get(key); len(vals) > 0 {
+                bound = true
+                err = b.setParsed(valField, key, vals[0])
+            }
+        case valType.Kind() == reflect.Ptr:
+            bound, err = b.bindPointer(valField, key, 
+                func(v reflect.Value, k string) (bool, error) {
+                    return b.bindValue(v, k, source)
+                })
+        case valType.Kind() == reflect.Struct:
+            if b.hasKeys(key + ".", source) {
+                bound, err = b.bindStruct(valField, key + ".", source, nil)
+            }
+        case valType.Kind() == reflect.Slice:
+            bound, err = b.bindSlice(valField, key, source)
+        case valType.Kind() == reflect.Map && valType.Key().Kind() == reflect.String:
+            bound, err = b.bindMap(valField, key, source)
+    }
+    return
+}
+
+func (b *binder) hasKeys(prefix, source string) bool {
+    if b.sources[source].hasPrefix(prefix) {
+        return true
+    }
+    for key := range b.files {
+        if strings.HasPrefix(key, prefix) {
+            return true
+        }
+    }
+    return false
+}
+
+func (b *binder) bindPointer(valField reflect.Value, key string, 
+        bindFunc func(reflect.Value, string) (bool, error)) (bound bool, err error) {
+    target := reflect.New(valField.Type().Elem())
+    if !valField.IsNil() {
+        target.Elem().Set(valField.Elem())
+    }
+    if bound, err = bindFunc(target.Elem(), key); bound && err == nil {
+        valField.Set(target)
+    }
+    return
+}
+
+func (b *binder) bindSlice(valField reflect.Value, key, 
+        source string) (bound bool, err error) {
+    elemType := valField.Type().Elem()
+    values := b.sources[source]
+    if isParseable(elemType) {
+        if vals := values.get(key); len(vals) > 0 {
+            slice := reflect.MakeSlice(valField.Type(), 0, len(vals))
+            for _, val := range vals {
+                if isBlank(elemType, val) {
+                    continue
+                }
+                var elem reflect.Value
+                if elem, err = parseValueToType(elemType, val); err != nil {
+                    return true, bindError(key, err)
+                }
+                slice = reflect.Append(slice, elem)
+            }
+            valField.Set(slice)
+            return true, nil
+        }
+    }
+    indices := []int {}
+    for _, sub := range values.subscripts(key) {
+        if index, convErr := strconv.Atoi(sub); convErr == nil && index >= 0 {
+            if index > b.maxIndex {
+                return true, bindError(fmt.Sprintf("%v[%v]", key, sub), 
+                    fmt.Errorf("Index exceeds the maximum of %v", b.maxIndex))
+            }
+            indices = append(indices, index)
+        }
+    }
+    if len(indices) == 0 {
+        return
+    }
+    sort.Ints(indices)
+    length := indices[len(indices) - 1] + 1
+    if valField.Len() > length {
+        length = valField.Len()
+    }
+    slice := reflect.MakeSlice(valField.Type(), length, length)
+    reflect.Copy(slice, valField)
+    for _, index := range indices {
+        if _, err = b.bindValue(slice.Index(index), 
+                fmt.Sprintf("%v[%v]", key, index), source); err != nil {
+            return true, err
+        }
+    }
+    valField.Set(slice)
+    return true, nil
+}
+
+func (b *binder) bindMap(valField reflect.Value, key, 
+        source string) (bound bool, err error) {
+    subs := b.sources[source].subscripts(key)
+    if len(subs) == 0 {
+        return
+    }
+    mapType := valField.Type()
+    if valField.IsNil() {
+        valField.Set(reflect.MakeMap(mapType))
+    }
+    for _, sub := range subs {
+        elem := reflect.New(mapType.Elem()).Elem()
+        if existing := valField.MapIndex(reflect.ValueOf(sub).Convert(mapType.Key())); 
+                existing.IsValid() {
+            elem.Set(existing)
+        }
+        var elemBound bool
+        if elemBound, err = b.bindValue(elem, 
+                key + "[" + strings.ToLower(sub) + "]", source); err != nil {
+            return true, err
+        } else if elemBound {
+            valField.SetMapIndex(reflect.ValueOf(sub).Convert(mapType.Key()), elem)
+            bound = true
+        }
+    }
+    return
+}
+
+func (b *binder) setParsed(valField reflect.Value, key, val string) error {
+    if isBlank(valField.Type(), val) {
+        valField.Set(reflect.Zero(valField.Type()))
+        return nil
+    }
+    parsed, err := parseValueToType(valField.Type(), val)
+    if err != nil {
+        return bindError(key, err)
+    }
+    valField.Set(parsed)
+    return nil
+}
+
+func bindError(key string, err error) error {
+    return fmt.Errorf("Cannot bind value for %v: %v", key, err)
+}
+
+func fieldKey(field reflect.StructField, 
+        inherited string) (name, source string, tagged bool) {
+    source = inherited
+    for _, tag := range []string { querySource, formSource, "json" } {
+        if tagVal, ok := field.Tag.Lookup(tag); ok {
+            tagName := strings.Split(tagVal, ",")[0]
+            if tag == querySource {
+                source = querySource
+            }
+            if tagName != "" {
+                return strings.ToLower(tagName), source, true
+            }
+            break
+        }
+    }
+    return strings.ToLower(field.Name), source, false
+}
+
+func isParseable(target reflect.Type) bool {
+    if target == timeType || reflect.PtrTo(target).Implements(textUnmarshalerType) {
+        return true
+    } else if target.Kind() == reflect.Ptr {
+        return isParseable(target.Elem())
+    }
+    return target.Kind() == reflect.String || isScalar(target)
+}
+
+func isBlank(target reflect.Type, val string) bool {
+    return strings.TrimSpace(val) == "" && (target == timeType || 
+        isScalar(indirectType(target)))
+}
+
+func isStructType(target reflect.Type) bool {
+    return indirectType(target).Kind() == reflect.Struct
+}
+
+func indirectType(target reflect.Type) reflect.Type {
+    if target.Kind() == reflect.Ptr {
+        return target.Elem()
+    }
+    return target
+}
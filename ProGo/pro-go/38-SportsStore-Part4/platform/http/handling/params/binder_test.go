@@ -0,0 +1,118 @@
+package params
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "platform/config"
+    "platform/services"
+    "strings"
+    "testing"
+)
+
+type line struct {
+    Product string
+    Quantity int
+}
+
+type order struct {
+    Name string
+    Lines []line
+    Tags []string `form:"tag"`
+}
+
+func formRequest(values url.Values) *http.Request {
+    req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    return req
+}
+
+func TestBindSlices(t *testing.T) {
+    var target order
+    err := BindRequest(formRequest(url.Values{
+        "name": { "Bob" },
+        "lines[2].product": { "Kayak" }, "lines[2].quantity": { "3" },
+        "lines[0].product": { "Lifejacket" },
+        "tag": { "red", "", "blue" },
+    }), &target)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if target.Name != "Bob" || len(target.Lines) != 3 ||
+            target.Lines[2] != (line{ "Kayak", 3 }) ||
+            target.Lines[0].Product != "Lifejacket" || len(target.Tags) != 3 {
+        t.Fatalf("Unexpected binding: %+v", target)
+    }
+    err = BindRequest(formRequest(url.Values{ "lines[1].quantity": { "many" } }),
+        &target)
+    if err == nil || !strings.Contains(err.Error(), "lines[1].quantity") {
+        t.Fatalf("Expected bind error, got %v", err)
+    }
+}
+
+func TestBindSliceIndexLimit(t *testing.T) {
+    var target order
+    limit := formRequest(url.Values{
+        "lines[1000].product": { "Kayak" } })
+    if err := BindRequest(limit, &target); err != nil || len(target.Lines) != 1001 {
+        t.Fatalf("Expected index at the limit to bind: %v", err)
+    }
+    target = order{}
+    over := formRequest(url.Values{
+        "lines[1001].product": { "Kayak" } })
+    if err := BindRequest(over, &target); err == nil ||
+            !strings.Contains(err.Error(), "lines[1001]") || target.Lines != nil {
+        t.Fatalf("Expected index over the limit to be rejected: %v", err)
+    }
+
+    previous := services.UseRegistry(services.NewRegistry())
+    defer services.UseRegistry(previous)
+    services.AddSingleton(func() config.Configuration {
+        return config.NewMapConfig(map[string]interface{} {
+            "http": map[string]interface{} { "maxSliceIndex": 5.0 },
+        })
+    })
+    configured := formRequest(url.Values{ "lines[6].product": { "Kayak" } })
+    configured = configured.WithContext(
+        services.NewServiceContext(context.Background()))
+    if err := BindRequest(configured, &target); err == nil ||
+            !strings.Contains(err.Error(), "maximum of 5") {
+        t.Fatalf("Expected configured limit to be applied: %v", err)
+    }
+}
+
+type node struct {
+    Name string
+    Next *node
+    Children []node
+}
+
+type linked struct {
+    *linked
+    Name string
+}
+
+func TestBindRecursiveTypes(t *testing.T) {
+    var target node
+    err := BindRequest(formRequest(url.Values{
+        "name": { "root" },
+        "next.name": { "second" },
+        "next.next.name": { "third" },
+        "children[0].name": { "child" },
+    }), &target)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if target.Name != "root" || target.Next == nil || target.Next.Name != "second" ||
+            target.Next.Next == nil || target.Next.Next.Name != "third" ||
+            target.Next.Next.Next != nil || len(target.Children) != 1 ||
+            target.Children[0].Name != "child" || target.Children[0].Next != nil {
+        t.Fatalf("Unexpected binding: %+v", target)
+    }
+    var embedded linked
+    if err := BindRequest(formRequest(url.Values{ "name": { "Bob" } }),
+            &embedded); err != nil || embedded.Name != "Bob" {
+        t.Fatalf("Unexpected binding: %+v %v", embedded, err)
+    }
+}
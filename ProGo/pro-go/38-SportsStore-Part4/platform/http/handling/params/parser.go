@@ -1,40 +1,104 @@
 package params
 
 import (
-    "reflect"
+    "encoding"
     "fmt"
+    "reflect"
     "strconv"
+    "strings"
+    "time"
 )
 
+var timeType = reflect.TypeOf(time.Time{})
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+var timeLayouts = []string { time.RFC3339Nano, "2006-01-02T15:04:05", 
+    "2006-01-02T15:04", "2006-01-02" }
+
 func parseValueToType(target reflect.Type, val string) (result reflect.Value, 
         err error) {
+    result = reflect.New(target).Elem()
+    if target == timeType {
+        return parseTime(val)
+    } else if reflect.PtrTo(target).Implements(textUnmarshalerType) {
+        err = result.Addr().Interface().(encoding.TextUnmarshaler).
+            UnmarshalText([]byte(val))
+        return
+    } else if target.Kind() == reflect.Ptr {
+        var elem reflect.Value
+        if elem, err = parseValueToType(target.Elem(), val); err == nil {
+            result = reflect.New(target.Elem())
+            result.Elem().Set(elem)
+        }
+        return
+    }
     switch target.Kind() {
         case reflect.String:
-            result = reflect.ValueOf(val)
-        case reflect.Int:
-            iVal, convErr := strconv.Atoi(val)
-            if convErr == nil {
-                result = reflect.ValueOf(iVal)   
-            } else {
-                return reflect.Value{}, convErr
+            result.SetString(val)
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            var iVal int64
+            if iVal, err = strconv.ParseInt(strings.TrimSpace(val), 10, 
+                    target.Bits()); err == nil {
+                result.SetInt(iVal)
+            }
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, 
+                reflect.Uint64:
+            var uVal uint64
+            if uVal, err = strconv.ParseUint(strings.TrimSpace(val), 10, 
+                    target.Bits()); err == nil {
+                result.SetUint(uVal)
             }
-        case reflect.Float64:
-            fVal, convErr := strconv.ParseFloat(val, 64)
-            if (convErr == nil) {
-                result = reflect.ValueOf(fVal)   
-            } else {
-                return reflect.Value{}, convErr
+        case reflect.Float32, reflect.Float64:
+            var fVal float64
+            if fVal, err = strconv.ParseFloat(strings.TrimSpace(val), 
+                    target.Bits()); err == nil {
+                result.SetFloat(fVal)
             }
         case reflect.Bool:
-            bVal, convErr := strconv.ParseBool(val)
-            if (convErr == nil) {
-                result = reflect.ValueOf(bVal)   
-            } else {
-                return reflect.Value{}, convErr
-            }            
+            var bVal bool
+            if bVal, err = parseBool(val); err == nil {
+                result.SetBool(bVal)
+            }
         default:
             err = fmt.Errorf("Cannot use type %v as handler method parameter", 
                 target.Name())
         }
+    if err != nil {
+        result = reflect.Value{}
+    }
     return
 }
+
+func isScalar(target reflect.Type) bool {
+    switch target.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+                reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, 
+                reflect.Uint64, reflect.Float32, reflect.Float64, reflect.Bool:
+            return true
+    }
+    return false
+}
+
+func parseBool(val string) (bool, error) {
+    switch strings.ToLower(strings.TrimSpace(val)) {
+        case "on", "yes":
+            return true, nil
+        case "off", "no":
+            return false, nil
+    }
+    return strconv.ParseBool(strings.TrimSpace(val))
+}
+
+func parseTime(val string) (result reflect.Value, err error) {
+    val = strings.TrimSpace(val)
+    if val == "" {
+        return reflect.ValueOf(time.Time{}), nil
+    }
+    for _, layout := range timeLayouts {
+        var t time.Time
+        if t, err = time.ParseInLocation(layout, val, time.Local); err == nil {
+            return reflect.ValueOf(t), nil
+        }
+    }
+    return reflect.Value{}, fmt.Errorf("Cannot parse %q as a time value", val)
+}
@@ -1,36 +1,120 @@
 package params
 
 import (
-	"net/http"
-	"reflect"
+    "encoding/json"
+    "errors"
+    "mime"
+    "mime/multipart"
+    "net/http"
+    "platform/config"
+    "platform/services"
+    "reflect"
 )
 
+const multipartMaxMemory = 32 << 20
+
+const DefaultMaxSliceIndex = 1000
+
 func GetParametersFromRequest(request *http.Request, handlerMethod reflect.Method, 
         urlVals []string) (params []reflect.Value, err error) {
     handlerMethodType := handlerMethod.Type
-    params = make([]reflect.Value, handlerMethodType.NumIn() -1)
     if (handlerMethodType.NumIn() == 1) {
         return []reflect.Value {}, nil
-    } else if handlerMethodType.NumIn() == 2 && 
-            handlerMethodType.In(1).Kind() == reflect.Struct {
-        structVal := reflect.New(handlerMethodType.In(1))        
+    } else if !hasStructParams(handlerMethodType) {
+        return getParametersFromURLValues(handlerMethodType, urlVals)
+    }
+    var b *binder
+    if b, err = createBinder(request); err != nil {
+        return
+    }
+    params = make([]reflect.Value, handlerMethodType.NumIn() -1)
+    urlIndex := 0
+    for i := 1; i < handlerMethodType.NumIn(); i++ {
+        paramType := handlerMethodType.In(i)
+        if paramType.Kind() == reflect.Struct {
+            structVal := reflect.New(paramType)
+            if err = b.bind(request, structVal); err != nil {
+                return
+            }
+            params[i - 1] = structVal.Elem()
+        } else if urlIndex < len(urlVals) {
+            if params[i - 1], err = parseValueToType(paramType, 
+                    urlVals[urlIndex]); err != nil {
+                return
+            }
+            urlIndex++
+        } else {
+            return nil, errors.New("Parameter number mismatch")
+        }
+    }
+    if urlIndex != len(urlVals) {
+        err = errors.New("Parameter number mismatch")
+    }
+    return
+}
+
+func createBinder(request *http.Request) (b *binder, err error) {
+    contentType := getContentType(request)
+    if contentType == "multipart/form-data" {
+        err = request.ParseMultipartForm(multipartMaxMemory)
+    } else if contentType != "application/json" {
         err = request.ParseForm()
-        if err == nil && getContentType(request) == "application/json" {
-            err = populateStructFromJSON(structVal, request.Body)
-        }        
-        if err == nil {
-            err = populateStructFromForm(structVal, request.Form)
+    }
+    if err == nil {
+        var files map[string][]*multipart.FileHeader
+        if request.MultipartForm != nil {
+            files = request.MultipartForm.File
         }
-        return []reflect.Value { structVal.Elem() }, err
-    } else {
-        return getParametersFromURLValues(handlerMethodType, urlVals)
-    } 
+        b = newBinder(request.Form, request.URL.Query(), files, 
+            contentType == "application/json", maxSliceIndex(request))
+    }
+    return
+}
+
+func maxSliceIndex(request *http.Request) int {
+    var cfg config.Configuration
+    if services.GetServiceForContext(request.Context(), &cfg) == nil {
+        return cfg.GetIntDefault("http:maxSliceIndex", DefaultMaxSliceIndex)
+    }
+    return DefaultMaxSliceIndex
+}
+
+func hasStructParams(funcType reflect.Type) bool {
+    for i := 1; i < funcType.NumIn(); i++ {
+        if funcType.In(i).Kind() == reflect.Struct {
+            return true
+        }
+    }
+    return false
 }
 
 func getContentType(request *http.Request) (contentType string) {
     headerSlice := request.Header["Content-Type"]
     if headerSlice != nil && len(headerSlice) > 0 {
-        contentType = headerSlice[0]
+        contentType, _, _ = mime.ParseMediaType(headerSlice[0])
+    }
+    return
+}
+
+func BindRequest(request *http.Request, target interface{}) (err error) {
+    targetVal := reflect.ValueOf(target)
+    if targetVal.Kind() != reflect.Ptr || targetVal.Elem().Kind() != reflect.Struct {
+        return errors.New("Binding target must be a pointer to a struct")
+    }
+    var b *binder
+    if b, err = createBinder(request); err == nil {
+        err = b.bind(request, targetVal)
+    }
+    return
+}
+
+func (b *binder) bind(request *http.Request, structVal reflect.Value) (err error) {
+    if b.jsonBound {
+        if err = json.NewDecoder(request.Body).Decode(
+                structVal.Interface()); err != nil {
+            return
+        }
     }
+    _, err = b.bindStruct(structVal.Elem(), "", formSource, nil)
     return
 }
@@ -13,18 +13,24 @@ import (
 )
 
 func NewRouter(handlers ...HandlerEntry) *RouterComponent {
+    return (&RouterComponent{}).addHandlers(handlers...)
+}
+
+func (router *RouterComponent) addHandlers(handlers ...HandlerEntry) *RouterComponent {
     routes := generateRoutes(handlers...)
 
     var urlGen URLGenerator
     services.GetService(&urlGen)
     if urlGen == nil {   
+        urlGen = NewURLGenerator(routes...)
         services.AddSingleton(func () URLGenerator {
-            return &routeUrlGenerator { routes: routes }
+            return urlGen
         })
     } else {
         urlGen.AddRoutes(routes)
     }
-    return &RouterComponent{ routes: routes }
+    router.routes = append(router.routes, routes...)
+    return router
 }
 
 
@@ -63,11 +69,23 @@ func (router *RouterComponent) invokeHandler(route Route, rawParams []string,
       route.handlerMethod, rawParams)
   if (err == nil) {
       structVal := reflect.New(route.handlerMethod.Type.In(0))
-      services.PopulateForContext(context.Context(), structVal.Interface())
+      services.PopulateForContext(pipeline.WithRequest(context.Context(),
+          context.Request), structVal.Interface())
       paramVals = append([]reflect.Value { structVal.Elem() }, paramVals...)
-      result := route.handlerMethod.Func.Call(paramVals)
+      var result []reflect.Value
+      if filtered := filterAction(context.Context(), route, structVal.Elem()); 
+              filtered != nil {
+          result = []reflect.Value { reflect.ValueOf(filtered) }
+      } else {
+          result = route.handlerMethod.Func.Call(paramVals)
+      }
       if len(result) > 0 {
           if action, ok := result[0].Interface().(actionresults.ActionResult); ok {
+              var observer ActionObserver
+              if services.GetServiceForContext(context.Context(), &observer) == nil {
+                  observer.ObserveAction(context.Context(), route.handlerName, 
+                      route.handlerMethod.Name, action)
+              }
               invoker := createInvokehandlerFunc(context.Context(), router.routes)
               err = services.PopulateForContextWithExtras(context.Context(), 
                   action, 
@@ -0,0 +1,104 @@
+package handling
+
+import (
+    "net/http"
+    "platform/pipeline"
+    "regexp"
+    "strings"
+)
+
+func NewRouteGroup(prefix string, components ...interface{}) *RouteGroupComponent {
+    prefix = strings.Trim(prefix, "/")
+    return &RouteGroupComponent{
+        prefix: prefix,
+        expression: regexp.MustCompile("(?i)^/" + regexp.QuoteMeta(prefix) + "(/|$)"),
+        components: components,
+    }
+}
+
+type RouteGroupComponent struct {
+    prefix string
+    expression *regexp.Regexp
+    components []interface{}
+    router *RouterComponent
+    fallbacks []groupFallback
+    groupPipeline pipeline.RequestPipeline
+}
+
+type groupFallback struct {
+    expression *regexp.Regexp
+    target string
+}
+
+func (group *RouteGroupComponent) Init() {
+    components := group.components
+    if group.router != nil {
+        components = append(components, group.router)
+    }
+    group.groupPipeline = pipeline.CreatePipeline(components...)
+}
+
+func (group *RouteGroupComponent) ProcessRequest(context *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext)) {
+    if !group.expression.MatchString(context.Request.URL.Path) {
+        next(context)
+        return
+    }
+    for _, fallback := range group.fallbacks {
+        if fallback.expression.MatchString(context.Request.URL.Path) {
+            http.Redirect(context.ResponseWriter, context.Request, 
+                fallback.target, http.StatusSeeOther)
+            return
+        }
+    }
+    group.groupPipeline(context)
+}
+
+func (group *RouteGroupComponent) Prefix() string {
+    return "/" + group.prefix
+}
+
+func (group *RouteGroupComponent) AddHandlers(
+        handlers ...interface{}) *RouteGroupComponent {
+    entries := []HandlerEntry {}
+    for _, handler := range handlers {
+        entries = append(entries, HandlerEntry{ "", handler })
+    }
+    return group.AddHandlerEntries(entries...)
+}
+
+func (group *RouteGroupComponent) AddHandlerEntries(
+        entries ...HandlerEntry) *RouteGroupComponent {
+    for i := range entries {
+        entries[i].Prefix = strings.Trim(group.prefix + "/" + 
+            strings.Trim(entries[i].Prefix, "/"), "/")
+    }
+    if group.router == nil {
+        group.router = NewRouter(entries...)
+    } else {
+        group.router.addHandlers(entries...)
+    }
+    return group
+}
+
+func (group *RouteGroupComponent) AddMethodAlias(srcUrl string, 
+        method interface{}, data ...interface{}) *RouteGroupComponent {
+    group.ensureRouter().AddMethodAlias(srcUrl, method, data...)
+    return group
+}
+
+func (group *RouteGroupComponent) AddFallback(target string, 
+        patterns ...string) *RouteGroupComponent {
+    for _, p := range patterns {
+        group.fallbacks = append(group.fallbacks, 
+            groupFallback{ regexp.MustCompile(p), target })
+    }
+    return group
+}
+
+func (group *RouteGroupComponent) ensureRouter() *RouterComponent {
+    if group.router == nil {
+        group.router = NewRouter()
+    }
+    return group.router
+}
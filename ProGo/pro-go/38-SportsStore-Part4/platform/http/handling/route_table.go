@@ -0,0 +1,58 @@
+package handling
+
+type RouteDescription struct {
+    Method string
+    Pattern string
+    Handler string
+    Action string
+    Target string
+}
+
+type RouteDescriber interface {
+    DescribeRoutes() []RouteDescription
+}
+
+func (router *RouterComponent) DescribeRoutes() []RouteDescription {
+    descriptions := make([]RouteDescription, 0, len(router.routes))
+    for _, route := range router.routes {
+        description := RouteDescription{
+            Method: route.httpMethod,
+            Pattern: route.expression.String(),
+            Handler: route.handlerName,
+            Action: route.handlerMethod.Name,
+            Target: route.target,
+        }
+        if route.target != "" {
+            description.Action = route.actionName
+        }
+        descriptions = append(descriptions, description)
+    }
+    return descriptions
+}
+
+func (group *RouteGroupComponent) DescribeRoutes() []RouteDescription {
+    descriptions := []RouteDescription {}
+    for _, fallback := range group.fallbacks {
+        descriptions = append(descriptions, RouteDescription{
+            Method: "*",
+            Pattern: fallback.expression.String(),
+            Handler: "Fallback",
+            Action: "Redirect",
+            Target: fallback.target,
+        })
+    }
+    if group.router != nil {
+        descriptions = append(descriptions, group.router.DescribeRoutes()...)
+    }
+    return descriptions
+}
+
+func DescribeRoutes(components ...interface{}) []RouteDescription {
+    descriptions := []RouteDescription {}
+    for _, component := range components {
+        if describer, ok := component.(RouteDescriber); ok {
+            descriptions = append(descriptions, describer.DescribeRoutes()...)
+        }
+    }
+    return descriptions
+}
@@ -19,6 +19,7 @@ type Route struct {
     actionName string
     expression regexp.Regexp
     handlerMethod reflect.Method
+    target string
 }
 
 var httpMethods = []string { http.MethodGet, http.MethodPost, 
@@ -89,6 +90,8 @@ func generateRegularExpression(prefix string, route *Route) {
         for i := 1; i < route.handlerMethod.Type.NumIn(); i++ {
             if route.handlerMethod.Type.In(i).Kind() == reflect.Int {
                 pattern += "/([0-9]*)"
+            } else if route.handlerMethod.Type.In(i).Kind() == reflect.Struct {
+                continue
             } else {
                 pattern += "/([A-z0-9]*)"
             }
@@ -18,6 +18,10 @@ type URLGenerator interface {
     AddRoutes(routes []Route)
 }
 
+func NewURLGenerator(routes ...Route) URLGenerator {
+    return &routeUrlGenerator{ routes: routes }
+}
+
 type routeUrlGenerator struct {
     routes []Route
 }
@@ -67,7 +71,7 @@ func generateUrl(route Route, data ...interface{}) (url string, err error) {
     if len(data) > 0 && !strings.EqualFold(route.httpMethod, http.MethodGet) {
         err = errors.New("Only GET handler can have data values")                
     } else if strings.EqualFold(route.httpMethod, http.MethodGet) &&
-            len(data) != route.handlerMethod.Type.NumIn() -1 {
+            len(data) != countUrlParams(route) {
         err = errors.New("Number of data values doesn't match method params")
     } else {
         for _, val := range data {
@@ -76,3 +80,12 @@ func generateUrl(route Route, data ...interface{}) (url string, err error) {
     }
     return
 }
+
+func countUrlParams(route Route) (count int) {
+    for i := 1; i < route.handlerMethod.Type.NumIn(); i++ {
+        if route.handlerMethod.Type.In(i).Kind() != reflect.Struct {
+            count++
+        }
+    }
+    return
+}
@@ -1,8 +1,15 @@
 package http
 
 import (
+    "context"
+    "errors"
     "fmt"
+    "net"
+    "os"
+    "os/signal"
     "sync"
+    "syscall"
+    "time"
     "net/http"
     "platform/config"
     "platform/logging"
@@ -13,24 +20,66 @@ type pipelineAdaptor struct {
     pipeline.RequestPipeline
 }
 
-func (p pipelineAdaptor) ServeHTTP(writer http.ResponseWriter, 
+func (p pipelineAdaptor) ServeHTTP(writer http.ResponseWriter,
     request *http.Request) {
         p.ProcessRequest(request, writer)
 }
 
+var shutdownHooks = []func() {}
+var shutdownLock = sync.Mutex{}
+
+func OnShutdown(hook func()) {
+    shutdownLock.Lock()
+    defer shutdownLock.Unlock()
+    shutdownHooks = append(shutdownHooks, hook)
+}
+
+func runShutdownHooks() {
+    shutdownLock.Lock()
+    hooks := append([]func() {}, shutdownHooks...)
+    shutdownLock.Unlock()
+    for _, hook := range hooks {
+        hook()
+    }
+}
+
 func Serve(pl pipeline.RequestPipeline, cfg config.Configuration, logger logging.Logger ) *sync.WaitGroup {
     wg := sync.WaitGroup{}
 
     adaptor := pipelineAdaptor { RequestPipeline: pl }
+    servers := []*http.Server {}
+    shutdown, closeOnce := make(chan struct{}), sync.Once{}
+    newServer := func(addr string) *http.Server {
+        server := &http.Server{ Addr: addr, Handler: adaptor,
+            BaseContext: func(net.Listener) context.Context {
+                return pipeline.WithShutdown(context.Background(), shutdown)
+            },
+        }
+        server.RegisterOnShutdown(func() {
+            closeOnce.Do(func() { close(shutdown) })
+        })
+        return server
+    }
+
+    delay, err := time.ParseDuration(cfg.GetStringDefault("http:shutdownDelay", "0s"))
+    if (err != nil) {
+        panic(err)
+    }
+    timeout, err := time.ParseDuration(cfg.GetStringDefault("http:shutdownTimeout", "10s"))
+    if (err != nil) {
+        panic(err)
+    }
 
     enableHttp := cfg.GetBoolDefault("http:enableHttp", true)
     if (enableHttp) {
         httpPort := cfg.GetIntDefault("http:port", 5000)
         logger.Debugf("Starting HTTP server on port %v", httpPort)
+        server := newServer(fmt.Sprintf(":%v", httpPort))
+        servers = append(servers, server)
         wg.Add(1)
         go func() {
-            err := http.ListenAndServe(fmt.Sprintf(":%v", httpPort), adaptor)
-            if (err != nil) {
+            err := server.ListenAndServe()
+            if (err != nil && !errors.Is(err, http.ErrServerClosed)) {
                 panic(err)
             }
         }()
@@ -42,11 +91,12 @@ func Serve(pl pipeline.RequestPipeline, cfg config.Configuration, logger logging
         keyFile, kfok := cfg.GetString("http:httpsKey")
         if cfok && kfok {
             logger.Debugf("Starting HTTPS server on port %v", httpsPort)
+            server := newServer(fmt.Sprintf(":%v", httpsPort))
+            servers = append(servers, server)
             wg.Add(1)
             go func() {
-                err := http.ListenAndServeTLS(fmt.Sprintf(":%v", httpsPort),
-                    certFile, keyFile, adaptor)
-                if (err != nil) {
+                err := server.ListenAndServeTLS(certFile, keyFile)
+                if (err != nil && !errors.Is(err, http.ErrServerClosed)) {
                     panic(err)
                 }
             }()
@@ -54,5 +104,25 @@ func Serve(pl pipeline.RequestPipeline, cfg config.Configuration, logger logging
             panic("HTTPS certificate settings not found")
         }
     }
+    go shutdownOnSignal(servers, delay, timeout, logger, &wg)
     return &wg
 }
+
+func shutdownOnSignal(servers []*http.Server, delay, timeout time.Duration,
+        logger logging.Logger, wg *sync.WaitGroup) {
+    signals := make(chan os.Signal, 1)
+    signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+    sig := <- signals
+    signal.Stop(signals)
+    logger.Infof("Received %v, shutting down", sig)
+    runShutdownHooks()
+    time.Sleep(delay)
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+    for _, server := range servers {
+        if err := server.Shutdown(ctx); err != nil {
+            logger.Warnf("Server on %v did not shut down cleanly: %v", server.Addr, err)
+        }
+        wg.Done()
+    }
+}
@@ -0,0 +1,141 @@
+package jobs
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+type Schedule interface {
+    Next(after time.Time) time.Time
+}
+
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(after time.Time) time.Time {
+    return after.Add(time.Duration(s))
+}
+
+type cronSchedule struct {
+    minute, hour, dom, month, dow uint64
+    domStar, dowStar bool
+}
+
+var cronAliases = map[string]string {
+    "@yearly": "0 0 1 1 *",
+    "@annually": "0 0 1 1 *",
+    "@monthly": "0 0 1 * *",
+    "@weekly": "0 0 * * 0",
+    "@daily": "0 0 * * *",
+    "@midnight": "0 0 * * *",
+    "@hourly": "0 * * * *",
+}
+
+func ParseSchedule(spec string) (Schedule, error) {
+    spec = strings.TrimSpace(spec)
+    if strings.HasPrefix(spec, "@every ") {
+        interval, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+        if err != nil || interval < time.Second {
+            return nil, fmt.Errorf("Invalid schedule interval: %v", spec)
+        }
+        return intervalSchedule(interval), nil
+    }
+    if alias, found := cronAliases[spec]; found {
+        spec = alias
+    }
+    fields := strings.Fields(spec)
+    if len(fields) != 5 {
+        return nil, fmt.Errorf("Schedule must have five fields: %v", spec)
+    }
+    bounds := [][2]int { { 0, 59 }, { 0, 23 }, { 1, 31 }, { 1, 12 }, { 0, 7 } }
+    values := make([]uint64, 5)
+    for i, field := range fields {
+        bits, err := parseCronField(field, bounds[i][0], bounds[i][1])
+        if err != nil {
+            return nil, fmt.Errorf("Invalid schedule %v: %v", spec, err.Error())
+        }
+        values[i] = bits
+    }
+    if values[4] & (1 << 7) != 0 {
+        values[4] |= 1
+    }
+    schedule := &cronSchedule{ minute: values[0], hour: values[1], dom: values[2],
+        month: values[3], dow: values[4], domStar: fields[2] == "*", 
+        dowStar: fields[4] == "*" }
+    if schedule.Next(cronReference).IsZero() {
+        return nil, fmt.Errorf("Schedule never runs: %v", spec)
+    }
+    return schedule, nil
+}
+
+var cronReference = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func parseCronField(field string, min, max int) (bits uint64, err error) {
+    for _, part := range strings.Split(field, ",") {
+        step := 1
+        if idx := strings.Index(part, "/"); idx >= 0 {
+            if step, err = strconv.Atoi(part[idx + 1:]); err != nil || step < 1 {
+                return 0, fmt.Errorf("invalid step in %v", part)
+            }
+            part = part[:idx]
+        }
+        low, high := min, max
+        if part != "*" {
+            bounds := strings.SplitN(part, "-", 2)
+            if low, err = strconv.Atoi(bounds[0]); err != nil {
+                return 0, fmt.Errorf("invalid value %v", part)
+            }
+            high = low
+            if len(bounds) == 2 {
+                if high, err = strconv.Atoi(bounds[1]); err != nil {
+                    return 0, fmt.Errorf("invalid value %v", part)
+                }
+            } else if step > 1 {
+                high = max
+            }
+        }
+        if low < min || high > max || low > high {
+            return 0, fmt.Errorf("%v is outside %v-%v", part, min, max)
+        }
+        for i := low; i <= high; i += step {
+            bits |= 1 << uint(i)
+        }
+    }
+    return
+}
+
+func (s *cronSchedule) Next(after time.Time) time.Time {
+    t := after.Truncate(time.Minute).Add(time.Minute)
+    limit := t.AddDate(9, 0, 0)
+    for t.Before(limit) {
+        if s.month & (1 << uint(t.Month())) == 0 {
+            t = time.Date(t.Year(), t.Month() + 1, 1, 0, 0, 0, 0, t.Location())
+            continue
+        }
+        if !s.matchesDay(t) {
+            t = time.Date(t.Year(), t.Month(), t.Day() + 1, 0, 0, 0, 0, t.Location())
+            continue
+        }
+        if s.hour & (1 << uint(t.Hour())) == 0 {
+            t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour() + 1, 0, 0, 0, 
+                t.Location())
+            continue
+        }
+        if s.minute & (1 << uint(t.Minute())) == 0 {
+            t = t.Add(time.Minute)
+            continue
+        }
+        return t
+    }
+    return time.Time{}
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+    domMatch := s.dom & (1 << uint(t.Day())) != 0
+    dowMatch := s.dow & (1 << uint(t.Weekday())) != 0
+    if s.domStar || s.dowStar {
+        return domMatch && dowMatch
+    }
+    return domMatch || dowMatch
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "time"
+)
+
+const (
+    StatusPending = "pending"
+    StatusRunning = "running"
+    StatusSucceeded = "succeeded"
+    StatusFailed = "failed"
+)
+
+var ErrJobNotFound = errors.New("Job not found")
+
+type Job struct {
+    ID int
+    Name string
+    Payload string
+    Status string
+    Attempts int
+    MaxAttempts int
+    RunAt time.Time
+    Created time.Time
+    Updated time.Time
+    LastError string
+}
+
+func NewJob(name string, payload interface{}) (*Job, error) {
+    job := &Job{ Name: name, Status: StatusPending }
+    if payload != nil {
+        data, err := json.Marshal(payload)
+        if err != nil {
+            return nil, err
+        }
+        job.Payload = string(data)
+    }
+    return job, nil
+}
+
+func (job Job) Decode(target interface{}) error {
+    if job.Payload == "" {
+        return nil
+    }
+    return json.Unmarshal([]byte(job.Payload), target)
+}
+
+type JobFilter struct {
+    Status string
+    Name string
+    Limit int
+}
+
+type JobQueue interface {
+    Enqueue(ctx context.Context, job *Job) error
+    Claim(ctx context.Context, now time.Time, lease time.Duration) (job Job, 
+        found bool, err error)
+    Update(ctx context.Context, job *Job) error
+    GetJob(ctx context.Context, id int) (Job, error)
+    GetJobs(ctx context.Context, filter JobFilter) ([]Job, error)
+    GetJobCounts(ctx context.Context) (map[string]int, error)
+    Prune(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+    "platform/config"
+    "platform/logging"
+    "platform/services"
+)
+
+func RegisterJobService() {
+    err := services.AddSingleton(func() JobQueue {
+        return NewMemoryJobQueue()
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddSingleton(func(logger logging.Logger, 
+            cfg config.Configuration) *Scheduler {
+        var queue JobQueue
+        if err := services.GetService(&queue); err != nil {
+            panic(err)
+        }
+        return NewScheduler(queue, logger, cfg)
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
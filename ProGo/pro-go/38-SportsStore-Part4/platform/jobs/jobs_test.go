@@ -0,0 +1,168 @@
+package jobs
+
+import (
+    "context"
+    "errors"
+    "platform/config"
+    "platform/logging"
+    "testing"
+    "time"
+)
+
+func TestParseSchedule(t *testing.T) {
+    after := time.Date(2024, 1, 31, 10, 30, 20, 0, time.UTC)
+    tests := map[string]time.Time {
+        "*/15 * * * *": time.Date(2024, 1, 31, 10, 45, 0, 0, time.UTC),
+        "0 3 * * *": time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC),
+        "@monthly": time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+        "0 0 * * 7": time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC),
+        "0 0 29 2 *": time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+        "0 0 13 * 5": time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+        "@every 90s": time.Date(2024, 1, 31, 10, 31, 50, 0, time.UTC),
+    }
+    for spec, expected := range tests {
+        schedule, err := ParseSchedule(spec)
+        if err != nil {
+            t.Fatalf("Cannot parse %v: %v", spec, err)
+        }
+        if next := schedule.Next(after); !next.Equal(expected) {
+            t.Fatalf("%v: expected %v, got %v", spec, expected, next)
+        }
+    }
+    for _, spec := range []string { "", "* * * *", "60 * * * *", "5-1 * * * *",
+            "*/0 * * * *", "@every 10ms", "0 0 30 2 *", "0 0 31 4,6,9,11 *" } {
+        if _, err := ParseSchedule(spec); err == nil {
+            t.Fatalf("Expected %q to be rejected", spec)
+        }
+    }
+}
+
+type neverSchedule struct {}
+
+func (neverSchedule) Next(after time.Time) time.Time { return time.Time{} }
+
+func newTestScheduler() *Scheduler {
+    cfg := config.NewMapConfig(map[string]interface{} {
+        "logging": map[string]interface{} { "level": "none" },
+        "jobs": map[string]interface{} {
+            "pollInterval": "10ms",
+            "backoff": "10ms",
+            "maxAttempts": 2.0,
+        },
+    })
+    return NewScheduler(NewMemoryJobQueue(), logging.NewDefaultLogger(cfg), cfg)
+}
+
+func TestDueSchedules(t *testing.T) {
+    scheduler := newTestScheduler()
+    if err := scheduler.Schedule("hourly", "@hourly", nil); err != nil {
+        t.Fatal(err)
+    }
+    scheduler.schedules = append(scheduler.schedules, &scheduledJob{
+        ScheduleInfo: ScheduleInfo{ Name: "never" }, schedule: neverSchedule{} })
+    now := time.Now().Add(2 * time.Hour)
+    due := scheduler.dueSchedules(now)
+    if len(due) != 1 || due[0].Name != "hourly" {
+        t.Fatalf("Expected only the hourly schedule to be due, got %v", due)
+    }
+    if due := scheduler.dueSchedules(now); len(due) != 0 {
+        t.Fatalf("Expected schedule to advance, got %v", due)
+    }
+}
+
+func TestSchedulerHandlers(t *testing.T) {
+    scheduler := newTestScheduler()
+    for _, handler := range []interface{} { nil, "handler", func() {},
+            func(job Job) int { return 0 } } {
+        if scheduler.Handle("bad", handler) == nil {
+            t.Fatalf("Expected handler to be rejected: %T", handler)
+        }
+    }
+    done := make(chan string, 3)
+    scheduler.Handle("ok", func(job Job) {
+        var payload string
+        job.Decode(&payload)
+        done <- payload
+    })
+    scheduler.Handle("panics", func(job Job) error {
+        panic("boom")
+    })
+    ctx, cancel := context.WithCancel(context.Background())
+    wg := scheduler.Start(ctx)
+    okJob, err := scheduler.Enqueue(ctx, "ok", "payload")
+    if err != nil {
+        t.Fatal(err)
+    }
+    panicJob, _ := scheduler.Enqueue(ctx, "panics", nil)
+    if payload := <- done; payload != "payload" {
+        t.Fatalf("Unexpected payload: %v", payload)
+    }
+    deadline := time.Now().Add(5 * time.Second)
+    for time.Now().Before(deadline) {
+        stored, _ := scheduler.Queue.GetJob(ctx, panicJob.ID)
+        if stored.Status == StatusFailed {
+            if stored.Attempts != 2 || stored.LastError != "Job panicked: boom" {
+                t.Fatalf("Unexpected failed job: %+v", stored)
+            }
+            break
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    if stored, _ := scheduler.Queue.GetJob(ctx, okJob.ID); stored.Status != 
+            StatusSucceeded {
+        t.Fatalf("Expected job to succeed: %+v", stored)
+    }
+    cancel()
+    wg.Wait()
+    if scheduler.Status().Running {
+        t.Fatal("Expected scheduler to stop when its context is cancelled")
+    }
+    if err := scheduler.Retry(context.Background(), okJob.ID); err == nil {
+        t.Fatal("Expected retry of a successful job to fail")
+    }
+}
+
+func TestMemoryJobQueue(t *testing.T) {
+    ctx := context.Background()
+    queue := NewMemoryJobQueue()
+    now := time.Now()
+    later, _ := NewJob("later", nil)
+    later.RunAt = now.Add(time.Hour)
+    first, _ := NewJob("first", nil)
+    first.RunAt = now.Add(-time.Minute)
+    for _, job := range []*Job { later, first } {
+        if err := queue.Enqueue(ctx, job); err != nil {
+            t.Fatal(err)
+        }
+    }
+    job, found, err := queue.Claim(ctx, now, time.Minute)
+    if err != nil || !found || job.ID != first.ID || job.Attempts != 1 || 
+            job.Status != StatusRunning {
+        t.Fatalf("Expected earliest job to be claimed: %+v %v %v", job, found, err)
+    }
+    if _, found, _ = queue.Claim(ctx, now, time.Minute); found {
+        t.Fatal("Expected leased and future jobs not to be claimed")
+    }
+    if job, found, _ = queue.Claim(ctx, now.Add(2 * time.Minute), 
+            time.Minute); !found || job.ID != first.ID || job.Attempts != 2 {
+        t.Fatalf("Expected expired lease to be reclaimed: %+v", job)
+    }
+    job.Status = StatusSucceeded
+    if err := queue.Update(ctx, &job); err != nil {
+        t.Fatal(err)
+    }
+    if err := queue.Update(ctx, &Job{ ID: 99 }); !errors.Is(err, ErrJobNotFound) {
+        t.Fatalf("Expected ErrJobNotFound, got %v", err)
+    }
+    counts, _ := queue.GetJobCounts(ctx)
+    if counts[StatusSucceeded] != 1 || counts[StatusPending] != 1 {
+        t.Fatalf("Unexpected counts: %v", counts)
+    }
+    if count, _ := queue.Prune(ctx, time.Now().Add(time.Second)); count != 1 {
+        t.Fatalf("Expected finished job to be pruned, got %v", count)
+    }
+    if jobs, _ := queue.GetJobs(ctx, JobFilter{}); len(jobs) != 1 || 
+            jobs[0].Name != "later" {
+        t.Fatalf("Unexpected remaining jobs: %v", jobs)
+    }
+}
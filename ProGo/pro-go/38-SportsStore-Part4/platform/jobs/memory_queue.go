@@ -0,0 +1,124 @@
+package jobs
+
+import (
+    "context"
+    "sort"
+    "sync"
+    "time"
+)
+
+type MemoryJobQueue struct {
+    mutex sync.Mutex
+    jobs map[int]*Job
+    nextID int
+}
+
+func NewMemoryJobQueue() *MemoryJobQueue {
+    return &MemoryJobQueue{ jobs: map[int]*Job {} }
+}
+
+func (q *MemoryJobQueue) Enqueue(ctx context.Context, job *Job) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    q.nextID++
+    now := time.Now()
+    job.ID, job.Status, job.Created, job.Updated = q.nextID, StatusPending, now, now
+    if job.RunAt.IsZero() {
+        job.RunAt = now
+    }
+    stored := *job
+    q.jobs[job.ID] = &stored
+    return nil
+}
+
+func (q *MemoryJobQueue) Claim(ctx context.Context, now time.Time, 
+        lease time.Duration) (job Job, found bool, err error) {
+    if err = ctx.Err(); err != nil {
+        return
+    }
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    var next *Job
+    for _, candidate := range q.jobs {
+        if (candidate.Status == StatusPending || candidate.Status == StatusRunning) &&
+                !candidate.RunAt.After(now) && (next == nil || 
+                candidate.RunAt.Before(next.RunAt) || 
+                (candidate.RunAt.Equal(next.RunAt) && candidate.ID < next.ID)) {
+            next = candidate
+        }
+    }
+    if next != nil {
+        next.Status, next.Attempts = StatusRunning, next.Attempts + 1
+        next.RunAt, next.Updated = now.Add(lease), now
+        job, found = *next, true
+    }
+    return
+}
+
+func (q *MemoryJobQueue) Update(ctx context.Context, job *Job) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    if _, found := q.jobs[job.ID]; !found {
+        return ErrJobNotFound
+    }
+    job.Updated = time.Now()
+    stored := *job
+    q.jobs[job.ID] = &stored
+    return nil
+}
+
+func (q *MemoryJobQueue) GetJob(ctx context.Context, id int) (Job, error) {
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    if job, found := q.jobs[id]; found {
+        return *job, nil
+    }
+    return Job{}, ErrJobNotFound
+}
+
+func (q *MemoryJobQueue) GetJobs(ctx context.Context, filter JobFilter) ([]Job, error) {
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    jobs := []Job {}
+    for _, job := range q.jobs {
+        if (filter.Status == "" || job.Status == filter.Status) && 
+                (filter.Name == "" || job.Name == filter.Name) {
+            jobs = append(jobs, *job)
+        }
+    }
+    sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID > jobs[j].ID })
+    if filter.Limit > 0 && len(jobs) > filter.Limit {
+        jobs = jobs[:filter.Limit]
+    }
+    return jobs, nil
+}
+
+func (q *MemoryJobQueue) GetJobCounts(ctx context.Context) (map[string]int, error) {
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    counts := map[string]int {}
+    for _, job := range q.jobs {
+        counts[job.Status]++
+    }
+    return counts, nil
+}
+
+func (q *MemoryJobQueue) Prune(ctx context.Context, before time.Time) (int, error) {
+    q.mutex.Lock()
+    defer q.mutex.Unlock()
+    count := 0
+    for id, job := range q.jobs {
+        if (job.Status == StatusSucceeded || job.Status == StatusFailed) && 
+                job.Updated.Before(before) {
+            delete(q.jobs, id)
+            count++
+        }
+    }
+    return count, nil
+}
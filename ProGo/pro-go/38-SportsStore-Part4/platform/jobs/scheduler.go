@@ -0,0 +1,327 @@
+package jobs
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "platform/config"
+    "platform/logging"
+    "platform/services"
+    "reflect"
+    "sort"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+var ErrNoHandler = errors.New("No handler is registered for job")
+
+var jobType = reflect.TypeOf(Job{})
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type ScheduleInfo struct {
+    Name string
+    Spec string
+    Next time.Time
+    Last time.Time
+}
+
+type SchedulerStatus struct {
+    Running bool
+    Workers int
+    Busy int
+    Handlers []string
+    Schedules []ScheduleInfo
+}
+
+type scheduledJob struct {
+    ScheduleInfo
+    payload interface{}
+    schedule Schedule
+}
+
+type Scheduler struct {
+    Queue JobQueue
+    logging.Logger
+    config.Configuration
+    mutex sync.Mutex
+    handlers map[string]interface{}
+    schedules []*scheduledJob
+    wake chan struct{}
+    workers int
+    busy int32
+    running bool
+}
+
+func NewScheduler(queue JobQueue, logger logging.Logger, 
+        cfg config.Configuration) *Scheduler {
+    return &Scheduler{
+        Queue: queue,
+        Logger: logger,
+        Configuration: cfg,
+        handlers: map[string]interface{} {},
+        wake: make(chan struct{}, 1),
+    }
+}
+
+func (s *Scheduler) Handle(name string, handler interface{}) error {
+    handlerType := reflect.TypeOf(handler)
+    if handlerType == nil || handlerType.Kind() != reflect.Func || 
+            handlerType.NumIn() == 0 || handlerType.In(0) != jobType ||
+            handlerType.NumOut() > 1 || 
+            (handlerType.NumOut() == 1 && handlerType.Out(0) != errorType) {
+        return fmt.Errorf("Type cannot be used as job handler: %v", handlerType)
+    }
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    s.handlers[name] = handler
+    return nil
+}
+
+func (s *Scheduler) Schedule(name, spec string, payload interface{}) error {
+    schedule, err := ParseSchedule(spec)
+    if err != nil {
+        return err
+    }
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    s.schedules = append(s.schedules, &scheduledJob{
+        ScheduleInfo: ScheduleInfo{ Name: name, Spec: spec, 
+            Next: schedule.Next(time.Now()) },
+        payload: payload,
+        schedule: schedule,
+    })
+    return nil
+}
+
+func (s *Scheduler) Enqueue(ctx context.Context, name string, 
+        payload interface{}) (Job, error) {
+    return s.EnqueueAt(ctx, name, payload, time.Now())
+}
+
+func (s *Scheduler) EnqueueAt(ctx context.Context, name string, 
+        payload interface{}, runAt time.Time) (Job, error) {
+    job, err := NewJob(name, payload)
+    if err != nil {
+        return Job{}, err
+    }
+    job.RunAt = runAt
+    job.MaxAttempts = s.Configuration.GetIntDefault("jobs:maxAttempts", 5)
+    if err = s.Queue.Enqueue(ctx, job); err != nil {
+        return Job{}, err
+    }
+    s.notify()
+    return *job, nil
+}
+
+func (s *Scheduler) Retry(ctx context.Context, id int) error {
+    job, err := s.Queue.GetJob(ctx, id)
+    if err != nil {
+        return err
+    }
+    if job.Status != StatusFailed {
+        return fmt.Errorf("Only failed jobs can be retried, job %v is %v", id, 
+            job.Status)
+    }
+    job.Status, job.Attempts, job.LastError = StatusPending, 0, ""
+    job.RunAt = time.Now()
+    if err = s.Queue.Update(ctx, &job); err == nil {
+        s.notify()
+    }
+    return err
+}
+
+func (s *Scheduler) Start(ctx context.Context) *sync.WaitGroup {
+    wg := &sync.WaitGroup{}
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    if s.running {
+        return wg
+    }
+    s.running = true
+    s.workers = s.Configuration.GetIntDefault("jobs:workers", 2)
+    for i := 0; i < s.workers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            s.work(ctx)
+        }()
+    }
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        s.runSchedules(ctx)
+        s.mutex.Lock()
+        s.running = false
+        s.mutex.Unlock()
+    }()
+    s.Logger.Infof("Started %v job workers", s.workers)
+    return wg
+}
+
+func (s *Scheduler) Status() SchedulerStatus {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    status := SchedulerStatus{ Running: s.running, Workers: s.workers, 
+        Busy: int(atomic.LoadInt32(&s.busy)) }
+    for name := range s.handlers {
+        status.Handlers = append(status.Handlers, name)
+    }
+    sort.Strings(status.Handlers)
+    for _, sj := range s.schedules {
+        status.Schedules = append(status.Schedules, sj.ScheduleInfo)
+    }
+    return status
+}
+
+func (s *Scheduler) notify() {
+    select {
+        case s.wake <- struct{}{}:
+        default:
+    }
+}
+
+func (s *Scheduler) work(ctx context.Context) {
+    poll := s.duration("jobs:pollInterval", time.Second)
+    for {
+        job, found, err := s.Queue.Claim(ctx, time.Now(), 
+            s.duration("jobs:timeout", 5 * time.Minute))
+        if err != nil && ctx.Err() == nil {
+            s.Logger.Warnf("Cannot claim job: %v", err.Error())
+        }
+        if found {
+            s.run(ctx, job)
+            continue
+        }
+        select {
+            case <- ctx.Done():
+                return
+            case <- s.wake:
+            case <- time.After(poll):
+        }
+    }
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+    atomic.AddInt32(&s.busy, 1)
+    defer atomic.AddInt32(&s.busy, -1)
+    started := time.Now()
+    err := s.execute(ctx, job)
+    if err == nil {
+        job.Status, job.LastError, job.RunAt = StatusSucceeded, "", started
+        s.Logger.Debugf("Job %v (%v) completed in %v", job.ID, job.Name, 
+            time.Since(started))
+    } else {
+        job.LastError = err.Error()
+        if job.Attempts >= job.MaxAttempts || errors.Is(err, ErrNoHandler) {
+            job.Status, job.RunAt = StatusFailed, started
+            s.Logger.Warnf("Job %v (%v) failed after %v attempts: %v", job.ID, 
+                job.Name, job.Attempts, job.LastError)
+        } else {
+            job.Status, job.RunAt = StatusPending, time.Now().Add(s.backoff(job.Attempts))
+            s.Logger.Infof("Job %v (%v) failed, retrying at %v: %v", job.ID, 
+                job.Name, job.RunAt.Format(time.RFC3339), job.LastError)
+        }
+    }
+    updateCtx, cancel := context.WithTimeout(context.Background(), 10 * time.Second)
+    defer cancel()
+    if err := s.Queue.Update(updateCtx, &job); err != nil {
+        s.Logger.Warnf("Cannot update job %v: %v", job.ID, err.Error())
+    }
+}
+
+func (s *Scheduler) execute(ctx context.Context, job Job) (err error) {
+    s.mutex.Lock()
+    handler, found := s.handlers[job.Name]
+    s.mutex.Unlock()
+    if !found {
+        return fmt.Errorf("%w: %v", ErrNoHandler, job.Name)
+    }
+    jobCtx, cancel := context.WithTimeout(ctx, s.duration("jobs:timeout", 
+        5 * time.Minute))
+    defer cancel()
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("Job panicked: %v", r)
+        }
+    }()
+    results, err := services.CallForContext(services.NewServiceContext(jobCtx), 
+        handler, job)
+    if err == nil && len(results) == 1 && results[0] != nil {
+        err = results[0].(error)
+    }
+    return
+}
+
+func (s *Scheduler) runSchedules(ctx context.Context) {
+    ticker := time.NewTicker(time.Second)
+    defer ticker.Stop()
+    retention := s.duration("jobs:retention", 24 * time.Hour)
+    nextPrune := time.Now()
+    for {
+        select {
+            case <- ctx.Done():
+                return
+            case now := <- ticker.C:
+                for _, sj := range s.dueSchedules(now) {
+                    s.enqueueScheduled(ctx, sj)
+                }
+                if now.After(nextPrune) {
+                    if count, err := s.Queue.Prune(ctx, now.Add(-retention)); err != nil {
+                        s.Logger.Warnf("Cannot prune jobs: %v", err.Error())
+                    } else if count > 0 {
+                        s.Logger.Debugf("Pruned %v finished jobs", count)
+                    }
+                    nextPrune = now.Add(time.Hour)
+                }
+        }
+    }
+}
+
+func (s *Scheduler) dueSchedules(now time.Time) (due []scheduledJob) {
+    s.mutex.Lock()
+    defer s.mutex.Unlock()
+    for _, sj := range s.schedules {
+        if sj.Next.IsZero() {
+            continue
+        }
+        if !now.Before(sj.Next) {
+            sj.Last, sj.Next = now, sj.schedule.Next(now)
+            due = append(due, *sj)
+        }
+    }
+    return
+}
+
+func (s *Scheduler) enqueueScheduled(ctx context.Context, sj scheduledJob) {
+    pending, err := s.Queue.GetJobs(ctx, JobFilter{ Status: StatusPending, 
+        Name: sj.Name, Limit: 1 })
+    if err == nil && len(pending) > 0 {
+        return
+    }
+    if _, err = s.Enqueue(ctx, sj.Name, sj.payload); err != nil {
+        s.Logger.Warnf("Cannot enqueue scheduled job %v: %v", sj.Name, err.Error())
+    }
+}
+
+func (s *Scheduler) backoff(attempts int) time.Duration {
+    delay := s.duration("jobs:backoff", 10 * time.Second)
+    limit := s.duration("jobs:maxBackoff", 30 * time.Minute)
+    for i := 1; i < attempts && delay < limit; i++ {
+        delay *= 2
+    }
+    if delay > limit {
+        delay = limit
+    }
+    return delay
+}
+
+func (s *Scheduler) duration(name string, defVal time.Duration) time.Duration {
+    if val, found := s.Configuration.GetString(name); found {
+        if d, err := time.ParseDuration(val); err == nil && d > 0 {
+            return d
+        }
+        s.Logger.Warnf("Invalid duration for %v: %v", name, val)
+    }
+    return defVal
+}
@@ -0,0 +1,32 @@
+package mail
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sync/atomic"
+    "time"
+)
+
+var fileCounter uint64
+
+type FileMailer struct {
+    Path string
+    From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    if msg.From == "" {
+        msg.From = m.From
+    }
+    if err := os.MkdirAll(m.Path, 0755); err != nil {
+        return err
+    }
+    name := fmt.Sprintf("%v-%v.eml", time.Now().Format("20060102-150405"), 
+        atomic.AddUint64(&fileCounter, 1))
+    return os.WriteFile(filepath.Join(m.Path, name), msg.Bytes(), 0644)
+}
@@ -0,0 +1,134 @@
+package mail
+
+import (
+    "bufio"
+    "context"
+    "net"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestMessageBytes(t *testing.T) {
+    msg := Message{ From: "shop@example.com", To: []string { "a@example.com", 
+        "b@example.com" }, Subject: "Your order ✓", Body: "Line 1\nLine 2" }
+    text := string(msg.Bytes())
+    for _, expected := range []string {
+        "From: shop@example.com\r\n",
+        "To: a@example.com, b@example.com\r\n",
+        "Subject: =?UTF-8?q?Your_order_=E2=9C=93?=\r\n",
+        "Content-Type: text/plain; charset=UTF-8\r\n\r\nLine 1\r\nLine 2",
+    } {
+        if !strings.Contains(text, expected) {
+            t.Fatalf("Expected %q in %q", expected, text)
+        }
+    }
+    msg.HTML, msg.Subject = true, "Injected\r\nBcc: x@example.com"
+    text = string(msg.Bytes())
+    if !strings.Contains(text, "Content-Type: text/html") || 
+            strings.Contains(text, "\r\nBcc:") {
+        t.Fatalf("Unexpected HTML message: %q", text)
+    }
+}
+
+func TestMemoryMailer(t *testing.T) {
+    mailer := &MemoryMailer{ From: "shop@example.com" }
+    if err := mailer.Send(context.Background(), Message{ Subject: "One" }); err != nil {
+        t.Fatal(err)
+    }
+    mailer.Send(context.Background(), Message{ From: "other@example.com" })
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if err := mailer.Send(ctx, Message{}); err != context.Canceled {
+        t.Fatalf("Expected cancelled send to fail, got %v", err)
+    }
+    messages := mailer.Messages()
+    if len(messages) != 2 || messages[0].From != "shop@example.com" || 
+            messages[1].From != "other@example.com" {
+        t.Fatalf("Unexpected messages: %v", messages)
+    }
+    messages[0].Subject = "Changed"
+    if mailer.Messages()[0].Subject != "One" {
+        t.Fatal("Expected Messages to return a copy")
+    }
+}
+
+func TestFileMailer(t *testing.T) {
+    dir := filepath.Join(t.TempDir(), "mail")
+    mailer := &FileMailer{ Path: dir, From: "shop@example.com" }
+    for i := 0; i < 2; i++ {
+        if err := mailer.Send(context.Background(), Message{ 
+                To: []string { "a@example.com" }, Body: "Hello" }); err != nil {
+            t.Fatal(err)
+        }
+    }
+    files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+    if err != nil || len(files) != 2 {
+        t.Fatalf("Expected two message files, got %v %v", files, err)
+    }
+    data, err := os.ReadFile(files[0])
+    if err != nil || !strings.HasPrefix(string(data), "From: shop@example.com\r\n") {
+        t.Fatalf("Unexpected message file: %q %v", data, err)
+    }
+}
+
+func TestSmtpMailer(t *testing.T) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer listener.Close()
+    received := make(chan []string, 1)
+    go func() {
+        conn, err := listener.Accept()
+        if err != nil {
+            return
+        }
+        defer conn.Close()
+        reader, lines := bufio.NewReader(conn), []string {}
+        conn.Write([]byte("220 localhost ESMTP\r\n"))
+        inData := false
+        for {
+            line, err := reader.ReadString('\n')
+            if err != nil {
+                break
+            }
+            line = strings.TrimRight(line, "\r\n")
+            lines = append(lines, line)
+            switch {
+                case inData && line == ".":
+                    inData = false
+                    conn.Write([]byte("250 OK\r\n"))
+                case inData:
+                case strings.HasPrefix(line, "EHLO"):
+                    conn.Write([]byte("250 localhost\r\n"))
+                case line == "DATA":
+                    inData = true
+                    conn.Write([]byte("354 Go ahead\r\n"))
+                case line == "QUIT":
+                    conn.Write([]byte("221 Bye\r\n"))
+                    received <- lines
+                    return
+                default:
+                    conn.Write([]byte("250 OK\r\n"))
+            }
+        }
+        received <- lines
+    }()
+    addr := listener.Addr().(*net.TCPAddr)
+    mailer := &SmtpMailer{ Host: "127.0.0.1", Port: addr.Port, 
+        From: "shop@example.com" }
+    err = mailer.Send(context.Background(), Message{ To: []string { "a@example.com" },
+        Subject: "Order", Body: "Thanks" })
+    if err != nil {
+        t.Fatal(err)
+    }
+    conversation := strings.Join(<- received, "\n")
+    for _, expected := range []string { "MAIL FROM:<shop@example.com>", 
+            "RCPT TO:<a@example.com>", "Subject: Order", "Thanks" } {
+        if !strings.Contains(conversation, expected) {
+            t.Fatalf("Expected %q in %q", expected, conversation)
+        }
+    }
+}
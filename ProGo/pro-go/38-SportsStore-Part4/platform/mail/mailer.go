@@ -0,0 +1,81 @@
+package mail
+
+import (
+    "context"
+    "fmt"
+    "mime"
+    "platform/config"
+    "platform/services"
+    "strings"
+    "sync"
+    "time"
+)
+
+type Message struct {
+    From string
+    To []string
+    Subject string
+    Body string
+    HTML bool
+}
+
+type Mailer interface {
+    Send(ctx context.Context, msg Message) error
+}
+
+func (msg Message) Bytes() []byte {
+    contentType := "text/plain"
+    if msg.HTML {
+        contentType = "text/html"
+    }
+    var sb strings.Builder
+    fmt.Fprintf(&sb, "From: %v\r\n", msg.From)
+    fmt.Fprintf(&sb, "To: %v\r\n", strings.Join(msg.To, ", "))
+    fmt.Fprintf(&sb, "Subject: %v\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+    fmt.Fprintf(&sb, "Date: %v\r\n", time.Now().Format(time.RFC1123Z))
+    fmt.Fprintf(&sb, "MIME-Version: 1.0\r\n")
+    fmt.Fprintf(&sb, "Content-Type: %v; charset=UTF-8\r\n\r\n", contentType)
+    sb.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+    return []byte(sb.String())
+}
+
+func RegisterMailService() {
+    mailers := map[config.Configuration]Mailer {}
+    lock := sync.Mutex {}
+    err := services.AddScoped(func(c config.Configuration) Mailer {
+        lock.Lock()
+        defer lock.Unlock()
+        mailer, found := mailers[c]
+        if !found {
+            mailer = NewMailer(c)
+            mailers[c] = mailer
+        }
+        return mailer
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
+
+func NewMailer(c config.Configuration) Mailer {
+    from := c.GetStringDefault("mail:from", "no-reply@localhost")
+    switch mailerType := c.GetStringDefault("mail:type", "file"); mailerType {
+        case "smtp":
+            return &SmtpMailer{
+                Host: c.GetStringDefault("mail:smtp:host", "localhost"),
+                Port: c.GetIntDefault("mail:smtp:port", 25),
+                Username: c.GetStringDefault("mail:smtp:username", ""),
+                Password: c.GetStringDefault("mail:smtp:password", ""),
+                From: from,
+            }
+        case "file":
+            return &FileMailer{ 
+                Path: c.GetStringDefault("mail:path", "mail"), 
+                From: from,
+            }
+        case "memory":
+            return &MemoryMailer{ From: from }
+        default:
+            panic("Unknown mail type: " + mailerType)
+    }
+}
@@ -0,0 +1,31 @@
+package mail
+
+import (
+    "context"
+    "sync"
+)
+
+type MemoryMailer struct {
+    From string
+    mutex sync.Mutex
+    messages []Message
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, msg Message) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    if msg.From == "" {
+        msg.From = m.From
+    }
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.messages = append(m.messages, msg)
+    return nil
+}
+
+func (m *MemoryMailer) Messages() []Message {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    return append([]Message(nil), m.messages...)
+}
@@ -0,0 +1,30 @@
+package mail
+
+import (
+    "context"
+    "fmt"
+    "net/smtp"
+)
+
+type SmtpMailer struct {
+    Host string
+    Port int
+    Username string
+    Password string
+    From string
+}
+
+func (m *SmtpMailer) Send(ctx context.Context, msg Message) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    if msg.From == "" {
+        msg.From = m.From
+    }
+    var auth smtp.Auth
+    if m.Username != "" {
+        auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+    }
+    return smtp.SendMail(fmt.Sprintf("%v:%v", m.Host, m.Port), auth, msg.From, 
+        msg.To, msg.Bytes())
+}
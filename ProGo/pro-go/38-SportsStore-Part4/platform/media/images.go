@@ -0,0 +1,176 @@
+package media
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "image"
+    "image/draw"
+    "image/gif"
+    "image/jpeg"
+    "image/png"
+    "io"
+    "mime/multipart"
+    "net/http"
+    "path"
+    "strings"
+)
+
+var imageExtensions = map[string]string {
+    "image/jpeg": ".jpg",
+    "image/png": ".png",
+    "image/gif": ".gif",
+}
+
+const MaxImageDimension = 8192
+
+type UploadedImage struct {
+    Data []byte
+    ContentType string
+    Extension string
+    Width int
+    Height int
+}
+
+func ReadImageUpload(header *multipart.FileHeader, maxSize int64, 
+        maxDimension int) (upload UploadedImage, err error) {
+    if header.Size > maxSize {
+        err = fmt.Errorf("The image cannot be larger than %v KB", maxSize / 1024)
+        return
+    }
+    file, err := header.Open()
+    if err != nil {
+        return
+    }
+    defer file.Close()
+    upload.Data, err = io.ReadAll(io.LimitReader(file, maxSize + 1))
+    if err != nil {
+        return
+    }
+    if int64(len(upload.Data)) > maxSize {
+        err = fmt.Errorf("The image cannot be larger than %v KB", maxSize / 1024)
+        return
+    }
+    upload.ContentType = http.DetectContentType(upload.Data)
+    ext, ok := imageExtensions[upload.ContentType]
+    if !ok {
+        err = errors.New("Only JPEG, PNG and GIF images can be uploaded")
+        return
+    }
+    upload.Extension = ext
+    upload.Width, upload.Height, err = imageDimensions(upload.Data, maxDimension)
+    return
+}
+
+func imageDimensions(data []byte, maxDimension int) (width, height int, 
+        err error) {
+    if maxDimension <= 0 || maxDimension > MaxImageDimension {
+        maxDimension = MaxImageDimension
+    }
+    cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+    if err != nil {
+        return 0, 0, fmt.Errorf("The image cannot be decoded: %v", err)
+    }
+    if cfg.Width < 1 || cfg.Height < 1 || cfg.Width > maxDimension || 
+            cfg.Height > maxDimension {
+        return 0, 0, fmt.Errorf("The image cannot be larger than %v x %v pixels", 
+            maxDimension, maxDimension)
+    }
+    return cfg.Width, cfg.Height, nil
+}
+
+func VariantName(name string, width int) string {
+    ext := path.Ext(name)
+    return fmt.Sprintf("%v-%v%v", strings.TrimSuffix(name, ext), width, ext)
+}
+
+func SrcSet(store MediaStore, name string, widths []int) string {
+    entries := make([]string, len(widths))
+    for i, width := range widths {
+        entries[i] = fmt.Sprintf("%v %vw", store.URL(VariantName(name, width)), width)
+    }
+    return strings.Join(entries, ", ")
+}
+
+func SaveImageVariants(store MediaStore, name string, upload UploadedImage, 
+        widths []int) error {
+    if _, _, err := imageDimensions(upload.Data, MaxImageDimension); err != nil {
+        return err
+    }
+    src, _, err := image.Decode(bytes.NewReader(upload.Data))
+    if err != nil {
+        return fmt.Errorf("The image cannot be decoded: %v", err)
+    }
+    if err = store.Save(name, bytes.NewReader(upload.Data)); err != nil {
+        return err
+    }
+    for _, width := range widths {
+        var buffer bytes.Buffer
+        if err = encodeImage(&buffer, resizeToWidth(src, width), 
+                upload.ContentType); err != nil {
+            return err
+        }
+        if err = store.Save(VariantName(name, width), &buffer); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func DeleteImageVariants(store MediaStore, name string, widths []int) {
+    store.Delete(name)
+    for _, width := range widths {
+        store.Delete(VariantName(name, width))
+    }
+}
+
+func encodeImage(writer io.Writer, img image.Image, contentType string) error {
+    switch contentType {
+        case "image/png":
+            return png.Encode(writer, img)
+        case "image/gif":
+            return gif.Encode(writer, img, nil)
+        default:
+            return jpeg.Encode(writer, img, &jpeg.Options{ Quality: 85 })
+    }
+}
+
+func resizeToWidth(src image.Image, width int) image.Image {
+    bounds := src.Bounds()
+    if bounds.Dx() <= width {
+        return src
+    }
+    height := bounds.Dy() * width / bounds.Dx()
+    if height < 1 {
+        height = 1
+    }
+    rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+    draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+    dst := image.NewRGBA(image.Rect(0, 0, width, height))
+    for y := 0; y < height; y++ {
+        y0 := y * bounds.Dy() / height
+        y1 := (y + 1) * bounds.Dy() / height
+        for x := 0; x < width; x++ {
+            x0 := x * bounds.Dx() / width
+            x1 := (x + 1) * bounds.Dx() / width
+            var r, g, b, a, count int
+            for sy := y0; sy < y1; sy++ {
+                offset := rgba.PixOffset(x0, sy)
+                for sx := x0; sx < x1; sx++ {
+                    r += int(rgba.Pix[offset])
+                    g += int(rgba.Pix[offset + 1])
+                    b += int(rgba.Pix[offset + 2])
+                    a += int(rgba.Pix[offset + 3])
+                    offset += 4
+                    count++
+                }
+            }
+            offset := dst.PixOffset(x, y)
+            dst.Pix[offset] = uint8(r / count)
+            dst.Pix[offset + 1] = uint8(g / count)
+            dst.Pix[offset + 2] = uint8(b / count)
+            dst.Pix[offset + 3] = uint8(a / count)
+        }
+    }
+    return dst
+}
@@ -0,0 +1,133 @@
+package media
+
+import (
+    "bytes"
+    "encoding/binary"
+    "hash/crc32"
+    "image"
+    "image/color"
+    "image/png"
+    "mime/multipart"
+    "os"
+    "platform/config"
+    "strings"
+    "testing"
+)
+
+func encodePng(t *testing.T, width, height int) []byte {
+    img := image.NewRGBA(image.Rect(0, 0, width, height))
+    for x := 0; x < width; x++ {
+        img.Set(x, 0, color.RGBA{ 255, 0, 0, 255 })
+    }
+    var buffer bytes.Buffer
+    if err := png.Encode(&buffer, img); err != nil {
+        t.Fatal(err)
+    }
+    return buffer.Bytes()
+}
+
+func declareDimensions(data []byte, width, height uint32) []byte {
+    patched := append([]byte {}, data...)
+    binary.BigEndian.PutUint32(patched[16:], width)
+    binary.BigEndian.PutUint32(patched[20:], height)
+    binary.BigEndian.PutUint32(patched[29:], crc32.ChecksumIEEE(patched[12:29]))
+    return patched
+}
+
+func fileHeader(t *testing.T, data []byte) *multipart.FileHeader {
+    var body bytes.Buffer
+    writer := multipart.NewWriter(&body)
+    part, err := writer.CreateFormFile("image", "upload.png")
+    if err == nil {
+        _, err = part.Write(data)
+    }
+    if err == nil {
+        err = writer.Close()
+    }
+    if err != nil {
+        t.Fatal(err)
+    }
+    form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { form.RemoveAll() })
+    return form.File["image"][0]
+}
+
+func TestReadImageUpload(t *testing.T) {
+    upload, err := ReadImageUpload(fileHeader(t, encodePng(t, 40, 30)), 1 << 20, 100)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if upload.ContentType != "image/png" || upload.Extension != ".png" ||
+            upload.Width != 40 || upload.Height != 30 {
+        t.Fatalf("Unexpected upload: %v %v %vx%v", upload.ContentType, 
+            upload.Extension, upload.Width, upload.Height)
+    }
+}
+
+func TestReadImageUploadRejects(t *testing.T) {
+    small := encodePng(t, 4, 4)
+    for name, test := range map[string]struct { data []byte; maxSize int64; 
+            message string } {
+        "size": { encodePng(t, 40, 30), 64, "larger than" },
+        "type": { []byte("GIF89 is not really an image"), 1024, "Only JPEG" },
+        "dimensions": { encodePng(t, 120, 10), 1 << 20, "pixels" },
+        "declared": { declareDimensions(small, 60000, 60000), 1 << 20, "pixels" },
+        "decode": { small[:20], 1 << 20, "cannot be decoded" },
+    } {
+        _, err := ReadImageUpload(fileHeader(t, test.data), test.maxSize, 100)
+        if err == nil || !strings.Contains(err.Error(), test.message) {
+            t.Fatalf("%v: unexpected error: %v", name, err)
+        }
+    }
+}
+
+func TestSaveImageVariants(t *testing.T) {
+    store := NewLocalMediaStore(t.TempDir(), "/media")
+    data := encodePng(t, 400, 200)
+    upload := UploadedImage{ Data: data, ContentType: "image/png", Extension: ".png" }
+    if err := SaveImageVariants(store, "products/1.png", upload, 
+            []int { 100, 800 }); err != nil {
+        t.Fatal(err)
+    }
+    for name, width := range map[string]int { 
+            "products/1.png": 400, "products/1-100.png": 100, "products/1-800.png": 400 } {
+        file, err := store.Open(name)
+        if err != nil {
+            t.Fatal(err)
+        }
+        cfg, err := png.DecodeConfig(file)
+        file.Close()
+        if err != nil || cfg.Width != width {
+            t.Fatalf("Unexpected variant %v: %v %v", name, cfg.Width, err)
+        }
+    }
+    if url := SrcSet(store, "products/1.png", []int { 100 }); 
+            url != "/media/products/1-100.png 100w" {
+        t.Fatalf("Unexpected srcset: %v", url)
+    }
+    DeleteImageVariants(store, "products/1.png", []int { 100, 800 })
+    if _, err := store.Open("products/1-100.png"); !os.IsNotExist(err) {
+        t.Fatalf("Expected variant to be deleted: %v", err)
+    }
+
+    huge := UploadedImage{ Data: declareDimensions(data, 60000, 60000), 
+        ContentType: "image/png" }
+    if err := SaveImageVariants(store, "products/2.png", huge, 
+            []int { 100 }); err == nil {
+        t.Fatal("Expected huge image to be rejected before decoding")
+    }
+}
+
+func TestImageWidths(t *testing.T) {
+    for sizes, expected := range map[string]int { "100, 200": 2, "": 3, 
+            "none,-1": 3 } {
+        cfg := config.NewMapConfig(map[string]interface{} {
+            "media": map[string]interface{} { "sizes": sizes } })
+        if widths := ImageWidths(cfg); len(widths) != expected {
+            t.Fatalf("Unexpected widths for %q: %v", sizes, widths)
+        }
+    }
+}
@@ -0,0 +1,72 @@
+package media
+
+import (
+    "errors"
+    "io"
+    "os"
+    "path"
+    "path/filepath"
+    "strings"
+)
+
+func NewLocalMediaStore(root, urlPrefix string) *LocalMediaStore {
+    if !strings.HasSuffix(urlPrefix, "/") {
+        urlPrefix += "/"
+    }
+    return &LocalMediaStore{ root: root, urlPrefix: urlPrefix }
+}
+
+type LocalMediaStore struct {
+    root string
+    urlPrefix string
+}
+
+func (store *LocalMediaStore) Save(name string, data io.Reader) error {
+    fullPath, err := store.resolve(name)
+    if err != nil {
+        return err
+    }
+    if err = os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+        return err
+    }
+    file, err := os.Create(fullPath)
+    if err != nil {
+        return err
+    }
+    _, err = io.Copy(file, data)
+    if closeErr := file.Close(); err == nil {
+        err = closeErr
+    }
+    return err
+}
+
+func (store *LocalMediaStore) Open(name string) (io.ReadSeekCloser, error) {
+    fullPath, err := store.resolve(name)
+    if err != nil {
+        return nil, err
+    }
+    return os.Open(fullPath)
+}
+
+func (store *LocalMediaStore) Delete(name string) error {
+    fullPath, err := store.resolve(name)
+    if err == nil {
+        err = os.Remove(fullPath)
+        if os.IsNotExist(err) {
+            err = nil
+        }
+    }
+    return err
+}
+
+func (store *LocalMediaStore) URL(name string) string {
+    return store.urlPrefix + strings.TrimPrefix(path.Clean("/" + name), "/")
+}
+
+func (store *LocalMediaStore) resolve(name string) (string, error) {
+    cleaned := path.Clean("/" + name)
+    if cleaned == "/" {
+        return "", errors.New("Media name cannot be empty")
+    }
+    return filepath.Join(store.root, filepath.FromSlash(cleaned)), nil
+}
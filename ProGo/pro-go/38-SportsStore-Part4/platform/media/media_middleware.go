@@ -0,0 +1,41 @@
+package media
+
+import (
+    "net/http"
+    "path"
+    "platform/config"
+    "platform/pipeline"
+    "strings"
+    "time"
+)
+
+type MediaComponent struct {
+    urlPrefix string
+    Config config.Configuration
+    Store MediaStore
+}
+
+func (mc *MediaComponent) Init() {
+    mc.urlPrefix = mc.Config.GetStringDefault("media:urlprefix", "/media/")
+    if !strings.HasSuffix(mc.urlPrefix, "/") {
+        mc.urlPrefix += "/"
+    }
+}
+
+func (mc *MediaComponent) ProcessRequest(ctx *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext)) {
+    if ctx.Request.Method == http.MethodGet && 
+            strings.HasPrefix(ctx.Request.URL.Path, mc.urlPrefix) {
+        name := strings.TrimPrefix(ctx.Request.URL.Path, mc.urlPrefix)
+        file, err := mc.Store.Open(name)
+        if err != nil {
+            ctx.ResponseWriter.WriteHeader(http.StatusNotFound)
+            return
+        }
+        defer file.Close()
+        http.ServeContent(ctx.ResponseWriter, ctx.Request, path.Base(name), 
+            time.Time{}, file)
+    } else {
+        next(ctx)
+    }
+}
@@ -0,0 +1,60 @@
+package media
+
+import (
+    "io"
+    "platform/config"
+    "platform/services"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+type MediaStore interface {
+
+    Save(name string, data io.Reader) error
+    Open(name string) (io.ReadSeekCloser, error)
+    Delete(name string) error
+    URL(name string) string
+}
+
+func RegisterLocalMediaStore() {
+    stores := map[config.Configuration]MediaStore {}
+    lock := sync.Mutex {}
+    err := services.AddScoped(func(c config.Configuration) MediaStore {
+        lock.Lock()
+        defer lock.Unlock()
+        store, found := stores[c]
+        if !found {
+            path, found := c.GetString("media:path")
+            if !found {
+                panic("Cannot load media configuration settings")
+            }
+            store = NewLocalMediaStore(path, 
+                c.GetStringDefault("media:urlprefix", "/media/"))
+            stores[c] = store
+        }
+        return store
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
+
+const defaultImageSizes = "160,320,640"
+
+func ImageWidths(c config.Configuration) []int {
+    if widths := parseWidths(c.GetStringDefault("media:sizes", 
+            defaultImageSizes)); len(widths) > 0 {
+        return widths
+    }
+    return parseWidths(defaultImageSizes)
+}
+
+func parseWidths(sizes string) (widths []int) {
+    for _, val := range strings.Split(sizes, ",") {
+        if width, err := strconv.Atoi(strings.TrimSpace(val)); err == nil && width > 0 {
+            widths = append(widths, width)
+        }
+    }
+    return
+}
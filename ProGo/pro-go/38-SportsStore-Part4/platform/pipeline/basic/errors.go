@@ -1,6 +1,7 @@
 package basic
 
 import (
+    "errors"
     "fmt"
     "net/http"
     "platform/logging"
@@ -28,6 +29,11 @@ func (c *ErrorComponent) ProcessRequest(ctx *pipeline.ComponentContext,
     next(ctx)
     if (ctx.GetError() != nil) {
         logger.Debugf("Error: %v", ctx.GetError())
-        ctx.ResponseWriter.WriteHeader(http.StatusInternalServerError)
+        status := http.StatusInternalServerError
+        var statusErr interface { StatusCode() int }
+        if errors.As(ctx.GetError(), &statusErr) {
+            status = statusErr.StatusCode()
+        }
+        ctx.ResponseWriter.WriteHeader(status)
     }
 }
@@ -0,0 +1,42 @@
+package basic
+
+import (
+    "encoding/json"
+    "errors"
+    "net/http"
+    "platform/logging"
+    "platform/pipeline"
+    "platform/services"
+)
+
+type JsonErrorComponent struct {}
+
+type jsonError struct {
+    Status int `json:"status"`
+    Error string `json:"error"`
+}
+
+func (c *JsonErrorComponent) Init() {}
+
+func (c *JsonErrorComponent) ProcessRequest(ctx *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext)) {
+    next(ctx)
+    if err := ctx.GetError(); err != nil {
+        var logger logging.Logger
+        services.GetServiceForContext(ctx.Context(), &logger)
+        logger.Debugf("Error: %v", err)
+        status := http.StatusInternalServerError
+        var statusErr interface { StatusCode() int }
+        if errors.As(err, &statusErr) {
+            status = statusErr.StatusCode()
+        }
+        message := http.StatusText(status)
+        if status < http.StatusInternalServerError {
+            message = err.Error()
+        }
+        ctx.ResponseWriter.Header().Set("Content-Type", "application/json")
+        ctx.ResponseWriter.WriteHeader(status)
+        json.NewEncoder(ctx.ResponseWriter).Encode(jsonError{ status, message })
+        ctx.Error(nil)
+    }
+}
@@ -24,6 +24,15 @@ func (w *LoggingResponseWriter) Write(b []byte) (int, error) {
     return w.ResponseWriter.Write(b)
 }
 
+func (w *LoggingResponseWriter) Stream(statusCode int) http.ResponseWriter {
+    w.statusCode = statusCode
+    if streamer, ok := w.ResponseWriter.(pipeline.StreamingResponseWriter); ok {
+        return streamer.Stream(statusCode)
+    }
+    w.ResponseWriter.WriteHeader(statusCode)
+    return w.ResponseWriter
+}
+
 type LoggingComponent struct {}
 
 func (lc *LoggingComponent) ImplementsProcessRequestWithServices() {}
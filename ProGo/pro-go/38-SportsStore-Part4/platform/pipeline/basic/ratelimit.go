@@ -0,0 +1,89 @@
+package basic
+
+import (
+    "fmt"
+    "math"
+    "net"
+    "net/http"
+    "platform/config"
+    "platform/pipeline"
+    "sync"
+    "time"
+)
+
+const rateLimitSweepSize = 10000
+
+func NewRateLimitComponent(configKey string) *RateLimitComponent {
+    return &RateLimitComponent{ configKey: configKey }
+}
+
+type RateLimitComponent struct {
+    configKey string
+    config.Configuration
+    limit int
+    window time.Duration
+    clients map[string]*rateWindow
+    mutex sync.Mutex
+}
+
+type rateWindow struct {
+    start time.Time
+    count int
+}
+
+func (c *RateLimitComponent) Init() {
+    c.limit = c.Configuration.GetIntDefault(c.configKey + ":requests", 0)
+    var err error
+    if c.window, err = time.ParseDuration(c.Configuration.GetStringDefault(
+            c.configKey + ":window", "1m")); err != nil {
+        panic(err)
+    }
+    c.clients = map[string]*rateWindow {}
+}
+
+func (c *RateLimitComponent) ProcessRequest(ctx *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext)) {
+    if c.limit <= 0 {
+        next(ctx)
+        return
+    }
+    remaining, reset := c.take(clientAddress(ctx.Request), time.Now())
+    header := ctx.ResponseWriter.Header()
+    header.Set("X-RateLimit-Limit", fmt.Sprint(c.limit))
+    if remaining < 0 {
+        header.Set("X-RateLimit-Remaining", "0")
+        header.Set("Retry-After", 
+            fmt.Sprint(int(math.Ceil(reset.Seconds()))))
+        ctx.ResponseWriter.WriteHeader(http.StatusTooManyRequests)
+        return
+    }
+    header.Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+    next(ctx)
+}
+
+func (c *RateLimitComponent) take(client string, now time.Time) (remaining int, 
+        reset time.Duration) {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+    if len(c.clients) >= rateLimitSweepSize {
+        for key, w := range c.clients {
+            if now.Sub(w.start) >= c.window {
+                delete(c.clients, key)
+            }
+        }
+    }
+    w, found := c.clients[client]
+    if !found || now.Sub(w.start) >= c.window {
+        w = &rateWindow{ start: now }
+        c.clients[client] = w
+    }
+    w.count++
+    return c.limit - w.count, w.start.Add(c.window).Sub(now)
+}
+
+func clientAddress(req *http.Request) string {
+    if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+        return host
+    }
+    return req.RemoteAddr
+}
@@ -11,7 +11,8 @@ func (c *ServicesComponent) Init() {}
 
 func (c *ServicesComponent)  ProcessRequest(ctx *pipeline.ComponentContext, 
         next func(*pipeline.ComponentContext))  {
-    reqContext := ctx.Request.Context()
-    ctx.Request.WithContext(services.NewServiceContext(reqContext))
+    reqContext := services.NewServiceContext(ctx.Request.Context())
+    ctx.Request = ctx.Request.WithContext(pipeline.WithRequest(reqContext, 
+        ctx.Request))
     next(ctx)
 }
@@ -9,13 +9,20 @@ type DeferredResponseWriter struct {
     http.ResponseWriter
     strings.Builder
     statusCode int
+    streaming bool
 }
 
 func (dw *DeferredResponseWriter) Write(data []byte) (int, error) {
+    if (dw.streaming) {
+        return dw.ResponseWriter.Write(data)
+    }
     return dw.Builder.Write(data)
 }
 
 func (dw *DeferredResponseWriter) FlushData()  {
+    if (dw.streaming) {
+        return
+    }
     if (dw.statusCode == 0) {
         dw.statusCode = http.StatusOK
     }
@@ -23,6 +30,29 @@ func (dw *DeferredResponseWriter) FlushData()  {
     dw.ResponseWriter.Write([]byte(dw.Builder.String()))
 }
 
+func (dw *DeferredResponseWriter) FlushStatus()  {
+    if (dw.streaming) {
+        return
+    }
+    if (dw.statusCode == 0 || dw.statusCode == http.StatusOK) {
+        dw.statusCode = http.StatusInternalServerError
+    }
+    dw.ResponseWriter.WriteHeader(dw.statusCode)
+}
+
 func (dw *DeferredResponseWriter) WriteHeader(statusCode int) {
     dw.statusCode = statusCode
 }
+
+func (dw *DeferredResponseWriter) Stream(statusCode int) http.ResponseWriter {
+    dw.statusCode, dw.streaming = statusCode, true
+    if streamer, ok := dw.ResponseWriter.(StreamingResponseWriter); ok {
+        return streamer.Stream(statusCode)
+    }
+    dw.ResponseWriter.WriteHeader(statusCode)
+    return dw.ResponseWriter
+}
+
+type StreamingResponseWriter interface {
+    Stream(statusCode int) http.ResponseWriter
+}
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestDeferredResponseWriter(t *testing.T) {
+    recorder := httptest.NewRecorder()
+    writer := &DeferredResponseWriter{ ResponseWriter: recorder }
+    writer.WriteHeader(http.StatusCreated)
+    writer.Write([]byte("buffered"))
+    if recorder.Body.Len() != 0 || recorder.Code != http.StatusOK || 
+            recorder.Flushed {
+        t.Fatal("Expected output to be deferred")
+    }
+    writer.FlushData()
+    if recorder.Code != http.StatusCreated || recorder.Body.String() != "buffered" {
+        t.Fatalf("Unexpected response: %v %v", recorder.Code, recorder.Body.String())
+    }
+
+    recorder = httptest.NewRecorder()
+    writer = &DeferredResponseWriter{ ResponseWriter: recorder }
+    writer.Write([]byte("discarded"))
+    writer.FlushStatus()
+    if recorder.Code != http.StatusInternalServerError || recorder.Body.Len() != 0 {
+        t.Fatalf("Expected error status only, got %v %v", recorder.Code, 
+            recorder.Body.String())
+    }
+}
+
+func TestDeferredResponseWriterStreaming(t *testing.T) {
+    recorder := httptest.NewRecorder()
+    outer := &DeferredResponseWriter{ ResponseWriter: recorder }
+    inner := &DeferredResponseWriter{ ResponseWriter: outer }
+    stream := inner.Stream(http.StatusAccepted)
+    if stream != recorder {
+        t.Fatal("Expected streaming to reach the underlying writer")
+    }
+    stream.Write([]byte("first "))
+    if recorder.Code != http.StatusAccepted || recorder.Body.String() != "first " {
+        t.Fatalf("Expected data to be written immediately, got %v %q", 
+            recorder.Code, recorder.Body.String())
+    }
+    inner.Write([]byte("second"))
+    inner.FlushData()
+    inner.FlushStatus()
+    outer.FlushData()
+    if recorder.Body.String() != "first second" {
+        t.Fatalf("Expected flushes to be ignored while streaming, got %q", 
+            recorder.Body.String())
+    }
+}
@@ -61,6 +61,8 @@ func (pl RequestPipeline) ProcessRequest(req *http.Request,
     pl(&ctx)
     if (ctx.error == nil) {
         deferredWriter.FlushData()
+    } else {
+        deferredWriter.FlushStatus()
     }
     return ctx.error    
 }
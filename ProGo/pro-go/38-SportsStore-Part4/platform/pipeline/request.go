@@ -0,0 +1,31 @@
+package pipeline
+
+import (
+    "context"
+    "net/http"
+)
+
+const REQUEST_CONTEXT_KEY = "request"
+const SHUTDOWN_CONTEXT_KEY = "shutdown"
+
+func WithRequest(ctx context.Context, req *http.Request) context.Context {
+    return context.WithValue(ctx, REQUEST_CONTEXT_KEY, req)
+}
+
+func RequestFromContext(ctx context.Context) (req *http.Request, found bool) {
+    if ctx != nil {
+        req, found = ctx.Value(REQUEST_CONTEXT_KEY).(*http.Request)
+    }
+    return
+}
+
+func WithShutdown(ctx context.Context, shutdown <-chan struct{}) context.Context {
+    return context.WithValue(ctx, SHUTDOWN_CONTEXT_KEY, shutdown)
+}
+
+func ShutdownFromContext(ctx context.Context) (shutdown <-chan struct{}) {
+    if ctx != nil {
+        shutdown, _ = ctx.Value(SHUTDOWN_CONTEXT_KEY).(<-chan struct{})
+    }
+    return
+}
@@ -0,0 +1,32 @@
+package platformtest
+
+import (
+    "context"
+    "platform/http/actionresults"
+    "sync"
+)
+
+type ObservedAction struct {
+    Handler string
+    Method string
+    Result actionresults.ActionResult
+}
+
+type actionLog struct {
+    mutex sync.Mutex
+    actions []ObservedAction
+}
+
+func (l *actionLog) ObserveAction(ctx context.Context, handlerName, 
+        methodName string, action actionresults.ActionResult) {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    l.actions = append(l.actions, ObservedAction{ Handler: handlerName, 
+        Method: methodName, Result: action })
+}
+
+func (l *actionLog) Actions() []ObservedAction {
+    l.mutex.Lock()
+    defer l.mutex.Unlock()
+    return append([]ObservedAction(nil), l.actions...)
+}
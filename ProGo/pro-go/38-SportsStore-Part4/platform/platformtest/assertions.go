@@ -0,0 +1,71 @@
+package platformtest
+
+import (
+    "errors"
+    "platform/http/actionresults"
+    "testing"
+)
+
+func AssertStatus(t testing.TB, response *Response, status int) {
+    t.Helper()
+    if response.Code != status {
+        t.Fatalf("Expected status %v, got %v", status, response.Code)
+    }
+}
+
+func AssertRedirect(t testing.TB, response *Response, location string) {
+    t.Helper()
+    if response.Code < 300 || response.Code > 399 {
+        t.Fatalf("Expected redirect to %v, got status %v", location, response.Code)
+    }
+    if actual := response.Header().Get("Location"); actual != location {
+        t.Fatalf("Expected redirect to %v, got %v", location, actual)
+    }
+}
+
+func AssertTemplate(t testing.TB, result actionresults.ActionResult, 
+        name string) interface{} {
+    t.Helper()
+    template, ok := result.(*actionresults.TemplateActionResult)
+    if !ok {
+        t.Fatalf("Expected template %v, got %T", name, result)
+    }
+    if template.TemplateName() != name {
+        t.Fatalf("Expected template %v, got %v", name, template.TemplateName())
+    }
+    return template.Data()
+}
+
+func AssertRedirectAction(t testing.TB, result actionresults.ActionResult, 
+        url string) {
+    t.Helper()
+    redirect, ok := result.(*actionresults.RedirectActionResult)
+    if !ok {
+        t.Fatalf("Expected redirect to %v, got %T", url, result)
+    }
+    if redirect.URL() != url {
+        t.Fatalf("Expected redirect to %v, got %v", url, redirect.URL())
+    }
+}
+
+func AssertJson(t testing.TB, result actionresults.ActionResult) interface{} {
+    t.Helper()
+    json, ok := result.(*actionresults.JsonActionResult)
+    if !ok {
+        t.Fatalf("Expected JSON result, got %T", result)
+    }
+    return json.Data()
+}
+
+func AssertError(t testing.TB, result actionresults.ActionResult, 
+        target error) error {
+    t.Helper()
+    errResult, ok := result.(*actionresults.ErrorActionResult)
+    if !ok {
+        t.Fatalf("Expected error result, got %T", result)
+    }
+    if target != nil && !errors.Is(errResult.Err(), target) {
+        t.Fatalf("Expected error %v, got %v", target, errResult.Err())
+    }
+    return errResult.Err()
+}
@@ -0,0 +1,117 @@
+package platformtest
+
+import (
+    "context"
+    "io"
+    "net/http"
+    "net/http/cookiejar"
+    "net/http/httptest"
+    "net/url"
+    "platform/authorization/identity"
+    "platform/http/handling"
+    "platform/services"
+    "strings"
+)
+
+const testUserKey = "platformtest_user"
+
+type Client struct {
+    h *Harness
+    Jar http.CookieJar
+    Host string
+    user identity.User
+}
+
+type Response struct {
+    *httptest.ResponseRecorder
+    Actions []ObservedAction
+    Err error
+}
+
+func (h *Harness) NewClient() *Client {
+    h.T.Helper()
+    jar, err := cookiejar.New(nil)
+    h.check(err)
+    return &Client{ h: h, Jar: jar, Host: "localhost" }
+}
+
+func (c *Client) AuthenticateAs(user identity.User) *Client {
+    c.h.userOverride.Do(func() {
+        c.h.Decorate(func(current identity.User, 
+                ctx context.Context) identity.User {
+            if user, ok := ctx.Value(testUserKey).(identity.User); ok {
+                return user
+            }
+            return current
+        })
+    })
+    c.user = user
+    return c
+}
+
+func (c *Client) Do(req *http.Request) *Response {
+    c.h.T.Helper()
+    if c.h.Pipeline == nil {
+        c.h.T.Fatal("No pipeline has been built for the test harness")
+    }
+    if req.Host == "" || req.Host == "example.com" {
+        req.Host = c.Host
+    }
+    for _, cookie := range c.Jar.Cookies(c.cookieURL(req)) {
+        req.AddCookie(cookie)
+    }
+    ctx := services.NewServiceContext(req.Context())
+    if c.user != nil {
+        ctx = context.WithValue(ctx, testUserKey, c.user)
+    }
+    req = req.WithContext(ctx)
+    recorder := httptest.NewRecorder()
+    err := c.h.Pipeline.ProcessRequest(req, recorder)
+    c.Jar.SetCookies(c.cookieURL(req), recorder.Result().Cookies())
+    response := &Response{ ResponseRecorder: recorder, Err: err }
+    var observer handling.ActionObserver
+    if services.GetServiceForContext(ctx, &observer) == nil {
+        if log, ok := observer.(*actionLog); ok {
+            response.Actions = log.Actions()
+        }
+    }
+    return response
+}
+
+func (c *Client) Get(target string) *Response {
+    c.h.T.Helper()
+    return c.Do(httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+func (c *Client) Post(target, contentType string, body io.Reader) *Response {
+    c.h.T.Helper()
+    req := httptest.NewRequest(http.MethodPost, target, body)
+    req.Header.Set("Content-Type", contentType)
+    return c.Do(req)
+}
+
+func (c *Client) PostForm(target string, values url.Values) *Response {
+    c.h.T.Helper()
+    return c.Post(target, "application/x-www-form-urlencoded", 
+        strings.NewReader(values.Encode()))
+}
+
+func (c *Client) Follow(response *Response) *Response {
+    c.h.T.Helper()
+    location := response.Header().Get("Location")
+    if location == "" {
+        c.h.T.Fatalf("Response has no Location header (status %v)", response.Code)
+    }
+    return c.Get(location)
+}
+
+func (c *Client) cookieURL(req *http.Request) *url.URL {
+    return &url.URL{ Scheme: "http", Host: req.Host, Path: req.URL.Path }
+}
+
+func (r *Response) Action() (action ObservedAction, found bool) {
+    if len(r.Actions) > 0 {
+        return r.Actions[len(r.Actions) - 1], true
+    }
+    return
+}
@@ -0,0 +1,168 @@
+package platformtest
+
+import (
+    "context"
+    "platform/config"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/pipeline"
+    "platform/services"
+    "platform/sessions"
+    "reflect"
+    "strings"
+    "sync"
+    "testing"
+    gorilla "github.com/gorilla/sessions"
+)
+
+var harnessLock = sync.Mutex{}
+
+var owner = struct {
+    sync.Mutex
+    t testing.TB
+}{}
+
+type Harness struct {
+    T testing.TB
+    Config config.Configuration
+    Pipeline pipeline.RequestPipeline
+    previous *services.Registry
+    userOverride sync.Once
+}
+
+func New(t testing.TB, cfg config.Configuration) *Harness {
+    t.Helper()
+    if active := activeHarness(); active != nil && (active.Name() == t.Name() || 
+            strings.HasPrefix(t.Name(), active.Name() + "/")) {
+        t.Fatalf("A test harness is already active for %v; use a single harness " +
+            "or create each one in its own subtest", active.Name())
+    }
+    harnessLock.Lock()
+    setActiveHarness(t)
+    h := &Harness{ T: t, Config: cfg }
+    h.previous = services.UseRegistry(services.NewRegistry())
+    t.Cleanup(func() {
+        services.UseRegistry(h.previous)
+        setActiveHarness(nil)
+        harnessLock.Unlock()
+    })
+    h.Singleton(func() config.Configuration { return cfg })
+    h.Scoped(func() handling.ActionObserver { return &actionLog{} })
+    return h
+}
+
+func activeHarness() testing.TB {
+    owner.Lock()
+    defer owner.Unlock()
+    return owner.t
+}
+
+func setActiveHarness(t testing.TB) {
+    owner.Lock()
+    defer owner.Unlock()
+    owner.t = t
+}
+
+func (h *Harness) Singleton(factory interface{}) *Harness {
+    h.T.Helper()
+    h.check(services.AddSingleton(factory))
+    return h
+}
+
+func (h *Harness) Scoped(factory interface{}) *Harness {
+    h.T.Helper()
+    h.check(services.AddScoped(factory))
+    return h
+}
+
+func (h *Harness) Transient(factory interface{}) *Harness {
+    h.T.Helper()
+    h.check(services.AddTransient(factory))
+    return h
+}
+
+func (h *Harness) Decorate(decorator interface{}) *Harness {
+    h.T.Helper()
+    h.check(services.Decorate(decorator))
+    return h
+}
+
+func (h *Harness) Build(components ...interface{}) *Harness {
+    h.Pipeline = pipeline.CreatePipeline(components...)
+    return h
+}
+
+func (h *Harness) Context() context.Context {
+    ctx := services.NewServiceContext(context.Background())
+    return context.WithValue(ctx, sessions.SESSION__CONTEXT_KEY, 
+        gorilla.NewSession(nil, sessions.SESSION__CONTEXT_KEY))
+}
+
+func (h *Harness) GetService(target interface{}) {
+    h.T.Helper()
+    h.check(services.GetServiceForContext(h.Context(), target))
+}
+
+func (h *Harness) Invoke(method interface{}, 
+        args ...interface{}) actionresults.ActionResult {
+    h.T.Helper()
+    return h.InvokeForContext(h.Context(), method, args...)
+}
+
+func (h *Harness) InvokeForContext(ctx context.Context, method interface{}, 
+        args ...interface{}) actionresults.ActionResult {
+    h.T.Helper()
+    methodVal := reflect.ValueOf(method)
+    if methodVal.Kind() != reflect.Func || methodVal.Type().NumIn() == 0 || 
+            methodVal.Type().In(0).Kind() != reflect.Struct {
+        h.T.Fatalf("Cannot invoke %v as a handler method", methodVal.Type())
+    }
+    handler := reflect.New(methodVal.Type().In(0))
+    h.check(services.PopulateForContext(ctx, handler.Interface()))
+    params := []reflect.Value { handler.Elem() }
+    for _, arg := range args {
+        params = append(params, reflect.ValueOf(arg))
+    }
+    results := methodVal.Call(params)
+    if len(results) == 0 {
+        return nil
+    }
+    action, _ := results[0].Interface().(actionresults.ActionResult)
+    return action
+}
+
+func (h *Harness) check(err error) {
+    h.T.Helper()
+    if err != nil {
+        h.T.Fatal(err)
+    }
+}
+
+func ConfigValues(values map[string]interface{}) config.Configuration {
+    data := map[string]interface{} {}
+    for name, val := range values {
+        section := data
+        keys := strings.Split(name, ":")
+        for _, key := range keys[:len(keys) - 1] {
+            child, ok := section[key].(map[string]interface{})
+            if !ok {
+                child = map[string]interface{} {}
+                section[key] = child
+            }
+            section = child
+        }
+        section[keys[len(keys) - 1]] = normalize(val)
+    }
+    return config.NewMapConfig(data)
+}
+
+func normalize(val interface{}) interface{} {
+    switch typed := val.(type) {
+        case int:
+            return float64(typed)
+        case int64:
+            return float64(typed)
+        default:
+            return val
+    }
+}
@@ -0,0 +1,132 @@
+package platformtest
+
+import (
+    "fmt"
+    "net/http"
+    "platform/config"
+    "platform/pipeline"
+    "platform/services"
+    "platform/sessions"
+    "strings"
+    "testing"
+    gorilla "github.com/gorilla/sessions"
+)
+
+type counter struct {
+    count int
+}
+
+type visit struct {
+    number int
+}
+
+type echoComponent struct {
+    Config config.Configuration
+}
+
+func (c *echoComponent) Init() {}
+
+func (c *echoComponent) ProcessRequest(ctx *pipeline.ComponentContext,
+        next func(*pipeline.ComponentContext)) {
+    greeting := c.Config.GetStringDefault("test:greeting", "hello")
+    http.SetCookie(ctx.ResponseWriter, &http.Cookie{ Name: "seen", Value: "yes" })
+    if _, err := ctx.Request.Cookie("seen"); err == nil {
+        greeting += " again"
+    }
+    ctx.ResponseWriter.Write([]byte(greeting))
+}
+
+type fatalTB struct {
+    testing.TB
+    name string
+    message string
+}
+
+func (t *fatalTB) Helper() {}
+
+func (t *fatalTB) Name() string {
+    return t.name
+}
+
+func (t *fatalTB) Fatalf(format string, args ...interface{}) {
+    t.message = fmt.Sprintf(format, args...)
+    panic(t)
+}
+
+func newFails(t testing.TB) (message string) {
+    defer func() {
+        if failed, ok := recover().(*fatalTB); ok {
+            message = failed.message
+        }
+    }()
+    New(t, config.NewMapConfig(map[string]interface{} {}))
+    return
+}
+
+func TestConfigValues(t *testing.T) {
+    cfg := ConfigValues(map[string]interface{} {
+        "server:http:port": 5000,
+        "server:http:enabled": true,
+        "server:name": "test",
+    })
+    if port, found := cfg.GetInt("server:http:port"); !found || port != 5000 {
+        t.Fatalf("Expected nested int value, got %v", port)
+    }
+    if enabled, _ := cfg.GetBool("server:http:enabled"); !enabled ||
+            cfg.GetStringDefault("server:name", "") != "test" {
+        t.Fatal("Expected bool and string values")
+    }
+}
+
+func TestHarnessServices(t *testing.T) {
+    previous := services.UseRegistry(services.NewRegistry())
+    defer services.UseRegistry(previous)
+    t.Run("harness", func(t *testing.T) {
+        h := New(t, ConfigValues(map[string]interface{} { "test:greeting": "hi" }))
+        h.Singleton(func() *counter { return &counter{} }).
+            Scoped(func(c *counter) *visit {
+                c.count++
+                return &visit{ number: c.count }
+            })
+        var cfg config.Configuration
+        var first, second *visit
+        h.GetService(&cfg)
+        h.GetService(&first)
+        h.GetService(&second)
+        if cfg.GetStringDefault("test:greeting", "") != "hi" || 
+                first.number != 1 || second.number != 2 {
+            t.Fatal("Expected configuration, shared singleton and scoped services")
+        }
+        if _, ok := h.Context().Value(sessions.SESSION__CONTEXT_KEY).(*gorilla.Session);
+                !ok {
+            t.Fatal("Expected session in harness context")
+        }
+    })
+    var cfg config.Configuration
+    if services.GetService(&cfg) == nil {
+        t.Fatal("Expected previous registry to be restored")
+    }
+}
+
+func TestHarnessClient(t *testing.T) {
+    h := New(t, ConfigValues(map[string]interface{} { "test:greeting": "hi" }))
+    client := h.Build(&echoComponent{}).NewClient()
+    first := client.Get("/")
+    AssertStatus(t, first, http.StatusOK)
+    if first.Body.String() != "hi" || client.Get("/").Body.String() != "hi again" {
+        t.Fatal("Expected configured component and cookies to be kept")
+    }
+    if h.NewClient().Get("/").Body.String() != "hi" {
+        t.Fatal("Expected each client to have its own cookies")
+    }
+}
+
+func TestNestedHarnessFails(t *testing.T) {
+    New(t, config.NewMapConfig(map[string]interface{} {}))
+    for _, name := range []string { t.Name(), t.Name() + "/subtest" } {
+        if message := newFails(&fatalTB{ name: name });
+                !strings.Contains(message, "already active for " + t.Name()) {
+            t.Fatalf("Expected nested harness for %v to fail, got %q", name, message)
+        }
+    }
+}
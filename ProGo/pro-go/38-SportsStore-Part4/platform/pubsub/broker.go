@@ -0,0 +1,27 @@
+package pubsub
+
+import (
+    "time"
+)
+
+type Message struct {
+    Topic string
+    Payload interface{}
+    Published time.Time
+}
+
+type Broker interface {
+
+    Publish(topic string, payload interface{})
+
+    Subscribe(topics ...string) *Subscription
+}
+
+type Subscription struct {
+    Messages <-chan Message
+    close func()
+}
+
+func (s *Subscription) Close() {
+    s.close()
+}
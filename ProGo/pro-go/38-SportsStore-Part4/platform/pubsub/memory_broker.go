@@ -0,0 +1,77 @@
+package pubsub
+
+import (
+    "platform/logging"
+    "sync"
+    "time"
+)
+
+type subscriber struct {
+    messages chan Message
+    topics []string
+}
+
+type MemoryBroker struct {
+    logger logging.Logger
+    buffer int
+    mutex sync.Mutex
+    subscribers map[string]map[*subscriber]bool
+}
+
+func NewMemoryBroker(buffer int, logger logging.Logger) *MemoryBroker {
+    if buffer < 1 {
+        buffer = 1
+    }
+    return &MemoryBroker{ logger: logger, buffer: buffer,
+        subscribers: map[string]map[*subscriber]bool {} }
+}
+
+func (b *MemoryBroker) Publish(topic string, payload interface{}) {
+    msg := Message{ Topic: topic, Payload: payload, Published: time.Now() }
+    b.mutex.Lock()
+    defer b.mutex.Unlock()
+    for sub := range b.subscribers[topic] {
+        select {
+            case sub.messages <- msg:
+            default:
+                if b.logger != nil {
+                    b.logger.Warnf("Dropped message for slow subscriber on topic %v",
+                        topic)
+                }
+        }
+    }
+}
+
+func (b *MemoryBroker) Subscribe(topics ...string) *Subscription {
+    sub := &subscriber{ messages: make(chan Message, b.buffer), topics: topics }
+    b.mutex.Lock()
+    defer b.mutex.Unlock()
+    for _, topic := range topics {
+        if b.subscribers[topic] == nil {
+            b.subscribers[topic] = map[*subscriber]bool {}
+        }
+        b.subscribers[topic][sub] = true
+    }
+    once := sync.Once{}
+    return &Subscription{ Messages: sub.messages, close: func() {
+        once.Do(func() { b.unsubscribe(sub) })
+    }}
+}
+
+func (b *MemoryBroker) unsubscribe(sub *subscriber) {
+    b.mutex.Lock()
+    defer b.mutex.Unlock()
+    for _, topic := range sub.topics {
+        delete(b.subscribers[topic], sub)
+        if len(b.subscribers[topic]) == 0 {
+            delete(b.subscribers, topic)
+        }
+    }
+    close(sub.messages)
+}
+
+func (b *MemoryBroker) SubscriberCount(topic string) int {
+    b.mutex.Lock()
+    defer b.mutex.Unlock()
+    return len(b.subscribers[topic])
+}
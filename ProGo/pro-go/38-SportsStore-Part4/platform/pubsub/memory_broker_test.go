@@ -0,0 +1,71 @@
+package pubsub
+
+import (
+    "testing"
+    "time"
+)
+
+func receive(t *testing.T, sub *Subscription) Message {
+    t.Helper()
+    select {
+        case msg := <- sub.Messages:
+            return msg
+        case <- time.After(time.Second):
+            t.Fatal("Expected a message")
+    }
+    return Message{}
+}
+
+func TestMemoryBroker(t *testing.T) {
+    broker := NewMemoryBroker(2, nil)
+    orders, all := broker.Subscribe("orders"), broker.Subscribe("orders", "reviews")
+    broker.Publish("orders", 1)
+    broker.Publish("reviews", 2)
+    broker.Publish("other", 3)
+    if msg := receive(t, orders); msg.Topic != "orders" || msg.Payload != 1 || 
+            msg.Published.IsZero() {
+        t.Fatalf("Unexpected message: %+v", msg)
+    }
+    if receive(t, all).Payload != 1 || receive(t, all).Payload != 2 {
+        t.Fatal("Expected messages for both topics")
+    }
+    select {
+        case msg := <- orders.Messages:
+            t.Fatalf("Unexpected message: %+v", msg)
+        default:
+    }
+    if count := broker.SubscriberCount("orders"); count != 2 {
+        t.Fatalf("Expected two subscribers, got %v", count)
+    }
+    orders.Close()
+    orders.Close()
+    if _, open := <- orders.Messages; open {
+        t.Fatal("Expected closed subscription channel")
+    }
+    all.Close()
+    if broker.SubscriberCount("orders") != 0 || broker.SubscriberCount("reviews") != 0 {
+        t.Fatal("Expected subscribers to be removed")
+    }
+    broker.Publish("orders", 4)
+}
+
+func TestMemoryBrokerSlowSubscriber(t *testing.T) {
+    broker := NewMemoryBroker(1, nil)
+    sub := broker.Subscribe("orders")
+    defer sub.Close()
+    done := make(chan struct{})
+    go func() {
+        for i := 0; i < 3; i++ {
+            broker.Publish("orders", i)
+        }
+        close(done)
+    }()
+    select {
+        case <- done:
+        case <- time.After(time.Second):
+            t.Fatal("Expected publishing not to block on a slow subscriber")
+    }
+    if receive(t, sub).Payload != 0 {
+        t.Fatal("Expected the buffered message to be kept")
+    }
+}
@@ -0,0 +1,17 @@
+package pubsub
+
+import (
+    "platform/config"
+    "platform/logging"
+    "platform/services"
+)
+
+func RegisterPubSubService() {
+    err := services.AddSingleton(func(logger logging.Logger,
+            cfg config.Configuration) Broker {
+        return NewMemoryBroker(cfg.GetIntDefault("pubsub:buffer", 16), logger)
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
@@ -0,0 +1,317 @@
+package scaffold
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "html/template"
+    "net/http"
+    "net/url"
+    "platform/authorization"
+    "platform/config"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/http/handling/params"
+    "platform/pipeline"
+    "platform/services"
+    "platform/templates"
+    "platform/validation"
+    "reflect"
+    "strconv"
+    "strings"
+)
+
+const ListTemplate = "scaffold_list.html"
+const FormTemplate = "scaffold_form.html"
+
+type PageRenderer interface {
+    RenderPage(model *Model, content template.HTML) actionresults.ActionResult
+}
+
+type ScaffoldHandler struct {
+    *Registry
+    handling.URLGenerator
+    templates.TemplateExecutor
+    validation.Validator
+    config.Configuration
+    Context context.Context
+}
+
+type ListTemplateContext struct {
+    Model *Model
+    Fields []Field
+    Request ListRequest
+    ListPage
+    ListUrl string
+    NewUrl string
+    DeleteUrl string
+    EditUrlFunc func(int) string
+    ListUrlFunc func(sort string, desc bool, page int) string
+}
+
+type FormField struct {
+    Field
+    Value string
+    Checked bool
+    Error string
+}
+
+type FormTemplateContext struct {
+    Model *Model
+    ID int
+    Fields []FormField
+    SaveUrl string
+    ListUrl string
+}
+
+type ModelReference struct {
+    Model string `form:"_model"`
+    ID int `form:"_id"`
+}
+
+func (handler ScaffoldHandler) GetList(name string, 
+        req ListRequest) actionresults.ActionResult {
+    model, denied := handler.resolveModel(name)
+    if denied != nil {
+        return denied
+    }
+    store, err := model.store(handler.Context)
+    if err != nil {
+        return model.errorAction(err)
+    }
+    items, err := store.GetAll(handler.Context)
+    if err != nil {
+        return model.errorAction(err)
+    }
+    listUrl := handler.mustGenerateUrl(ScaffoldHandler.GetList, model.Name)
+    return handler.render(model, ListTemplate, ListTemplateContext {
+        Model: model,
+        Fields: model.ListFields(),
+        Request: req,
+        ListPage: model.list(items, req, 
+            handler.Configuration.GetIntDefault("scaffold:pageSize", defaultPageSize)),
+        ListUrl: listUrl,
+        NewUrl: handler.mustGenerateUrl(ScaffoldHandler.GetNew, model.Name),
+        DeleteUrl: handler.mustGenerateUrl(ScaffoldHandler.PostDelete),
+        EditUrlFunc: func(id int) string {
+            return handler.mustGenerateUrl(ScaffoldHandler.GetEdit, model.Name, id)
+        },
+        ListUrlFunc: func(sort string, desc bool, page int) string {
+            return listUrl + "?" + listQuery(req.Filter, sort, desc, page)
+        },
+    })
+}
+
+func (handler ScaffoldHandler) GetNew(name string) actionresults.ActionResult {
+    model, denied := handler.resolveModel(name)
+    if denied != nil {
+        return denied
+    }
+    return handler.renderForm(model, model.newItem(), nil)
+}
+
+func (handler ScaffoldHandler) GetEdit(name string, id int) actionresults.ActionResult {
+    model, denied := handler.resolveModel(name)
+    if denied != nil {
+        return denied
+    }
+    item, err := handler.getItem(model, id)
+    if err != nil {
+        return model.errorAction(err)
+    }
+    return handler.renderForm(model, item, nil)
+}
+
+func (handler ScaffoldHandler) PostSave(ref ModelReference) actionresults.ActionResult {
+    model, denied := handler.resolveModel(ref.Model)
+    if denied != nil {
+        return denied
+    }
+    item := model.newItem()
+    if ref.ID != 0 {
+        var err error
+        if item, err = handler.getItem(model, ref.ID); err != nil {
+            return model.errorAction(err)
+        }
+    }
+    for _, field := range model.EditableFields() {
+        if field.Input == "checkbox" {
+            item.Elem().Field(field.index).SetBool(false)
+        }
+    }
+    request, found := pipeline.RequestFromContext(handler.Context)
+    if !found {
+        return actionresults.NewErrorAction(errors.New("No request in context"))
+    }
+    editable := reflect.New(editableType(model))
+    if err := params.BindRequest(request, editable.Interface()); err != nil {
+        return actionresults.NewStatusErrorAction(http.StatusBadRequest, err)
+    }
+    for i, field := range model.EditableFields() {
+        if field.Input == "checkbox" || isPresent(request, field.Name) {
+            item.Elem().Field(field.index).Set(editable.Elem().Field(i))
+        }
+    }
+    item.Elem().FieldByName("ID").SetInt(int64(ref.ID))
+    if handler.Validator != nil {
+        if valid, errs := handler.Validator.Validate(item.Interface()); !valid {
+            return handler.renderForm(model, item, errs)
+        }
+    }
+    store, err := model.store(handler.Context)
+    if err == nil {
+        err = store.Save(handler.Context, item.Interface())
+    }
+    if err != nil {
+        return model.errorAction(err)
+    }
+    return actionresults.NewRedirectAction(
+        handler.mustGenerateUrl(ScaffoldHandler.GetList, model.Name))
+}
+
+func (handler ScaffoldHandler) PostDelete(ref ModelReference) actionresults.ActionResult {
+    model, denied := handler.resolveModel(ref.Model)
+    if denied != nil {
+        return denied
+    }
+    store, err := model.store(handler.Context)
+    if err == nil {
+        err = store.Delete(handler.Context, ref.ID)
+    }
+    if err != nil {
+        return model.errorAction(err)
+    }
+    return actionresults.NewRedirectAction(
+        handler.mustGenerateUrl(ScaffoldHandler.GetList, model.Name))
+}
+
+func (handler ScaffoldHandler) resolveModel(name string) (*Model, actionresults.ActionResult) {
+    var model *Model
+    found := false
+    if handler.Registry != nil {
+        model, found = handler.Registry.Get(strings.ToLower(name))
+    }
+    if !found {
+        return nil, actionresults.NewStatusErrorAction(http.StatusNotFound, 
+            fmt.Errorf("Unknown model: %v", name))
+    }
+    if model.Policy != "" {
+        var authorizer authorization.Authorizer
+        if services.GetServiceForContext(handler.Context, &authorizer) != nil || 
+                !authorizer.Authorize(model.Policy) {
+            return nil, actionresults.NewStatusErrorAction(http.StatusForbidden, 
+                errors.New("Access denied"))
+        }
+    }
+    return model, nil
+}
+
+func (handler ScaffoldHandler) getItem(model *Model, id int) (item reflect.Value, 
+        err error) {
+    store, err := model.store(handler.Context)
+    if err != nil {
+        return
+    }
+    result, err := store.Get(handler.Context, id)
+    if err != nil {
+        return
+    }
+    resultVal := reflect.ValueOf(result)
+    if !resultVal.IsValid() || reflect.Indirect(resultVal).Type() != model.modelType {
+        return item, fmt.Errorf("Store returned unexpected type for model: %v", 
+            model.Name)
+    }
+    item = model.newItem()
+    item.Elem().Set(reflect.Indirect(resultVal))
+    return
+}
+
+func (handler ScaffoldHandler) renderForm(model *Model, item reflect.Value, 
+        errs []validation.ValidationError) actionresults.ActionResult {
+    context := FormTemplateContext {
+        Model: model,
+        ID: model.itemID(item),
+        SaveUrl: handler.mustGenerateUrl(ScaffoldHandler.PostSave),
+        ListUrl: handler.mustGenerateUrl(ScaffoldHandler.GetList, model.Name),
+    }
+    for _, field := range model.EditableFields() {
+        val := item.Elem().Field(field.index)
+        formField := FormField{ Field: field, Value: formatValue(val, field) }
+        if field.Input == "checkbox" {
+            formField.Checked = val.Bool()
+        } else if val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64 {
+            formField.Value = strconv.FormatFloat(val.Float(), 'f', -1, 64)
+        }
+        for _, e := range errs {
+            if e.FieldName == field.Name {
+                formField.Error = e.Error.Error()
+                break
+            }
+        }
+        context.Fields = append(context.Fields, formField)
+    }
+    return handler.render(model, FormTemplate, context)
+}
+
+func (handler ScaffoldHandler) render(model *Model, templateName string, 
+        data interface{}) actionresults.ActionResult {
+    var renderer PageRenderer
+    if services.GetServiceForContext(handler.Context, &renderer) != nil {
+        return actionresults.NewTemplateAction(templateName, data)
+    }
+    var sb strings.Builder
+    if err := handler.TemplateExecutor.ExecTemplate(&sb, templateName, data); 
+            err != nil {
+        return model.errorAction(err)
+    }
+    return renderer.RenderPage(model, template.HTML(sb.String()))
+}
+
+func (handler ScaffoldHandler) mustGenerateUrl(method interface{}, 
+        data ...interface{}) string {
+    url, err := handler.URLGenerator.GenerateUrl(method, data...)
+    if err != nil {
+        panic(err)
+    }
+    return url
+}
+
+func editableType(model *Model) reflect.Type {
+    fields := []reflect.StructField {}
+    for _, field := range model.EditableFields() {
+        structField := model.modelType.Field(field.index)
+        fields = append(fields, reflect.StructField{
+            Name: structField.Name,
+            Type: structField.Type,
+            Tag: reflect.StructTag(fmt.Sprintf(`form:"%v"`, 
+                strings.ToLower(structField.Name))),
+        })
+    }
+    return reflect.StructOf(fields)
+}
+
+func isPresent(request *http.Request, name string) bool {
+    for key := range request.Form {
+        if strings.EqualFold(key, name) {
+            return true
+        }
+    }
+    return false
+}
+
+func listQuery(filter, sort string, desc bool, page int) string {
+    values := url.Values{}
+    if filter != "" {
+        values.Set("filter", filter)
+    }
+    if sort != "" {
+        values.Set("sort", sort)
+    }
+    if desc {
+        values.Set("desc", "true")
+    }
+    if page > 1 {
+        values.Set("page", strconv.Itoa(page))
+    }
+    return values.Encode()
+}
@@ -0,0 +1,136 @@
+package scaffold
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+    "time"
+)
+
+const defaultPageSize = 10
+
+type ListRequest struct {
+    Filter string `query:"filter"`
+    Sort string `query:"sort"`
+    Desc bool `query:"desc"`
+    Page int `query:"page"`
+}
+
+type ListRow struct {
+    ID int
+    Item interface{}
+    Cells []string
+}
+
+type ListPage struct {
+    Rows []ListRow
+    Total int
+    Page int
+    PageCount int
+}
+
+func (page ListPage) PageNumbers() []int {
+    numbers := make([]int, page.PageCount)
+    for i := range numbers {
+        numbers[i] = i + 1
+    }
+    return numbers
+}
+
+func (m *Model) list(items interface{}, req ListRequest, pageSize int) (page ListPage) {
+    itemsVal := reflect.Indirect(reflect.ValueOf(items))
+    if itemsVal.Kind() != reflect.Slice {
+        return
+    }
+    values := make([]reflect.Value, 0, itemsVal.Len())
+    filter := strings.ToLower(strings.TrimSpace(req.Filter))
+    for i := 0; i < itemsVal.Len(); i++ {
+        item := reflect.Indirect(itemsVal.Index(i))
+        if filter == "" || m.matches(item, filter) {
+            values = append(values, item)
+        }
+    }
+    if field, ok := m.field(req.Sort); ok && field.Listed {
+        sort.SliceStable(values, func(i, j int) bool {
+            a, b := values[i].Field(field.index), values[j].Field(field.index)
+            if req.Desc {
+                return lessValue(b, a)
+            }
+            return lessValue(a, b)
+        })
+    }
+    if pageSize < 1 {
+        pageSize = defaultPageSize
+    }
+    page.Total = len(values)
+    page.PageCount = (page.Total + pageSize - 1) / pageSize
+    if page.PageCount == 0 {
+        page.PageCount = 1
+    }
+    page.Page = req.Page
+    if page.Page < 1 {
+        page.Page = 1
+    } else if page.Page > page.PageCount {
+        page.Page = page.PageCount
+    }
+    start := (page.Page - 1) * pageSize
+    end := start + pageSize
+    if end > len(values) {
+        end = len(values)
+    }
+    listFields := m.ListFields()
+    for _, item := range values[start:end] {
+        row := ListRow{ ID: int(item.FieldByName("ID").Int()), 
+            Item: item.Interface() }
+        for _, field := range listFields {
+            row.Cells = append(row.Cells, formatValue(item.Field(field.index), field))
+        }
+        page.Rows = append(page.Rows, row)
+    }
+    return
+}
+
+func (m *Model) matches(item reflect.Value, filter string) bool {
+    for _, field := range m.ListFields() {
+        if strings.Contains(strings.ToLower(
+                formatValue(item.Field(field.index), field)), filter) {
+            return true
+        }
+    }
+    return false
+}
+
+func lessValue(a, b reflect.Value) bool {
+    if a.Type() == timeType {
+        return a.Interface().(time.Time).Before(b.Interface().(time.Time))
+    }
+    switch a.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            return a.Int() < b.Int()
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, 
+                reflect.Uint64:
+            return a.Uint() < b.Uint()
+        case reflect.Float32, reflect.Float64:
+            return a.Float() < b.Float()
+        case reflect.Bool:
+            return !a.Bool() && b.Bool()
+    }
+    return strings.ToLower(a.String()) < strings.ToLower(b.String())
+}
+
+func formatValue(val reflect.Value, field Field) string {
+    if val.Type() == timeType {
+        t := val.Interface().(time.Time)
+        if t.IsZero() {
+            return ""
+        } else if field.Input == "datetime-local" {
+            return t.Format("2006-01-02T15:04")
+        }
+        return t.Format("2006-01-02")
+    }
+    if val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64 {
+        return fmt.Sprintf("%.2f", val.Float())
+    }
+    return fmt.Sprint(val.Interface())
+}
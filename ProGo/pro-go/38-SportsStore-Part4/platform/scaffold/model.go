@@ -0,0 +1,191 @@
+package scaffold
+
+import (
+    "fmt"
+    "platform/http/actionresults"
+    "reflect"
+    "strings"
+    "time"
+    "unicode"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type Model struct {
+    Name string
+    Title string
+    Policy string
+    Fields []Field
+    modelType reflect.Type
+    storeFactory interface{}
+    errorAction func(error) actionresults.ActionResult
+}
+
+type Field struct {
+    Name string
+    Label string
+    Input string
+    Required bool
+    Min string
+    Listed bool
+    Editable bool
+    index int
+}
+
+func NewModel(name string, prototype interface{}, storeFactory interface{}) *Model {
+    modelType := reflect.TypeOf(prototype)
+    if modelType.Kind() == reflect.Ptr {
+        modelType = modelType.Elem()
+    }
+    if modelType.Kind() != reflect.Struct {
+        panic(fmt.Sprintf("Scaffold model must be a struct: %v", modelType))
+    }
+    if idField, found := modelType.FieldByName("ID"); !found || 
+            idField.Type.Kind() != reflect.Int {
+        panic(fmt.Sprintf("Scaffold model must have an int ID field: %v", modelType))
+    }
+    factoryType := reflect.TypeOf(storeFactory)
+    if factoryType == nil || factoryType.Kind() != reflect.Func || 
+            factoryType.NumOut() != 1 || factoryType.Out(0) != storeType {
+        panic(fmt.Sprintf("Scaffold store factory must return scaffold.Store: %v", 
+            factoryType))
+    }
+    model := &Model{
+        Name: strings.ToLower(name),
+        Title: labelFor(modelType.Name()),
+        modelType: modelType,
+        storeFactory: storeFactory,
+        errorAction: actionresults.NewErrorAction,
+    }
+    for i := 0; i < modelType.NumField(); i++ {
+        if field, ok := describeField(modelType.Field(i)); ok {
+            field.index = i
+            model.Fields = append(model.Fields, field)
+        }
+    }
+    return model
+}
+
+func (m *Model) WithTitle(title string) *Model {
+    m.Title = title
+    return m
+}
+
+func (m *Model) WithPolicy(policy string) *Model {
+    m.Policy = policy
+    return m
+}
+
+func (m *Model) WithErrorAction(
+        errorAction func(error) actionresults.ActionResult) *Model {
+    m.errorAction = errorAction
+    return m
+}
+
+func (m *Model) ListFields() (fields []Field) {
+    for _, f := range m.Fields {
+        if f.Listed {
+            fields = append(fields, f)
+        }
+    }
+    return
+}
+
+func (m *Model) EditableFields() (fields []Field) {
+    for _, f := range m.Fields {
+        if f.Editable {
+            fields = append(fields, f)
+        }
+    }
+    return
+}
+
+func (m *Model) field(name string) (Field, bool) {
+    for _, f := range m.Fields {
+        if strings.EqualFold(f.Name, name) {
+            return f, true
+        }
+    }
+    return Field{}, false
+}
+
+func (m *Model) newItem() reflect.Value {
+    return reflect.New(m.modelType)
+}
+
+func (m *Model) itemID(item reflect.Value) int {
+    return int(reflect.Indirect(item).FieldByName("ID").Int())
+}
+
+func describeField(structField reflect.StructField) (field Field, ok bool) {
+    if structField.PkgPath != "" || structField.Anonymous {
+        return
+    }
+    field = Field{ Name: structField.Name, Label: labelFor(structField.Name), 
+        Listed: true, Editable: structField.Name != "ID" }
+    switch {
+        case structField.Type == timeType:
+            field.Input = "date"
+        case structField.Type.Kind() == reflect.String:
+            field.Input = "text"
+        case structField.Type.Kind() == reflect.Bool:
+            field.Input = "checkbox"
+        case isNumber(structField.Type):
+            field.Input = "number"
+        default:
+            return field, false
+    }
+    for _, rule := range strings.Split(structField.Tag.Get("validation"), ",") {
+        name, arg := rule, ""
+        if parts := strings.SplitN(rule, ":", 2); len(parts) == 2 {
+            name, arg = parts[0], parts[1]
+        }
+        switch name {
+            case "required":
+                field.Required = true
+            case "min":
+                field.Min = arg
+            case "email":
+                field.Input = "email"
+        }
+    }
+    for _, option := range strings.Split(structField.Tag.Get("scaffold"), ",") {
+        switch {
+            case option == "-":
+                return field, false
+            case option == "nolist":
+                field.Listed = false
+            case option == "readonly":
+                field.Editable = false
+            case option == "textarea" || option == "datetime-local" || 
+                    option == "password":
+                field.Input = option
+            case strings.HasPrefix(option, "label="):
+                field.Label = strings.TrimPrefix(option, "label=")
+        }
+    }
+    return field, true
+}
+
+func isNumber(t reflect.Type) bool {
+    switch t.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+                reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, 
+                reflect.Uint64, reflect.Float32, reflect.Float64:
+            return true
+    }
+    return false
+}
+
+func labelFor(name string) string {
+    var sb strings.Builder
+    runes := []rune(name)
+    for i, r := range runes {
+        if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i - 1]) || 
+                (i + 1 < len(runes) && unicode.IsLower(runes[i + 1]))) {
+            sb.WriteRune(' ')
+        }
+        sb.WriteRune(r)
+    }
+    return sb.String()
+}
@@ -0,0 +1,54 @@
+package scaffold
+
+import (
+    "context"
+    "fmt"
+    "platform/services"
+    "reflect"
+)
+
+var storeType = reflect.TypeOf((*Store)(nil)).Elem()
+
+type Registry struct {
+    models []*Model
+}
+
+func (r *Registry) Models() []*Model {
+    return r.models
+}
+
+func (r *Registry) Get(name string) (model *Model, found bool) {
+    for _, m := range r.models {
+        if m.Name == name {
+            return m, true
+        }
+    }
+    return
+}
+
+func (m *Model) store(ctx context.Context) (store Store, err error) {
+    results, err := services.CallForContext(ctx, m.storeFactory)
+    if err == nil {
+        store, _ = results[0].(Store)
+        if store == nil {
+            err = fmt.Errorf("No store available for model: %v", m.Name)
+        }
+    }
+    return
+}
+
+func RegisterModels(models ...*Model) {
+    registry := &Registry{}
+    for _, m := range models {
+        if _, found := registry.Get(m.Name); found {
+            panic(fmt.Sprintf("Duplicate scaffold model: %v", m.Name))
+        }
+        registry.models = append(registry.models, m)
+    }
+    err := services.AddSingleton(func() *Registry {
+        return registry
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
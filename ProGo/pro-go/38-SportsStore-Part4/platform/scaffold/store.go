@@ -0,0 +1,14 @@
+package scaffold
+
+import "context"
+
+type Store interface {
+
+    GetAll(ctx context.Context) (items interface{}, err error)
+
+    Get(ctx context.Context, id int) (item interface{}, err error)
+
+    Save(ctx context.Context, item interface{}) error
+
+    Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,129 @@
+package security
+
+import (
+    "fmt"
+    "net/http"
+    "platform/config"
+    "platform/pipeline"
+    "strings"
+)
+
+func NewCorsComponent(configKey string) *CorsComponent {
+    return &CorsComponent{ configKey: configKey }
+}
+
+type CorsComponent struct {
+    configKey string
+    config.Configuration
+    origins map[string]bool
+    anyOrigin bool
+    methods map[string]bool
+    headers map[string]bool
+    anyHeader bool
+    allowMethods string
+    allowHeaders string
+    exposeHeaders string
+    credentials bool
+    maxAge int
+}
+
+func (c *CorsComponent) Init() {
+    cfg := func(name, defVal string) string {
+        return c.Configuration.GetStringDefault(c.configKey + ":" + name, defVal)
+    }
+    var methods, headers []string
+    c.origins, _ = splitList(cfg("allowedOrigins", ""), strings.ToLower)
+    c.methods, methods = splitList(cfg("allowedMethods", "GET,HEAD,POST"), 
+        strings.ToUpper)
+    c.headers, headers = splitList(cfg("allowedHeaders", "Content-Type"), 
+        strings.ToLower)
+    _, exposed := splitList(cfg("exposedHeaders", ""), strings.ToLower)
+    c.anyOrigin, c.anyHeader = c.origins["*"], c.headers["*"]
+    c.allowMethods = strings.Join(methods, ", ")
+    c.allowHeaders = strings.Join(headers, ", ")
+    c.exposeHeaders = strings.Join(exposed, ", ")
+    c.credentials = c.Configuration.GetBoolDefault(c.configKey + ":allowCredentials", 
+        false)
+    c.maxAge = c.Configuration.GetIntDefault(c.configKey + ":maxAge", 0)
+}
+
+func (c *CorsComponent) ProcessRequest(ctx *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext)) {
+    origin := ctx.Request.Header.Get("Origin")
+    preflight := ctx.Request.Method == http.MethodOptions && 
+        ctx.Request.Header.Get("Access-Control-Request-Method") != ""
+    header := ctx.ResponseWriter.Header()
+    if !c.anyOrigin || c.credentials {
+        header.Add("Vary", "Origin")
+    }
+    if origin == "" {
+        next(ctx)
+        return
+    } else if !c.anyOrigin && !c.origins[strings.ToLower(origin)] {
+        if preflight {
+            ctx.ResponseWriter.WriteHeader(http.StatusForbidden)
+        } else {
+            next(ctx)
+        }
+        return
+    }
+    if c.anyOrigin && !c.credentials {
+        header.Set("Access-Control-Allow-Origin", "*")
+    } else {
+        header.Set("Access-Control-Allow-Origin", origin)
+    }
+    if c.credentials {
+        header.Set("Access-Control-Allow-Credentials", "true")
+    }
+    if !preflight {
+        if c.exposeHeaders != "" {
+            header.Set("Access-Control-Expose-Headers", c.exposeHeaders)
+        }
+        next(ctx)
+        return
+    }
+    header.Add("Vary", "Access-Control-Request-Method")
+    header.Add("Vary", "Access-Control-Request-Headers")
+    if !c.methods[strings.ToUpper(
+            ctx.Request.Header.Get("Access-Control-Request-Method"))] || 
+            !c.allowsHeaders(ctx.Request.Header.Get("Access-Control-Request-Headers")) {
+        ctx.ResponseWriter.WriteHeader(http.StatusForbidden)
+        return
+    }
+    header.Set("Access-Control-Allow-Methods", c.allowMethods)
+    if c.anyHeader {
+        header.Set("Access-Control-Allow-Headers", 
+            ctx.Request.Header.Get("Access-Control-Request-Headers"))
+    } else if c.allowHeaders != "" {
+        header.Set("Access-Control-Allow-Headers", c.allowHeaders)
+    }
+    if c.maxAge > 0 {
+        header.Set("Access-Control-Max-Age", fmt.Sprint(c.maxAge))
+    }
+    ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CorsComponent) allowsHeaders(requested string) bool {
+    if c.anyHeader {
+        return true
+    }
+    for _, name := range strings.Split(requested, ",") {
+        if name = strings.TrimSpace(name); name != "" && 
+                !c.headers[strings.ToLower(name)] {
+            return false
+        }
+    }
+    return true
+}
+
+func splitList(list string, 
+        normalize func(string) string) (set map[string]bool, values []string) {
+    set = map[string]bool {}
+    for _, val := range strings.Split(list, ",") {
+        if val = strings.TrimSpace(val); val != "" {
+            set[normalize(val)] = true
+            values = append(values, val)
+        }
+    }
+    return
+}
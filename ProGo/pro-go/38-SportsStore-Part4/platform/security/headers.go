@@ -0,0 +1,114 @@
+package security
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/base64"
+    "fmt"
+    "net/http"
+    "platform/config"
+    "platform/pipeline"
+    "strings"
+)
+
+const NONCE_PLACEHOLDER = "{nonce}"
+
+type nonceKey struct {}
+
+func NewSecurityHeadersComponent(configKey string) *SecurityHeadersComponent {
+    return &SecurityHeadersComponent{ configKey: configKey }
+}
+
+type SecurityHeadersComponent struct {
+    configKey string
+    config.Configuration
+    headers map[string]string
+    hsts string
+    hstsAlways bool
+    trustForwardedProto bool
+    policy string
+}
+
+func (c *SecurityHeadersComponent) Init() {
+    cfg := func(name, defVal string) string {
+        return c.Configuration.GetStringDefault(c.configKey + ":" + name, defVal)
+    }
+    c.headers = map[string]string {}
+    for header, val := range map[string]string {
+        "X-Frame-Options": cfg("frameOptions", "DENY"),
+        "X-Content-Type-Options": cfg("contentTypeOptions", "nosniff"),
+        "Referrer-Policy": cfg("referrerPolicy", "strict-origin-when-cross-origin"),
+    } {
+        if val != "" {
+            c.headers[header] = val
+        }
+    }
+    if maxAge := c.Configuration.GetIntDefault(c.configKey + ":hsts:maxAge", 0); 
+            maxAge > 0 {
+        c.hsts = fmt.Sprintf("max-age=%v", maxAge)
+        if c.Configuration.GetBoolDefault(c.configKey + ":hsts:includeSubDomains", 
+                false) {
+            c.hsts += "; includeSubDomains"
+        }
+        if c.Configuration.GetBoolDefault(c.configKey + ":hsts:preload", false) {
+            c.hsts += "; preload"
+        }
+        c.hstsAlways = c.Configuration.GetBoolDefault(c.configKey + ":hsts:always",
+            false)
+        c.trustForwardedProto = c.Configuration.GetBoolDefault(
+            c.configKey + ":hsts:trustForwardedProto", false)
+    }
+    c.policy = cfg("contentSecurityPolicy", "")
+}
+
+func (c *SecurityHeadersComponent) ProcessRequest(ctx *pipeline.ComponentContext, 
+        next func(*pipeline.ComponentContext)) {
+    header := ctx.ResponseWriter.Header()
+    for name, val := range c.headers {
+        header.Set(name, val)
+    }
+    if c.hsts != "" && c.isSecure(ctx.Request) {
+        header.Set("Strict-Transport-Security", c.hsts)
+    }
+    if c.policy != "" {
+        policy := c.policy
+        if strings.Contains(policy, NONCE_PLACEHOLDER) {
+            nonce, err := newNonce()
+            if err != nil {
+                ctx.Error(err)
+                return
+            }
+            policy = strings.ReplaceAll(policy, NONCE_PLACEHOLDER, nonce)
+            ctx.Request = ctx.Request.WithContext(context.WithValue(
+                ctx.Request.Context(), nonceKey{}, nonce))
+        }
+        header.Set("Content-Security-Policy", policy)
+    }
+    next(ctx)
+}
+
+func (c *SecurityHeadersComponent) isSecure(req *http.Request) bool {
+    if c.hstsAlways || req.TLS != nil {
+        return true
+    } else if c.trustForwardedProto {
+        proto := strings.Split(req.Header.Get("X-Forwarded-Proto"), ",")[0]
+        return strings.EqualFold(strings.TrimSpace(proto), "https")
+    }
+    return false
+}
+
+func CSPNonce(ctx context.Context) string {
+    if ctx == nil {
+        return ""
+    }
+    nonce, _ := ctx.Value(nonceKey{}).(string)
+    return nonce
+}
+
+func newNonce() (string, error) {
+    data := make([]byte, 16)
+    if _, err := rand.Read(data); err != nil {
+        return "", err
+    }
+    return base64.RawURLEncoding.EncodeToString(data), nil
+}
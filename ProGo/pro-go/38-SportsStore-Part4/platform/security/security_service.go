@@ -0,0 +1,17 @@
+package security
+
+import (
+    "context"
+    "platform/templates"
+)
+
+func RegisterSecurityServices() {
+    err := templates.AddContextFunc("cspNonce", func(ctx context.Context) func() string {
+        return func() string {
+            return CSPNonce(ctx)
+        }
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
@@ -0,0 +1,212 @@
+package security
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "platform/pipeline"
+    "platform/platformtest"
+    "testing"
+)
+
+type nonceComponent struct {
+    nonce string
+}
+
+func (c *nonceComponent) Init() {}
+
+func (c *nonceComponent) ProcessRequest(ctx *pipeline.ComponentContext,
+        next func(*pipeline.ComponentContext)) {
+    c.nonce = CSPNonce(ctx.Request.Context())
+    ctx.ResponseWriter.Write([]byte("ok"))
+}
+
+func headersClient(t *testing.T, values map[string]interface{}) (*platformtest.Client,
+        *nonceComponent) {
+    recorder := &nonceComponent{}
+    h := platformtest.New(t, platformtest.ConfigValues(values)).
+        Build(NewSecurityHeadersComponent("security:headers"), recorder)
+    return h.NewClient(), recorder
+}
+
+func TestSecurityHeaders(t *testing.T) {
+    client, recorder := headersClient(t, map[string]interface{} {
+        "security:headers:referrerPolicy": "no-referrer",
+        "security:headers:frameOptions": "",
+    })
+    response := client.Get("/")
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    header := response.Header()
+    if header.Get("X-Content-Type-Options") != "nosniff" ||
+            header.Get("Referrer-Policy") != "no-referrer" ||
+            header.Get("X-Frame-Options") != "" ||
+            header.Get("Content-Security-Policy") != "" {
+        t.Fatalf("Unexpected headers: %v", header)
+    }
+    if recorder.nonce != "" || CSPNonce(nil) != "" {
+        t.Fatal("Expected no nonce without a policy")
+    }
+}
+
+func TestContentSecurityPolicy(t *testing.T) {
+    client, recorder := headersClient(t, map[string]interface{} {
+        "security:headers:contentSecurityPolicy": "script-src 'nonce-{nonce}'",
+    })
+    policy := client.Get("/").Header().Get("Content-Security-Policy")
+    first := recorder.nonce
+    if first == "" || policy != "script-src 'nonce-" + first + "'" {
+        t.Fatalf("Unexpected policy %v for nonce %v", policy, first)
+    }
+    client.Get("/")
+    if recorder.nonce == "" || recorder.nonce == first {
+        t.Fatal("Expected a new nonce for each request")
+    }
+}
+
+func TestStrictTransportSecurity(t *testing.T) {
+    hsts := map[string]interface{} {
+        "security:headers:hsts:maxAge": 3600,
+        "security:headers:hsts:includeSubDomains": true,
+        "security:headers:hsts:preload": true,
+    }
+    expected := "max-age=3600; includeSubDomains; preload"
+    forwarded := func(proto string) *http.Request {
+        req := httptest.NewRequest(http.MethodGet, "/", nil)
+        req.Header.Set("X-Forwarded-Proto", proto)
+        return req
+    }
+    tests := []struct { name string; options map[string]interface{}
+            req *http.Request; sent bool } {
+        { "plain", nil, httptest.NewRequest(http.MethodGet, "/", nil), false },
+        { "tls", nil, httptest.NewRequest(http.MethodGet, "https://localhost/", nil),
+            true },
+        { "untrusted proxy", nil, forwarded("https"), false },
+        { "trusted proxy", map[string]interface{} {
+            "security:headers:hsts:trustForwardedProto": true }, forwarded("HTTPS"),
+            true },
+        { "proxy chain", map[string]interface{} {
+            "security:headers:hsts:trustForwardedProto": true },
+            forwarded("https, http"), true },
+        { "trusted plain proxy", map[string]interface{} {
+            "security:headers:hsts:trustForwardedProto": true }, forwarded("http"),
+            false },
+        { "always", map[string]interface{} { "security:headers:hsts:always": true },
+            httptest.NewRequest(http.MethodGet, "/", nil), true },
+    }
+    for _, test := range tests {
+        values := map[string]interface{} {}
+        for _, options := range []map[string]interface{} { hsts, test.options } {
+            for key, val := range options {
+                values[key] = val
+            }
+        }
+        t.Run(test.name, func(t *testing.T) {
+            client, _ := headersClient(t, values)
+            header := client.Do(test.req).Header().Get("Strict-Transport-Security")
+            if (test.sent && header != expected) || (!test.sent && header != "") {
+                t.Fatalf("Unexpected header %q", header)
+            }
+        })
+    }
+    t.Run("no max age", func(t *testing.T) {
+        client, _ := headersClient(t, map[string]interface{} {
+            "security:headers:hsts:always": true })
+        if header := client.Get("/").Header().Get("Strict-Transport-Security");
+                header != "" {
+            t.Fatalf("Expected no header without a max age: %v", header)
+        }
+    })
+}
+
+func corsClient(t *testing.T, values map[string]interface{}) *platformtest.Client {
+    h := platformtest.New(t, platformtest.ConfigValues(values)).
+        Build(NewCorsComponent("api:cors"), &nonceComponent{})
+    return h.NewClient()
+}
+
+func corsRequest(method, origin string, headers ...string) *http.Request {
+    req := httptest.NewRequest(method, "/api/products", nil)
+    req.Header.Set("Origin", origin)
+    for i := 0; i + 1 < len(headers); i += 2 {
+        req.Header.Set(headers[i], headers[i + 1])
+    }
+    return req
+}
+
+func TestCors(t *testing.T) {
+    client := corsClient(t, map[string]interface{} {
+        "api:cors:allowedOrigins": "https://shop.example.com",
+        "api:cors:allowedMethods": "GET, PUT",
+        "api:cors:allowedHeaders": "Content-Type, Authorization",
+        "api:cors:exposedHeaders": "ETag",
+        "api:cors:allowCredentials": true,
+        "api:cors:maxAge": 600,
+    })
+    simple := client.Do(corsRequest(http.MethodGet, "https://SHOP.example.com"))
+    platformtest.AssertStatus(t, simple, http.StatusOK)
+    if header := simple.Header(); header.Get("Access-Control-Allow-Origin") !=
+            "https://SHOP.example.com" ||
+            header.Get("Access-Control-Allow-Credentials") != "true" ||
+            header.Get("Access-Control-Expose-Headers") != "ETag" ||
+            header.Get("Vary") != "Origin" || simple.Body.String() != "ok" {
+        t.Fatalf("Unexpected simple response: %v", header)
+    }
+    other := client.Do(corsRequest(http.MethodGet, "https://other.example.com"))
+    if other.Body.String() != "ok" ||
+            other.Header().Get("Access-Control-Allow-Origin") != "" {
+        t.Fatal("Expected other origins to be passed along without CORS headers")
+    }
+    preflight := client.Do(corsRequest(http.MethodOptions, "https://shop.example.com",
+        "Access-Control-Request-Method", "put",
+        "Access-Control-Request-Headers", "authorization"))
+    platformtest.AssertStatus(t, preflight, http.StatusNoContent)
+    if header := preflight.Header(); header.Get("Access-Control-Allow-Methods") !=
+            "GET, PUT" ||
+            header.Get("Access-Control-Allow-Headers") != "Content-Type, Authorization" ||
+            header.Get("Access-Control-Max-Age") != "600" ||
+            len(header.Values("Vary")) != 3 || preflight.Body.Len() != 0 {
+        t.Fatalf("Unexpected preflight response: %v", header)
+    }
+    for _, req := range []*http.Request {
+        corsRequest(http.MethodOptions, "https://other.example.com",
+            "Access-Control-Request-Method", "GET"),
+        corsRequest(http.MethodOptions, "https://shop.example.com",
+            "Access-Control-Request-Method", "DELETE"),
+        corsRequest(http.MethodOptions, "https://shop.example.com",
+            "Access-Control-Request-Method", "GET",
+            "Access-Control-Request-Headers", "X-Custom"),
+    } {
+        platformtest.AssertStatus(t, client.Do(req), http.StatusForbidden)
+    }
+}
+
+func TestCorsAnyOrigin(t *testing.T) {
+    client := corsClient(t, map[string]interface{} {
+        "api:cors:allowedOrigins": "*",
+        "api:cors:allowedHeaders": "*",
+    })
+    simple := client.Do(corsRequest(http.MethodGet, "https://any.example.com"))
+    if simple.Header().Get("Access-Control-Allow-Origin") != "*" ||
+            simple.Header().Get("Vary") != "" {
+        t.Fatalf("Unexpected response: %v", simple.Header())
+    }
+    preflight := client.Do(corsRequest(http.MethodOptions, "https://any.example.com",
+        "Access-Control-Request-Method", "POST",
+        "Access-Control-Request-Headers", "X-Custom"))
+    platformtest.AssertStatus(t, preflight, http.StatusNoContent)
+    if preflight.Header().Get("Access-Control-Allow-Headers") != "X-Custom" {
+        t.Fatalf("Expected requested headers to be allowed: %v", preflight.Header())
+    }
+    noOrigin := client.Do(httptest.NewRequest(http.MethodGet, "/", nil))
+    if noOrigin.Body.String() != "ok" ||
+            noOrigin.Header().Get("Access-Control-Allow-Origin") != "" {
+        t.Fatal("Expected requests without an origin to be passed along")
+    }
+}
+
+func TestCSPNonceContext(t *testing.T) {
+    ctx := context.WithValue(context.Background(), nonceKey{}, "abc")
+    if CSPNonce(ctx) != "abc" || CSPNonce(context.Background()) != "" {
+        t.Fatal("Expected nonce to be read from the context")
+    }
+}
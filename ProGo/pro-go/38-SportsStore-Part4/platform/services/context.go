@@ -16,3 +16,7 @@ func NewServiceContext(c context.Context) context.Context {
         return c
     }
 }
+
+func NewServiceScope(c context.Context) context.Context {
+    return context.WithValue(c, ServiceKey, make(serviceMap))
+}
@@ -11,12 +11,30 @@ type BindingMap struct {
     lifecycle
 }
 
-var services = make(map[reflect.Type]BindingMap)
+type Registry struct {
+    bindings map[reflect.Type]BindingMap
+}
+
+func NewRegistry() *Registry {
+    return &Registry{ bindings: make(map[reflect.Type]BindingMap) }
+}
+
+var services = NewRegistry()
+
+func UseRegistry(r *Registry) (previous *Registry) {
+    previous, services = services, r
+    return
+}
+
+func isRegistered(serviceType reflect.Type) bool {
+    _, found := services.bindings[serviceType]
+    return found
+}
 
 func addService(life lifecycle, factoryFunc interface{}) (err error) {
     factoryFuncType := reflect.TypeOf(factoryFunc)
     if factoryFuncType.Kind() == reflect.Func && factoryFuncType.NumOut() == 1 {
-        services[factoryFuncType.Out(0)] = BindingMap{
+        services.bindings[factoryFuncType.Out(0)] = BindingMap{
             factoryFunc: reflect.ValueOf(factoryFunc),
             lifecycle: life,
         }
@@ -32,8 +50,10 @@ var contextReferenceType = reflect.TypeOf(contextReference).Elem()
 func resolveServiceFromValue(c context.Context, val reflect.Value) (err error ){
     serviceType := val.Elem().Type()
     if serviceType == contextReferenceType {
-        val.Elem().Set(reflect.ValueOf(c))
-    } else if binding, found := services[serviceType]; found {
+        if (c != nil) {
+            val.Elem().Set(reflect.ValueOf(c))
+        }
+    } else if binding, found := services.bindings[serviceType]; found {
         if (binding.lifecycle == Scoped) {
             resolveScopedService(c, val, binding)
         } else {
@@ -47,7 +67,11 @@ func resolveServiceFromValue(c context.Context, val reflect.Value) (err error ){
 
 func resolveScopedService(c context.Context, val reflect.Value, 
         binding BindingMap) (err error) {
-    sMap, ok := c.Value(ServiceKey).(serviceMap)
+    var sMap serviceMap
+    ok := false
+    if (c != nil) {
+        sMap, ok = c.Value(ServiceKey).(serviceMap)
+    }
     if (ok) {
         serviceVal, ok := sMap[val.Type()]
         if (!ok) {
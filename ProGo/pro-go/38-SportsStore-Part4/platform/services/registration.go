@@ -1,6 +1,8 @@
 package services
 
 import (
+    "context"
+    "fmt"
     "reflect"
     "sync"
 )
@@ -18,7 +20,8 @@ func AddSingleton(factoryFunc interface{}) (err error) {
     if factoryFuncVal.Kind() == reflect.Func && factoryFuncVal.Type().NumOut() == 1 {
         var results []reflect.Value
         once := sync.Once{}
-        wrapper := reflect.MakeFunc(factoryFuncVal.Type(), 
+        wrapper := reflect.MakeFunc(reflect.FuncOf(nil, 
+                []reflect.Type { factoryFuncVal.Type().Out(0) }, false), 
             func ([]reflect.Value) []reflect.Value {
                 once.Do(func() { 
                     results = invokeFunction(nil, factoryFuncVal)
@@ -29,3 +32,40 @@ func AddSingleton(factoryFunc interface{}) (err error) {
     }   
     return 
 }
+
+func Decorate(decoratorFunc interface{}) (err error) {
+    decoratorVal := reflect.ValueOf(decoratorFunc)
+    decoratorType := decoratorVal.Type()
+    if decoratorType.Kind() != reflect.Func || decoratorType.NumOut() != 1 ||
+            decoratorType.NumIn() == 0 || decoratorType.In(0) != decoratorType.Out(0) {
+        return fmt.Errorf("Type cannot be used as decorator: %v", decoratorType)
+    }
+    serviceType := decoratorType.Out(0)
+    binding, found := services.bindings[serviceType]
+    if !found {
+        return fmt.Errorf("Cannot find service %v to decorate", serviceType)
+    }
+    decorate := func(c context.Context) []reflect.Value {
+        inner := invokeFunction(c, binding.factoryFunc)[0]
+        return invokeFunction(c, decoratorVal, inner.Interface())
+    }
+    if binding.lifecycle == Singleton {
+        var results []reflect.Value
+        once := sync.Once{}
+        factory := reflect.MakeFunc(reflect.FuncOf(nil, 
+                []reflect.Type { serviceType }, false),
+            func ([]reflect.Value) []reflect.Value {
+                once.Do(func() { results = decorate(nil) })
+                return results
+            })
+        return addService(Singleton, factory.Interface())
+    }
+    factory := reflect.MakeFunc(reflect.FuncOf(
+            []reflect.Type { contextReferenceType }, 
+            []reflect.Type { serviceType }, false),
+        func (args []reflect.Value) []reflect.Value {
+            c, _ := args[0].Interface().(context.Context)
+            return decorate(c)
+        })
+    return addService(binding.lifecycle, factory.Interface())
+}
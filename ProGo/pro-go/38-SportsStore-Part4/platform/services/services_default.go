@@ -1,21 +1,26 @@
 package services
 
 import (
+    "context"
     "platform/logging"
     "platform/config"
     "platform/templates"
     "platform/validation"    
+    "reflect"
 )
 
 func RegisterDefaultServices() {
 
-    err := AddSingleton(func() (c config.Configuration) {
-        c, loadErr :=  config.Load("config.json")
-        if (loadErr != nil) {
-            panic(loadErr)
-        }
-        return
-    })
+    var err error
+    if !isRegistered(reflect.TypeOf((*config.Configuration)(nil)).Elem()) {
+        err = AddSingleton(func() (c config.Configuration) {
+            c, loadErr :=  config.Load("config.json")
+            if (loadErr != nil) {
+                panic(loadErr)
+            }
+            return
+        })
+    }
 
     err = AddSingleton(func(appconfig config.Configuration) logging.Logger {
         return logging.NewDefaultLogger(appconfig)
@@ -24,10 +29,12 @@ func RegisterDefaultServices() {
         panic(err)
     }
 
-    err = AddSingleton(
-        func(c config.Configuration) templates.TemplateExecutor {
+    err = AddScoped(
+        func(ctx context.Context, c config.Configuration) templates.TemplateExecutor {
             templates.LoadTemplates(c)
-            return &templates.LayoutTemplateProcessor{}
+            return &templates.LayoutTemplateProcessor{ 
+                ContextFuncs: TemplateFuncsForContext(ctx),
+            }
         })
     if (err != nil) {
         panic(err)
@@ -0,0 +1,31 @@
+package services
+
+import (
+    "context"
+    "platform/templates"
+    "reflect"
+    "sync"
+)
+
+func TemplateFuncsForContext(c context.Context) func() map[string]interface{} {
+    return func() map[string]interface{} {
+        funcs := map[string]interface{} {}
+        for name, factory := range templates.ContextFuncFactories() {
+            funcs[name] = lazyTemplateFunc(c, reflect.ValueOf(factory))
+        }
+        return funcs
+    }
+}
+
+func lazyTemplateFunc(c context.Context, factory reflect.Value) interface{} {
+    var target reflect.Value
+    once := sync.Once{}
+    return reflect.MakeFunc(factory.Type().Out(0), 
+        func(args []reflect.Value) []reflect.Value {
+            once.Do(func() { target = invokeFunction(c, factory)[0] })
+            if target.Type().IsVariadic() {
+                return target.CallSlice(args)
+            }
+            return target.Call(args)
+        }).Interface()
+}
@@ -2,12 +2,19 @@ package sessions
 
 import (
 	"context"
+    "errors"
+    "sync"
     "time"
 	"platform/config"
 	"platform/pipeline"
+	"platform/services"
 	gorilla "github.com/gorilla/sessions"
+	"github.com/gorilla/securecookie"
 )
 
+var activeStore *gorilla.CookieStore
+var activeStoreLock = sync.RWMutex{}
+
 type SessionComponent struct {
     store *gorilla.CookieStore
     config.Configuration
@@ -22,11 +29,36 @@ func (sc *SessionComponent) Init() {
         cookiekey += time.Now().String()
     }
     sc.store = gorilla.NewCookieStore([]byte(cookiekey))    
+    activeStoreLock.Lock()
+    activeStore = sc.store
+    activeStoreLock.Unlock()
+}
+
+func CheckStore() error {
+    activeStoreLock.RLock()
+    store := activeStore
+    activeStoreLock.RUnlock()
+    if store == nil {
+        return errors.New("Session store has not been initialized")
+    }
+    values := map[interface{}]interface{} { "check": time.Now().UnixNano() }
+    encoded, err := securecookie.EncodeMulti("health", values, store.Codecs...)
+    if err == nil {
+        decoded := map[interface{}]interface{} {}
+        err = securecookie.DecodeMulti("health", encoded, &decoded, store.Codecs...)
+        if err == nil && decoded["check"] != values["check"] {
+            err = errors.New("Session store returned unexpected values")
+        }
+    }
+    return err
 }
 
 func (sc *SessionComponent) ProcessRequest(ctx *pipeline.ComponentContext, 
         next func(*pipeline.ComponentContext)) {
-    session, _ := sc.store.Get(ctx.Request, SESSION__CONTEXT_KEY)
+    cfg := sc.Configuration
+    services.GetServiceForContext(ctx.Request.Context(), &cfg)
+    session, _ := sc.store.Get(ctx.Request, 
+        cfg.GetStringDefault("sessions:cookie", SESSION__CONTEXT_KEY))
     c := context.WithValue(ctx.Request.Context(), SESSION__CONTEXT_KEY, session)
     ctx.Request = ctx.Request.WithContext(c)    
     next(ctx)
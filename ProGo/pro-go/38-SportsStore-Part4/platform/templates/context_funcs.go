@@ -0,0 +1,49 @@
+package templates
+
+import (
+    "fmt"
+    "reflect"
+    "sync"
+)
+
+var contextFuncs = map[string]interface{} {}
+var contextFuncsLock = sync.RWMutex{}
+
+func AddContextFunc(name string, factory interface{}) error {
+    factoryType := reflect.TypeOf(factory)
+    if factoryType == nil || factoryType.Kind() != reflect.Func || 
+            factoryType.NumOut() != 1 || factoryType.Out(0).Kind() != reflect.Func {
+        return fmt.Errorf("Type cannot be used as template function factory: %v", 
+            factoryType)
+    }
+    contextFuncsLock.Lock()
+    defer contextFuncsLock.Unlock()
+    contextFuncs[name] = factory
+    return nil
+}
+
+func ContextFuncFactories() map[string]interface{} {
+    contextFuncsLock.RLock()
+    defer contextFuncsLock.RUnlock()
+    factories := make(map[string]interface{}, len(contextFuncs))
+    for name, factory := range contextFuncs {
+        factories[name] = factory
+    }
+    return factories
+}
+
+func contextFuncPlaceholders() map[string]interface{} {
+    placeholders := map[string]interface{} {}
+    for name, factory := range ContextFuncFactories() {
+        funcType := reflect.TypeOf(factory).Out(0)
+        placeholders[name] = reflect.MakeFunc(funcType, 
+            func([]reflect.Value) []reflect.Value {
+                results := make([]reflect.Value, funcType.NumOut())
+                for i := range results {
+                    results[i] = reflect.Zero(funcType.Out(i))
+                }
+                return results
+            }).Interface()
+    }
+    return placeholders
+}
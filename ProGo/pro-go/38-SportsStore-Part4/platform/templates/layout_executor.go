@@ -6,7 +6,10 @@ import (
     "html/template"
 )
 
-type LayoutTemplateProcessor struct {}
+type LayoutTemplateProcessor struct {
+    Theme string
+    ContextFuncs func() map[string]interface{}
+}
 
 var emptyFunc = func(handlerName, methodName string, 
     args ...interface{}) interface{} { return "" }
@@ -23,11 +26,17 @@ func (proc *LayoutTemplateProcessor) ExecTemplateWithFunc(writer io.Writer,
     var sb strings.Builder
     layoutName := ""
     localTemplates := getTemplates()
+    if (proc.Theme != "") {
+        localTemplates = getThemeTemplates(proc.Theme)
+    }
     localTemplates.Funcs(map[string]interface{} {
         "body": insertBodyWrapper(&sb),
         "layout": setLayoutWrapper(&layoutName),
         "handler": handlerFunc,
     })    
+    if (proc.ContextFuncs != nil) {
+        localTemplates.Funcs(proc.ContextFuncs())
+    }
     err = localTemplates.ExecuteTemplate(&sb, name, data)
     if (layoutName != "") {
         localTemplates.ExecuteTemplate(writer, layoutName, data)
@@ -39,6 +48,8 @@ func (proc *LayoutTemplateProcessor) ExecTemplateWithFunc(writer io.Writer,
 
 var getTemplates func() (t *template.Template)
 
+var getThemeTemplates func(theme string) (t *template.Template)
+
 func insertBodyWrapper(body *strings.Builder) func() template.HTML {
     return func() template.HTML {
         return template.HTML(body.String())
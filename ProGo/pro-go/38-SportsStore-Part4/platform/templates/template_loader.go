@@ -2,6 +2,7 @@ package templates
 
 import (
     "html/template"
+    "path/filepath"
     "sync"
     "errors"
     "platform/config"
@@ -9,6 +10,8 @@ import (
 
 var once = sync.Once{}
 
+var checkTemplates func() error
+
 func LoadTemplates(c config.Configuration) (err error) {
     path, ok := c.GetString("templates:path")
     if !ok {
@@ -16,26 +19,74 @@ func LoadTemplates(c config.Configuration) (err error) {
     }    
     reload := c.GetBoolDefault("templates:reload", false)
     once.Do(func() {
-        doLoad := func() (t *template.Template) {
-            t = template.New("htmlTemplates")
+        parse := func() (*template.Template, error) {
+            t := template.New("htmlTemplates")
             t.Funcs(map[string]interface{} {
                 "body": func() string { return "" },
                 "layout": func() string { return "" },
                 "handler": func() interface{} { return "" },
             })    
-            t, err = t.ParseGlob(path)
+            t.Funcs(contextFuncPlaceholders())
+            return t.ParseGlob(path)
+        }
+        doLoad := func() (t *template.Template) {
+            t, err = parse()
             return            
         }
+        themesPath := c.GetStringDefault("templates:themes", "")
+        doLoadTheme := func(theme string) (t *template.Template) {
+            t = doLoad()
+            files, _ := filepath.Glob(filepath.Join(themesPath, theme, "*.html"))
+            if themesPath != "" && len(files) > 0 {
+                clone, _ := t.Clone()
+                if themed, themeErr := clone.ParseFiles(files...); themeErr == nil {
+                    t = themed
+                }
+            }
+            return
+        }
         if (reload) {
             getTemplates = doLoad
+            getThemeTemplates = doLoadTheme
+            checkTemplates = func() error {
+                _, parseErr := parse()
+                return parseErr
+            }
         } else {
             var templates *template.Template
-            templates = doLoad()
+            var loadErr error
+            loadOnce := sync.Once{}
+            load := func() { templates, loadErr = parse() }
+            checkTemplates = func() error {
+                loadOnce.Do(load)
+                return loadErr
+            }
             getTemplates = func() *template.Template {
+                loadOnce.Do(load)
                 t, _ := templates.Clone()
                 return t
             }
+            themes := map[string]*template.Template {}
+            themesLock := sync.Mutex{}
+            getThemeTemplates = func(theme string) *template.Template {
+                themesLock.Lock()
+                themed, found := themes[theme]
+                if !found {
+                    themed = doLoadTheme(theme)
+                    themes[theme] = themed
+                }
+                themesLock.Unlock()
+                t, _ := themed.Clone()
+                return t
+            }
         }
     })
     return
 }
+
+func CheckTemplates(c config.Configuration) error {
+    if err := LoadTemplates(c); err != nil {
+        return err
+    }
+    return checkTemplates()
+}
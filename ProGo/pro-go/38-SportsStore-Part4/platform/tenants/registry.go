@@ -0,0 +1,138 @@
+package tenants
+
+import (
+    "fmt"
+    "net"
+    "net/http"
+    "platform/config"
+    "platform/sessions"
+    "strings"
+)
+
+const DEFAULT_TENANT = "default"
+
+type Registry struct {
+    tenants []Tenant
+    defaultTenant *Tenant
+}
+
+func NewRegistry(cfg config.Configuration) (*Registry, error) {
+    registry := &Registry{}
+    stores, found := cfg.GetSection("tenants:stores")
+    if !found {
+        registry.tenants = []Tenant {
+            { Name: DEFAULT_TENANT, Default: true, Configuration: cfg },
+        }
+        registry.defaultTenant = &registry.tenants[0]
+        return registry, nil
+    }
+    defaultName := cfg.GetStringDefault("tenants:default", "")
+    for _, name := range stores.Keys() {
+        section, _ := stores.GetSection(name)
+        if section == nil {
+            return nil, fmt.Errorf("Invalid configuration for tenant %v", name)
+        }
+        tenant := Tenant{
+            Name: name,
+            Hosts: splitList(section.GetStringDefault("hosts", "")),
+            PathPrefix: strings.TrimSuffix(section.GetStringDefault("pathPrefix", ""),
+                "/"),
+            Default: name == defaultName,
+        }
+        if tenant.PathPrefix != "" && !strings.HasPrefix(tenant.PathPrefix, "/") {
+            tenant.PathPrefix = "/" + tenant.PathPrefix
+        }
+        tenant.Configuration = cfg
+        if !tenant.Default {
+            tenant.Configuration = config.NewOverlayConfig(cfg, 
+                config.NewMapConfig(map[string]interface{} {
+                    "sessions": map[string]interface{} {
+                        "cookie": fmt.Sprintf("%v_%v", cfg.GetStringDefault(
+                            "sessions:cookie", sessions.SESSION__CONTEXT_KEY), name),
+                    },
+                }))
+        }
+        if overlay, found := section.GetSection("config"); found {
+            tenant.Configuration = config.NewOverlayConfig(tenant.Configuration, 
+                overlay)
+        }
+        registry.tenants = append(registry.tenants, tenant)
+    }
+    for i := range registry.tenants {
+        if registry.tenants[i].Default {
+            registry.defaultTenant = &registry.tenants[i]
+        }
+    }
+    if defaultName != "" && registry.defaultTenant == nil {
+        return nil, fmt.Errorf("Unknown default tenant: %v", defaultName)
+    }
+    return registry, nil
+}
+
+func (r *Registry) Tenants() []Tenant {
+    return append([]Tenant(nil), r.tenants...)
+}
+
+func (r *Registry) Get(name string) (Tenant, bool) {
+    for _, tenant := range r.tenants {
+        if strings.EqualFold(tenant.Name, name) {
+            return tenant, true
+        }
+    }
+    return Tenant{}, false
+}
+
+func (r *Registry) Default() (Tenant, bool) {
+    if r.defaultTenant != nil {
+        return *r.defaultTenant, true
+    }
+    return Tenant{}, false
+}
+
+func (r *Registry) Resolve(req *http.Request) (tenant Tenant, found bool) {
+    host := req.Host
+    if h, _, err := net.SplitHostPort(host); err == nil {
+        host = h
+    }
+    bestScore := 0
+    for _, t := range r.tenants {
+        hostMatch := matchesHost(t.Hosts, host)
+        if (len(t.Hosts) > 0 && !hostMatch) || !matchesPrefix(req.URL.Path, 
+                t.PathPrefix) {
+            continue
+        }
+        score := len(t.PathPrefix) * 2
+        if hostMatch {
+            score++
+        }
+        if score > bestScore {
+            tenant, found, bestScore = t, true, score
+        }
+    }
+    if !found {
+        tenant, found = r.Default()
+    }
+    return
+}
+
+func matchesHost(hosts []string, host string) bool {
+    for _, h := range hosts {
+        if strings.EqualFold(h, host) {
+            return true
+        }
+    }
+    return false
+}
+
+func matchesPrefix(path, prefix string) bool {
+    return prefix == "" || path == prefix || strings.HasPrefix(path, prefix + "/")
+}
+
+func splitList(val string) (items []string) {
+    for _, item := range strings.Split(val, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    return
+}
@@ -0,0 +1,34 @@
+package tenants
+
+import (
+    "context"
+    "platform/config"
+)
+
+const TENANT_CONTEXT_KEY = "tenant"
+
+type Tenant struct {
+    Name string
+    Hosts []string
+    PathPrefix string
+    Default bool
+    config.Configuration
+}
+
+func NewContext(ctx context.Context, tenant Tenant) context.Context {
+    return context.WithValue(ctx, TENANT_CONTEXT_KEY, tenant)
+}
+
+func FromContext(ctx context.Context) (tenant Tenant, found bool) {
+    if ctx != nil {
+        tenant, found = ctx.Value(TENANT_CONTEXT_KEY).(Tenant)
+    }
+    return
+}
+
+func Scope(ctx context.Context, name string) string {
+    if tenant, found := FromContext(ctx); found && !tenant.Default {
+        return tenant.Name + ":" + name
+    }
+    return name
+}
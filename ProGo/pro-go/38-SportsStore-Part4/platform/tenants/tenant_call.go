@@ -0,0 +1,22 @@
+package tenants
+
+import (
+    "context"
+    "fmt"
+    "platform/services"
+)
+
+func (r *Registry) CallForEach(ctx context.Context, target interface{}, 
+        otherArgs ...interface{}) (err error) {
+    for _, tenant := range r.tenants {
+        tenantCtx := services.NewServiceScope(NewContext(ctx, tenant))
+        results, callErr := services.CallForContext(tenantCtx, target, otherArgs...)
+        if callErr == nil && len(results) == 1 {
+            callErr, _ = results[0].(error)
+        }
+        if callErr != nil && err == nil {
+            err = fmt.Errorf("Tenant %v: %w", tenant.Name, callErr)
+        }
+    }
+    return
+}
@@ -0,0 +1,42 @@
+package tenants
+
+import (
+    "net/http"
+    "platform/pipeline"
+    "strings"
+)
+
+type TenantComponent struct {
+    Registry *Registry
+}
+
+func (c *TenantComponent) Init() {}
+
+func (c *TenantComponent) ImplementsProcessRequestWithServices() {}
+
+func (c *TenantComponent) ProcessRequestWithServices(
+        ctx *pipeline.ComponentContext,
+        next func(*pipeline.ComponentContext),
+        registry *Registry) {
+    tenant, found := registry.Resolve(ctx.Request)
+    if !found {
+        ctx.ResponseWriter.WriteHeader(http.StatusNotFound)
+        return
+    }
+    if tenant.PathPrefix != "" {
+        req := ctx.Request.Clone(ctx.Request.Context())
+        req.URL.Path = strings.TrimPrefix(req.URL.Path, tenant.PathPrefix)
+        if req.URL.Path == "" {
+            req.URL.Path = "/"
+        }
+        req.URL.RawPath = ""
+        ctx.Request = req
+    }
+    ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), tenant))
+    next(ctx)
+    if location := ctx.ResponseWriter.Header().Get("Location"); 
+            tenant.PathPrefix != "" && strings.HasPrefix(location, "/") && 
+            !matchesPrefix(location, tenant.PathPrefix) {
+        ctx.ResponseWriter.Header().Set("Location", tenant.PathPrefix + location)
+    }
+}
@@ -0,0 +1,63 @@
+package tenants
+
+import (
+    "context"
+    "platform/config"
+    "platform/http/handling"
+    "platform/services"
+    "platform/templates"
+)
+
+func RegisterTenantServices() {
+    var baseConfig config.Configuration
+    if err := services.GetService(&baseConfig); err != nil {
+        panic(err)
+    }
+    registry, err := NewRegistry(baseConfig)
+    if err != nil {
+        panic(err)
+    }
+    var baseExecutor templates.TemplateExecutor
+    if err := services.GetService(&baseExecutor); err != nil {
+        panic(err)
+    }
+    baseGenerator := handling.NewURLGenerator()
+    err = services.AddSingleton(func() *Registry {
+        return registry
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddScoped(func(ctx context.Context) config.Configuration {
+        if tenant, found := FromContext(ctx); found {
+            return tenant.Configuration
+        }
+        return baseConfig
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddScoped(func(ctx context.Context) templates.TemplateExecutor {
+        if tenant, found := FromContext(ctx); found {
+            if theme, found := tenant.GetString("templates:theme"); found {
+                return &templates.LayoutTemplateProcessor{ Theme: theme, 
+                    ContextFuncs: services.TemplateFuncsForContext(ctx) }
+            }
+        }
+        return &templates.LayoutTemplateProcessor{ 
+            ContextFuncs: services.TemplateFuncsForContext(ctx) }
+    })
+    if (err != nil) {
+        panic(err)
+    }
+    err = services.AddScoped(func(ctx context.Context) handling.URLGenerator {
+        if tenant, found := FromContext(ctx); found && tenant.PathPrefix != "" {
+            return &prefixUrlGenerator{ URLGenerator: baseGenerator, 
+                prefix: tenant.PathPrefix }
+        }
+        return baseGenerator
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
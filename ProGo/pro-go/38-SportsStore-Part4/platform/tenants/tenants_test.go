@@ -0,0 +1,267 @@
+package tenants
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "platform/config"
+    "platform/http/handling"
+    "platform/pipeline"
+    "platform/platformtest"
+    "platform/services"
+    "platform/templates"
+    "testing"
+)
+
+func testConfig() config.Configuration {
+    return platformtest.ConfigValues(map[string]interface{} {
+        "sessions:cookie": "store",
+        "sql:connection_str": "store.db",
+        "tenants:default": "main",
+        "tenants:stores:main:hosts": "shop.example.com, localhost",
+        "tenants:stores:outdoors:pathPrefix": "outdoors/",
+        "tenants:stores:outdoors:config:sql:connection_str": "outdoors.db",
+        "tenants:stores:outdoors:config:templates:theme": "outdoors",
+        "tenants:stores:trade:hosts": "trade.example.com",
+        "tenants:stores:trade:pathPrefix": "/b2b",
+    })
+}
+
+func newTestRegistry(t *testing.T) *Registry {
+    registry, err := NewRegistry(testConfig())
+    if err != nil {
+        t.Fatal(err)
+    }
+    return registry
+}
+
+func TestResolve(t *testing.T) {
+    registry := newTestRegistry(t)
+    tests := []struct { host, path, tenant string } {
+        { "shop.example.com", "/products", "main" },
+        { "shop.example.com:5500", "/outdoors/products", "outdoors" },
+        { "localhost", "/outdoors", "outdoors" },
+        { "localhost", "/outdoorsy", "main" },
+        { "trade.example.com", "/b2b/cart", "trade" },
+        { "TRADE.example.com", "/b2b", "trade" },
+        { "trade.example.com", "/cart", "main" },
+        { "other.example.com", "/b2b/cart", "main" },
+        { "other.example.com", "/", "main" },
+    }
+    for _, test := range tests {
+        req := httptest.NewRequest(http.MethodGet, test.path, nil)
+        req.Host = test.host
+        tenant, found := registry.Resolve(req)
+        if !found || tenant.Name != test.tenant {
+            t.Fatalf("%v%v: expected %v, got %v", test.host, test.path,
+                test.tenant, tenant.Name)
+        }
+    }
+}
+
+func TestRegistryConfig(t *testing.T) {
+    registry := newTestRegistry(t)
+    main, _ := registry.Get("main")
+    outdoors, _ := registry.Get("Outdoors")
+    trade, _ := registry.Get("trade")
+    if tenant, _ := registry.Default(); tenant.Name != "main" || !main.Default {
+        t.Fatalf("Unexpected default tenant: %v", tenant.Name)
+    }
+    if outdoors.PathPrefix != "/outdoors" || len(main.Hosts) != 2 {
+        t.Fatalf("Unexpected tenant settings: %+v %+v", outdoors, main)
+    }
+    tests := []struct { tenant Tenant; key, expected string } {
+        { main, "sql:connection_str", "store.db" },
+        { main, "sessions:cookie", "store" },
+        { outdoors, "sql:connection_str", "outdoors.db" },
+        { outdoors, "templates:theme", "outdoors" },
+        { outdoors, "sessions:cookie", "store_outdoors" },
+        { trade, "sql:connection_str", "store.db" },
+        { trade, "sessions:cookie", "store_trade" },
+    }
+    for _, test := range tests {
+        if val := test.tenant.GetStringDefault(test.key, ""); val != test.expected {
+            t.Fatalf("%v %v: expected %v, got %v", test.tenant.Name, test.key,
+                test.expected, val)
+        }
+    }
+    if _, found := main.GetString("templates:theme"); found {
+        t.Fatal("Expected tenant overlay to be isolated")
+    }
+    if _, found := registry.Get("missing"); found {
+        t.Fatal("Expected unknown tenant not to be found")
+    }
+}
+
+func TestRegistryDefaults(t *testing.T) {
+    cfg := platformtest.ConfigValues(map[string]interface{} { "sql:dialect": "sqlite" })
+    registry, err := NewRegistry(cfg)
+    if err != nil {
+        t.Fatal(err)
+    }
+    tenant, found := registry.Resolve(httptest.NewRequest(http.MethodGet, "/", nil))
+    if !found || tenant.Name != DEFAULT_TENANT || tenant.Configuration != cfg {
+        t.Fatalf("Unexpected tenant: %+v", tenant)
+    }
+    _, err = NewRegistry(platformtest.ConfigValues(map[string]interface{} {
+        "tenants:default": "missing",
+        "tenants:stores:main:hosts": "localhost",
+    }))
+    if err == nil {
+        t.Fatal("Expected unknown default tenant to be rejected")
+    }
+    registry, _ = NewRegistry(platformtest.ConfigValues(map[string]interface{} {
+        "tenants:stores:main:hosts": "localhost",
+    }))
+    req := httptest.NewRequest(http.MethodGet, "/", nil)
+    req.Host = "other.example.com"
+    if _, found := registry.Resolve(req); found {
+        t.Fatal("Expected no tenant without a default")
+    }
+}
+
+type recordingComponent struct {
+    path string
+    tenant Tenant
+    location string
+}
+
+func (c *recordingComponent) Init() {}
+
+func (c *recordingComponent) ProcessRequest(ctx *pipeline.ComponentContext,
+        next func(*pipeline.ComponentContext)) {
+    c.path = ctx.Request.URL.Path
+    c.tenant, _ = FromContext(ctx.Request.Context())
+    ctx.ResponseWriter.Header().Set("Location", c.location)
+    ctx.ResponseWriter.WriteHeader(http.StatusSeeOther)
+}
+
+func TestTenantComponent(t *testing.T) {
+    registry := newTestRegistry(t)
+    recorder := &recordingComponent{}
+    h := platformtest.New(t, testConfig()).
+        Singleton(func() *Registry { return registry }).
+        Build(&TenantComponent{}, recorder)
+    tests := []struct { host, path, location, tenant, stripped, redirect string } {
+        { "localhost", "/products/1", "/cart", "main", "/products/1", "/cart" },
+        { "localhost", "/outdoors/products/1", "/cart", "outdoors", "/products/1",
+            "/outdoors/cart" },
+        { "localhost", "/outdoors", "/", "outdoors", "/", "/outdoors/" },
+        { "localhost", "/outdoors/cart", "/outdoors/signin", "outdoors", "/cart",
+            "/outdoors/signin" },
+        { "localhost", "/outdoors/cart", "https://example.com/x", "outdoors", "/cart",
+            "https://example.com/x" },
+        { "trade.example.com", "/b2b/cart", "/signin", "trade", "/cart",
+            "/b2b/signin" },
+    }
+    for _, test := range tests {
+        recorder.location = test.location
+        req := httptest.NewRequest(http.MethodGet, test.path, nil)
+        req.Host = test.host
+        response := h.NewClient().Do(req)
+        if recorder.tenant.Name != test.tenant || recorder.path != test.stripped {
+            t.Fatalf("%v: expected %v %v, got %v %v", test.path, test.tenant,
+                test.stripped, recorder.tenant.Name, recorder.path)
+        }
+        platformtest.AssertRedirect(t, response, test.redirect)
+    }
+}
+
+type stubGenerator struct {
+    handling.URLGenerator
+}
+
+func (gen stubGenerator) GenerateUrl(method interface{},
+        data ...interface{}) (string, error) {
+    return "/products", nil
+}
+
+func (gen stubGenerator) GenerateURLByName(handlerName, methodName string,
+        data ...interface{}) (string, error) {
+    return "/" + handlerName, nil
+}
+
+func TestTenantServices(t *testing.T) {
+    cfg := testConfig()
+    platformtest.New(t, cfg)
+    services.RegisterDefaultServices()
+    RegisterTenantServices()
+    var registry *Registry
+    if err := services.GetService(&registry); err != nil {
+        t.Fatal(err)
+    }
+    for _, name := range []string { "main", "outdoors" } {
+        tenant, _ := registry.Get(name)
+        ctx := services.NewServiceContext(NewContext(context.Background(), tenant))
+        var tenantConfig config.Configuration
+        var executor templates.TemplateExecutor
+        var generator handling.URLGenerator
+        for _, target := range []interface{} { &tenantConfig, &executor, &generator } {
+            if err := services.GetServiceForContext(ctx, target); err != nil {
+                t.Fatal(err)
+            }
+        }
+        if tenantConfig != tenant.Configuration {
+            t.Fatalf("%v: expected tenant configuration", name)
+        }
+        processor := executor.(*templates.LayoutTemplateProcessor)
+        prefixed, isPrefixed := generator.(*prefixUrlGenerator)
+        if name == "outdoors" && (processor.Theme != "outdoors" || !isPrefixed ||
+                prefixed.prefix != "/outdoors") {
+            t.Fatalf("Expected themed and prefixed services for %v", name)
+        } else if name == "main" && (processor.Theme != "" || isPrefixed) {
+            t.Fatalf("Expected default services for %v", name)
+        }
+    }
+    var baseConfig config.Configuration
+    services.GetServiceForContext(services.NewServiceContext(context.Background()),
+        &baseConfig)
+    if baseConfig != cfg {
+        t.Fatal("Expected base configuration outside a tenant")
+    }
+}
+
+func TestScope(t *testing.T) {
+    registry := newTestRegistry(t)
+    main, _ := registry.Get("main")
+    outdoors, _ := registry.Get("outdoors")
+    if name := Scope(context.Background(), "orders"); name != "orders" {
+        t.Fatalf("Unexpected scope: %v", name)
+    }
+    if name := Scope(NewContext(context.Background(), main), "orders"); name != "orders" {
+        t.Fatalf("Unexpected scope for default tenant: %v", name)
+    }
+    if name := Scope(NewContext(context.Background(), outdoors),
+            "orders"); name != "outdoors:orders" {
+        t.Fatalf("Unexpected scope for tenant: %v", name)
+    }
+}
+
+func TestPrefixUrlGenerator(t *testing.T) {
+    gen := &prefixUrlGenerator{ URLGenerator: stubGenerator{}, prefix: "/outdoors" }
+    if url, err := gen.GenerateUrl(nil); err != nil || url != "/outdoors/products" {
+        t.Fatalf("Unexpected URL: %v %v", url, err)
+    }
+    if url, err := gen.GenerateURLByName("cart", "Get");
+            err != nil || url != "/outdoors/cart" {
+        t.Fatalf("Unexpected URL: %v %v", url, err)
+    }
+}
+
+func TestCallForEach(t *testing.T) {
+    registry := newTestRegistry(t)
+    platformtest.New(t, testConfig())
+    seen := map[string]bool {}
+    err := registry.CallForEach(context.Background(), func(ctx context.Context) error {
+        tenant, _ := FromContext(ctx)
+        seen[tenant.Name] = true
+        if tenant.Name == "trade" {
+            return http.ErrNoCookie
+        }
+        return nil
+    })
+    if len(seen) != 3 || err == nil || err.Error() != "Tenant trade: " +
+            http.ErrNoCookie.Error() {
+        t.Fatalf("Unexpected results: %v %v", seen, err)
+    }
+}
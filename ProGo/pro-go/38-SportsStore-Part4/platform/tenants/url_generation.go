@@ -0,0 +1,27 @@
+package tenants
+
+import (
+    "platform/http/handling"
+)
+
+type prefixUrlGenerator struct {
+    handling.URLGenerator
+    prefix string
+}
+
+func (gen *prefixUrlGenerator) GenerateUrl(method interface{}, 
+        data ...interface{}) (url string, err error) {
+    if url, err = gen.URLGenerator.GenerateUrl(method, data...); err == nil {
+        url = gen.prefix + url
+    }
+    return
+}
+
+func (gen *prefixUrlGenerator) GenerateURLByName(handlerName, methodName string, 
+        data ...interface{}) (url string, err error) {
+    if url, err = gen.URLGenerator.GenerateURLByName(handlerName, methodName, 
+            data...); err == nil {
+        url = gen.prefix + url
+    }
+    return
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func NewDefaultValidator(validators map[string]ValidatorFunc) Validator {
-    return &TagValidator{ DefaultValidators() }
+    return &TagValidator{ validators }
 }
 
 type TagValidator struct {
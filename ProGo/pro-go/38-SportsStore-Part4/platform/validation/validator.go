@@ -1,5 +1,7 @@
 package validation
 
+import "sync"
+
 type Validator interface {
     Validate(data interface{}) (ok bool, errs []ValidationError)
 }
@@ -12,9 +14,27 @@ type ValidationError struct {
 type ValidatorFunc func(fieldName string, value interface{}, 
     arg string) (bool, error)
 
+var customValidators = map[string]ValidatorFunc {}
+var customLock = sync.RWMutex{}
+
+func AddValidator(name string, validator ValidatorFunc) {
+    customLock.Lock()
+    defer customLock.Unlock()
+    customValidators[name] = validator
+}
+
 func DefaultValidators() map[string]ValidatorFunc {
-    return map[string]ValidatorFunc {
+    validators := map[string]ValidatorFunc {
         "required": required,
         "min": min,
+        "max": max,
+        "email": email,
+        "maxlinks": maxlinks,
+    }
+    customLock.RLock()
+    defer customLock.RUnlock()
+    for name, validator := range customValidators {
+        validators[name] = validator
     }
+    return validators
 }
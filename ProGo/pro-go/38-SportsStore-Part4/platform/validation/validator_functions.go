@@ -3,7 +3,10 @@ package validation
 import (
     "errors"
     "fmt"
+    "net/mail"
+    "regexp"
     "strconv"
+    "unicode/utf8"
 )
 
 func required(fieldName string, value interface{}, 
@@ -35,3 +38,53 @@ func min(fieldName string, value interface{}, arg string) (valid bool, err error
     }
     return
 }
+
+func max(fieldName string, value interface{}, arg string) (valid bool, err error) {
+    maxVal, err := strconv.Atoi(arg)
+    if err != nil {
+        panic("Invalid arguments for validator: " + arg)
+    }
+    err = fmt.Errorf("The maximum value is %v", maxVal)
+    if iVal, iValOk := value.(int); iValOk {
+        valid = iVal <= maxVal
+    } else if fVal, fValOk := value.(float64); fValOk {
+        valid = fVal <= float64(maxVal)
+    } else if strVal, strValOk := value.(string); strValOk {
+        err = fmt.Errorf("The maximum length is %v characters", maxVal)
+        valid = utf8.RuneCountInString(strVal) <= maxVal
+    } else {
+        err = errors.New("The max validator is for int, float64, and str values")
+    }
+    return
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)`)
+
+func maxlinks(fieldName string, value interface{}, 
+        arg string) (valid bool, err error) {
+    maxVal, err := strconv.Atoi(arg)
+    if err != nil {
+        panic("Invalid arguments for validator: " + arg)
+    }
+    if str, ok := value.(string); ok {
+        valid = len(linkPattern.FindAllStringIndex(str, -1)) <= maxVal
+        err = fmt.Errorf("No more than %v links are allowed", maxVal)
+        if maxVal == 0 {
+            err = errors.New("Links are not allowed")
+        }
+    } else {
+        err = errors.New("The maxlinks validator is for strings")
+    }
+    return
+}
+
+func email(fieldName string, value interface{}, arg string) (valid bool, err error) {
+    if str, ok := value.(string); ok {
+        addr, parseErr := mail.ParseAddress(str)
+        valid = str == "" || (parseErr == nil && addr.Address == str)
+        err = fmt.Errorf("A valid email address is required")
+    } else {
+        err = errors.New("The email validator is for strings")
+    }
+    return
+}
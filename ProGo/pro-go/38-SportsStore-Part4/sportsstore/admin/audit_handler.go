@@ -0,0 +1,173 @@
+package admin
+
+import (
+    "context"
+    "fmt"
+    "html/template"
+    "io"
+    "net/url"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/templates"
+    "sportsstore/admin/transfer"
+    "sportsstore/models"
+    "sportsstore/store"
+    "strconv"
+    "strings"
+    "time"
+)
+
+type AuditHandler struct {
+    models.AuditRepository
+    handling.URLGenerator
+    templates.TemplateExecutor
+    Context context.Context
+}
+
+func (handler AuditHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type AuditFilterRequest struct {
+    Actor, Action, Entity string
+    EntityID int
+    From, To string
+    Format string
+}
+
+type AuditTemplateContext struct {
+    AuditFilterRequest
+    Entries []models.AuditEntry
+    Error string
+    FilterUrl string
+    ExportUrlFunc func(string) string
+}
+
+type AuditRecord struct {
+    ID int
+    Time string
+    ActorID int
+    ActorName, Action, Entity string
+    EntityID int
+    Changes string
+    Method, Path, RemoteAddr, UserAgent string
+}
+
+const auditDateFormat = "2006-01-02"
+
+func (handler AuditHandler) GetData() actionresults.ActionResult {
+    context, err := handler.auditContext(AuditFilterRequest{})
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewTemplateAction("admin_audit.html", context)
+}
+
+func (handler AuditHandler) GetAuditLog(req AuditFilterRequest) actionresults.ActionResult {
+    context, err := handler.auditContext(req)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    var sb strings.Builder
+    if err := handler.TemplateExecutor.ExecTemplate(&sb, "admin_audit.html", 
+            context); err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    adminContext := newAdminContext(handler.URLGenerator, "Audit")
+    adminContext.Content = template.HTML(sb.String())
+    return actionresults.NewTemplateAction("admin.html", adminContext)
+}
+
+func (handler AuditHandler) GetAuditExport(
+        req AuditFilterRequest) actionresults.ActionResult {
+    format := req.Format
+    if !contains(transfer.Formats, format) {
+        return store.ErrorAction(fmt.Errorf("%w: unsupported format %v", 
+            models.ErrInvalidQuery, format))
+    }
+    filter, err := req.filter()
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    filter.Limit = 100000
+    entries, err := handler.AuditRepository.GetAuditEntries(handler.Context, filter)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    records := make([]AuditRecord, len(entries))
+    for i, e := range entries {
+        records[i] = AuditRecord{ ID: e.ID, Time: e.Time.Format(time.RFC3339), 
+            ActorID: e.ActorID, ActorName: e.ActorName, Action: e.Action, 
+            Entity: e.Entity, EntityID: e.EntityID, Changes: formatChanges(e.Changes),
+            Method: e.Method, Path: e.Path, RemoteAddr: e.RemoteAddr, 
+            UserAgent: e.UserAgent }
+    }
+    return actionresults.NewDownloadAction(fmt.Sprintf("audit.%v", format),
+        transfer.ContentType(format), func(writer io.Writer) error {
+            return transfer.Write(writer, format, records)
+        })
+}
+
+func (handler AuditHandler) auditContext(req AuditFilterRequest) (
+        context AuditTemplateContext, err error) {
+    context = AuditTemplateContext{
+        AuditFilterRequest: req,
+        FilterUrl: mustGenerateUrl(handler.URLGenerator, AuditHandler.GetAuditLog),
+        ExportUrlFunc: func(format string) string {
+            query := req.query()
+            query.Set("format", format)
+            return mustGenerateUrl(handler.URLGenerator, 
+                AuditHandler.GetAuditExport) + "?" + query.Encode()
+        },
+    }
+    filter, filterErr := req.filter()
+    if filterErr != nil {
+        context.Error = filterErr.Error()
+        return
+    }
+    context.Entries, err = handler.AuditRepository.GetAuditEntries(handler.Context, 
+        filter)
+    return
+}
+
+func (req AuditFilterRequest) filter() (filter models.AuditFilter, err error) {
+    filter = models.AuditFilter{ Actor: req.Actor, Action: req.Action, 
+        Entity: req.Entity, EntityID: req.EntityID }
+    if req.From != "" {
+        if filter.From, err = time.Parse(auditDateFormat, req.From); err != nil {
+            return filter, fmt.Errorf("%w: invalid from date %v", 
+                models.ErrInvalidQuery, req.From)
+        }
+    }
+    if req.To != "" {
+        if filter.To, err = time.Parse(auditDateFormat, req.To); err != nil {
+            return filter, fmt.Errorf("%w: invalid to date %v", 
+                models.ErrInvalidQuery, req.To)
+        }
+        filter.To = filter.To.AddDate(0, 0, 1)
+    }
+    return
+}
+
+func (req AuditFilterRequest) query() url.Values {
+    values := url.Values{}
+    for name, val := range map[string]string { "actor": req.Actor, 
+            "action": req.Action, "entity": req.Entity, "from": req.From, 
+            "to": req.To } {
+        if val != "" {
+            values.Set(name, val)
+        }
+    }
+    if req.EntityID != 0 {
+        values.Set("entityid", strconv.Itoa(req.EntityID))
+    }
+    return values
+}
+
+func formatChanges(changes []models.AuditChange) string {
+    parts := make([]string, len(changes))
+    for i, c := range changes {
+        parts[i] = fmt.Sprintf("%v: %v -> %v", c.Field, c.Before, c.After)
+    }
+    return strings.Join(parts, "; ")
+}
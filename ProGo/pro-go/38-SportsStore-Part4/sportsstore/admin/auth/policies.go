@@ -0,0 +1,40 @@
+package auth
+
+import (
+    "platform/authorization"
+    "platform/authorization/identity"
+    "sportsstore/models"
+)
+
+const (
+    ADMIN_ROLE string = "Administrator"
+    FULFILMENT_ROLE string = "Fulfilment"
+)
+
+const (
+    AdminAccessPolicy = "admin:access"
+    AdminManagePolicy = "admin:manage"
+    OrdersViewPolicy = "orders:view"
+    OrdersShipPolicy = "orders:ship"
+)
+
+var staffCondition = authorization.NewRoleCondition(ADMIN_ROLE, FULFILMENT_ROLE)
+
+var Policies = authorization.PolicySet {
+    AdminAccessPolicy: staffCondition,
+    AdminManagePolicy: authorization.NewRoleCondition(ADMIN_ROLE),
+    OrdersViewPolicy: staffCondition,
+    OrdersShipPolicy: authorization.AllOf(staffCondition, 
+        authorization.NewResourceCondition(canChangeShipping)),
+}
+
+func RegisterPolicyServices() {
+    authorization.RegisterAuthorizerService(Policies)
+}
+
+func canChangeShipping(user identity.User, resource interface{}) bool {
+    if order, ok := resource.(models.Order); ok && order.Shipped {
+        return user.InRole(ADMIN_ROLE)
+    }
+    return true
+}
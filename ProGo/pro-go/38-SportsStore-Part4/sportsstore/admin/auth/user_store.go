@@ -1,36 +1,87 @@
 package auth
 
 import (
-    "platform/services"
+    "platform/authorization"
     "platform/authorization/identity"
+    "platform/services"
+    "sportsstore/models"
     "strings"
 )
 
+const CUSTOMER_ROLE string = "Customer"
+
 func RegisterUserStoreService() {
-    err := services.AddSingleton(func () identity.UserStore {
-        return &userStore{}
+    err := services.AddScoped(func (repo models.AccountRepository) identity.UserStore {
+        return &userStore{ AccountRepository: repo }
     })
     if (err != nil) {
         panic(err)
     }
 }
 
-var users = map[int]identity.User {
-    1: identity.NewBasicUser(1, "Alice", "Administrator"),
+type userStore struct {
+    models.AccountRepository
 }
 
-type userStore struct {}
-
 func (store *userStore) GetUserByID(id int) (identity.User, bool) {
-    user, found := users[id]
-    return user, found
+    if account, found := store.GetAccount(id); found {
+        return NewAccountUser(account), true
+    }
+    return nil, false
 }
 
 func (store *userStore) GetUserByName(name string) (identity.User, bool) {
-    for _, user := range users {
-        if strings.EqualFold(user.GetDisplayName(), name) {
-            return user, true
-        }     
+    if account, found := store.GetAccountByName(name); found {
+        return NewAccountUser(account), true
+    }
+    return nil, false
+}
+
+func Authenticate(repo models.AccountRepository, name, 
+        password string) (identity.User, bool) {
+    account, found := repo.GetAccountByName(name)
+    if found && authorization.CheckPassword(account.PasswordHash, password) {
+        return NewAccountUser(account), true
     }
     return nil, false
 }
+
+func NewAccountUser(account models.Account) identity.User {
+    return &accountUser{ Account: account }
+}
+
+type accountUser struct {
+    models.Account
+}
+
+func (user *accountUser) GetID() int {
+    return user.Account.ID
+}
+
+func (user *accountUser) GetDisplayName() string {
+    return user.Account.Name
+}
+
+func (user *accountUser) InRole(role string) bool {
+    for _, r := range user.Account.Roles {
+        if strings.EqualFold(r, role) {
+            return true
+        }
+    }
+    return false
+}
+
+func (user *accountUser) IsAuthenticated() bool {
+    return true
+}
+
+func (user *accountUser) GetClaims() []identity.Claim {
+    claims := []identity.Claim {
+        { Type: "name", Value: user.Account.Name },
+        { Type: "email", Value: user.Account.Email },
+    }
+    for _, r := range user.Account.Roles {
+        claims = append(claims, identity.Claim{ Type: "role", Value: r })
+    }
+    return claims
+}
@@ -5,6 +5,8 @@ import (
 	"platform/http/actionresults"
 	"platform/http/handling"
 	"platform/sessions"
+	"sportsstore/admin/auth"
+	"sportsstore/models"
 )
 
 type AuthenticationHandler struct {
@@ -13,6 +15,7 @@ type AuthenticationHandler struct {
     identity.UserStore
     sessions.Session
     handling.URLGenerator
+    models.AccountRepository
 }
 
 const SIGNIN_MSG_KEY string = "signin_message"
@@ -28,13 +31,12 @@ type Credentials struct {
 }
 
 func (handler AuthenticationHandler) PostSignIn(creds Credentials) actionresults.ActionResult {
-    if creds.Password == "mysecret" {
-        user, ok := handler.UserStore.GetUserByName(creds.Username)
-        if (ok) {
-            handler.Session.SetValue(SIGNIN_MSG_KEY, "")
-            handler.SignInManager.SignIn(user)
-            return actionresults.NewRedirectAction("/admin/section/")
-        }
+    user, ok := auth.Authenticate(handler.AccountRepository, creds.Username, 
+        creds.Password)
+    if ok && auth.Policies[auth.AdminAccessPolicy].Validate(user) {
+        handler.Session.SetValue(SIGNIN_MSG_KEY, "")
+        handler.SignInManager.SignIn(user)
+        return actionresults.NewRedirectAction("/admin/section/")
     } 
     handler.Session.SetValue(SIGNIN_MSG_KEY, "Access Denied")
     return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator, 
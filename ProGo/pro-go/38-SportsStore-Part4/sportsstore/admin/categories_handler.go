@@ -1,16 +1,23 @@
 package admin
 
 import (
+    "context"
     "sportsstore/models"
+    "sportsstore/store"
 	"platform/http/actionresults"
     "platform/http/handling"
     "platform/sessions"
 )
 
 type CategoriesHandler struct {
-    models.Repository
+    models.RepositoryV2
     handling.URLGenerator
     sessions.Session
+    Context context.Context
+}
+
+func (handler CategoriesHandler) Policies() map[string]string {
+    return managePolicies
 }
 
 type CategoryTemplateContext struct {
@@ -23,9 +30,13 @@ type CategoryTemplateContext struct {
 const CATEGORY_EDIT_KEY string = "category_edit"
 
 func (handler CategoriesHandler) GetData() actionresults.ActionResult {
+    categories, err := handler.RepositoryV2.GetCategories(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
     return actionresults.NewTemplateAction("admin_categories.html", 
         CategoryTemplateContext {
-            Categories: handler.Repository.GetCategories(),
+            Categories: categories,
             EditId: handler.Session.GetValueDefault(CATEGORY_EDIT_KEY, 0).(int),
             EditUrl: mustGenerateUrl(handler.URLGenerator, 
                  CategoriesHandler.PostCategoryEdit),
@@ -42,15 +53,21 @@ func (handler CategoriesHandler) PostCategoryEdit(ref EditReference) actionresul
 
 func (handler CategoriesHandler) PostCategorySave(
         c models.Category) actionresults.ActionResult {
-    handler.Repository.SaveCategory(&c)
+    if err := handler.RepositoryV2.SaveCategory(handler.Context, &c); err != nil {
+        return store.ErrorAction(err)
+    }
     handler.Session.SetValue(CATEGORY_EDIT_KEY, 0)
     return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
         AdminHandler.GetSection, "Categories"))
 }
 
 func (handler CategoriesHandler) GetSelect(current int) actionresults.ActionResult {
+    categories, err := handler.RepositoryV2.GetCategories(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
     return actionresults.NewTemplateAction("select_category.html", struct {
         Current int
         Categories []models.Category
-    }{ Current: current, Categories: handler.GetCategories()})
+    }{ Current: current, Categories: categories})
 }
@@ -1,35 +1,146 @@
 package admin
 
 import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "platform/cache"
+    "platform/config"
     "platform/http/actionresults"
     "platform/http/handling"
+    "platform/sessions"
+    "sportsstore/audit"
     "sportsstore/models"
+    "sportsstore/models/repo"
+    "sportsstore/store"
 )
 
 type DatabaseHandler struct {
-    models.Repository
+    models.RepositoryV2
     handling.URLGenerator
+    sessions.Session
+    Migrator *repo.Migrator
+    Cache *repo.RepositoryCache
+    Audit audit.AuditLogger
+    config.Configuration
+    Context context.Context
 }
 
+func (handler DatabaseHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+const MIGRATION_MSG_KEY = "migration_msg"
+
 func (handler DatabaseHandler) GetData() actionresults.ActionResult {
+    status, err := handler.Migrator.Status(handler.Context)
+    if err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    message := handler.Session.GetValueDefault(MIGRATION_MSG_KEY, "").(string)
+    if message != "" {
+        handler.Session.SetValue(MIGRATION_MSG_KEY, "")
+    }
+    var cacheStats *cache.Stats
+    if handler.Cache != nil {
+        stats := handler.Cache.Stats()
+        cacheStats = &stats
+    }
     return actionresults.NewTemplateAction("admin_database.html", struct {
-        InitUrl, SeedUrl string
+        InitUrl, SeedUrl, MigrateUrl, RollbackUrl, ClearCacheUrl string
+        Migrations []repo.MigrationStatus
+        Message string
+        CacheStats *cache.Stats
+        AllowReset bool
     }{
         InitUrl: mustGenerateUrl(handler.URLGenerator, 
             DatabaseHandler.PostDatabaseInit),
         SeedUrl: mustGenerateUrl(handler.URLGenerator,  
            DatabaseHandler.PostDatabaseSeed),
+        MigrateUrl: mustGenerateUrl(handler.URLGenerator,  
+           DatabaseHandler.PostMigrate),
+        RollbackUrl: mustGenerateUrl(handler.URLGenerator,  
+           DatabaseHandler.PostRollback),
+        ClearCacheUrl: mustGenerateUrl(handler.URLGenerator,  
+           DatabaseHandler.PostClearCache),
+        Migrations: status,
+        Message: message,
+        CacheStats: cacheStats,
+        AllowReset: handler.allowReset(),
     })
 }
 
 func (handler DatabaseHandler) PostDatabaseInit() actionresults.ActionResult {
-    handler.Repository.Init()
+    if !handler.allowReset() {
+        return resetDisabledAction()
+    }
+    if err := handler.RepositoryV2.Init(handler.Context); err != nil {
+        return store.ErrorAction(err)
+    }
     return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
         AdminHandler.GetSection, "Database"))
 }
 
 func (handler DatabaseHandler) PostDatabaseSeed() actionresults.ActionResult {
-    handler.Repository.Seed()
+    if !handler.allowReset() {
+        return resetDisabledAction()
+    }
+    if err := handler.RepositoryV2.Seed(handler.Context); err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        AdminHandler.GetSection, "Database"))
+}
+
+func (handler DatabaseHandler) PostMigrate() actionresults.ActionResult {
+    count, err := handler.Migrator.Up(handler.Context)
+    if count > 0 {
+        if auditErr := handler.Audit.Record(audit.ActionMigrate, "database", 0, nil, 
+                struct{ Applied int }{ count }); err == nil {
+            err = auditErr
+        }
+    }
+    handler.setMigrationMessage("Applied", count, err)
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        AdminHandler.GetSection, "Database"))
+}
+
+func (handler DatabaseHandler) PostRollback() actionresults.ActionResult {
+    count, err := handler.Migrator.Down(handler.Context, 1)
+    if count > 0 {
+        if auditErr := handler.Audit.Record(audit.ActionRollback, "database", 0, nil, 
+                struct{ RolledBack int }{ count }); err == nil {
+            err = auditErr
+        }
+    }
+    handler.setMigrationMessage("Rolled back", count, err)
     return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
         AdminHandler.GetSection, "Database"))
 }
+
+func (handler DatabaseHandler) PostClearCache() actionresults.ActionResult {
+    if handler.Cache != nil {
+        handler.Cache.Clear()
+    }
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        AdminHandler.GetSection, "Database"))
+}
+
+func (handler DatabaseHandler) setMigrationMessage(verb string, count int, 
+        err error) {
+    message := fmt.Sprintf("%v %v migration(s)", verb, count)
+    if err != nil {
+        message = fmt.Sprintf("%v: %v", message, err.Error())
+    }
+    handler.Session.SetValue(MIGRATION_MSG_KEY, message)
+}
+
+func (handler DatabaseHandler) allowReset() bool {
+    return handler.Configuration.GetBoolDefault("admin:database:allowReset", true)
+}
+
+func resetDisabledAction() actionresults.ActionResult {
+    return actionresults.NewStatusErrorAction(http.StatusForbidden, 
+        errors.New("Database initialization is disabled, use the seed command"))
+}
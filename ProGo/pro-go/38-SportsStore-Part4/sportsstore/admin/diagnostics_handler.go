@@ -0,0 +1,119 @@
+package admin
+
+import (
+    "context"
+    "fmt"
+    "platform/config"
+    "platform/health"
+    "platform/http/actionresults"
+    "runtime"
+    "runtime/debug"
+    "sort"
+    "strings"
+    "time"
+)
+
+var processStarted = time.Now()
+
+const redactedValue = "********"
+
+type DiagnosticsHandler struct {
+    config.Configuration
+    Monitor *health.Monitor
+    Context context.Context
+}
+
+func (handler DiagnosticsHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type ConfigEntry struct {
+    Key, Value string
+}
+
+type BuildDetails struct {
+    Path, Version, GoVersion, Platform string
+    Settings []ConfigEntry
+}
+
+type RuntimeDetails struct {
+    Goroutines, CPUs int
+    HeapAlloc, HeapSys uint64
+    GCCount uint32
+    Uptime time.Duration
+}
+
+func (handler DiagnosticsHandler) GetData() actionresults.ActionResult {
+    return actionresults.NewTemplateAction("admin_diagnostics.html", struct {
+        Health health.Report
+        Ready bool
+        Build BuildDetails
+        Runtime RuntimeDetails
+        Config []ConfigEntry
+    }{
+        Health: handler.Monitor.Run(handler.Context, health.Readiness),
+        Ready: handler.Monitor.Ready(),
+        Build: buildDetails(),
+        Runtime: runtimeDetails(),
+        Config: redactedConfig(handler.Configuration),
+    })
+}
+
+func buildDetails() (details BuildDetails) {
+    details.GoVersion = runtime.Version()
+    details.Platform = runtime.GOOS + "/" + runtime.GOARCH
+    if info, ok := debug.ReadBuildInfo(); ok {
+        details.Path = info.Main.Path
+        details.Version = info.Main.Version
+        for _, setting := range info.Settings {
+            details.Settings = append(details.Settings,
+                ConfigEntry{ setting.Key, setting.Value })
+        }
+    }
+    return
+}
+
+func runtimeDetails() RuntimeDetails {
+    stats := runtime.MemStats{}
+    runtime.ReadMemStats(&stats)
+    return RuntimeDetails{
+        Goroutines: runtime.NumGoroutine(),
+        CPUs: runtime.NumCPU(),
+        HeapAlloc: stats.HeapAlloc,
+        HeapSys: stats.HeapSys,
+        GCCount: stats.NumGC,
+        Uptime: time.Since(processStarted).Round(time.Second),
+    }
+}
+
+func redactedConfig(cfg config.Configuration) (entries []ConfigEntry) {
+    secrets := strings.Split(cfg.GetStringDefault("admin:diagnostics:redact",
+        "key,password,secret,token,connection_str"), ",")
+    for key, val := range config.Flatten(cfg) {
+        value := formatConfigValue(val)
+        if isSecretKey(key, secrets) {
+            value = redactedValue
+        }
+        entries = append(entries, ConfigEntry{ key, value })
+    }
+    sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+    return
+}
+
+func formatConfigValue(val interface{}) string {
+    if number, ok := val.(float64); ok && number == float64(int64(number)) {
+        return fmt.Sprint(int64(number))
+    }
+    return fmt.Sprint(val)
+}
+
+func isSecretKey(key string, secrets []string) bool {
+    lower := strings.ToLower(key)
+    for _, secret := range secrets {
+        if secret = strings.TrimSpace(secret); secret != "" &&
+                strings.Contains(lower, strings.ToLower(secret)) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,82 @@
+package admin
+
+import (
+    "context"
+    "fmt"
+    "platform/features"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "sportsstore/audit"
+    "sportsstore/models"
+    "sportsstore/store"
+)
+
+type FeaturesHandler struct {
+    Store features.FlagStore
+    Features features.FeatureFlags
+    Audit audit.AuditLogger
+    handling.URLGenerator
+    Context context.Context
+}
+
+func (handler FeaturesHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type FeatureReference struct {
+    Name string
+    Enabled bool
+    Percentage int
+    KeyBy string
+}
+
+type FeatureRow struct {
+    features.Flag
+    ActiveForYou bool
+}
+
+func (handler FeaturesHandler) GetData() actionresults.ActionResult {
+    flags, err := handler.Store.GetFlags(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    rows := make([]FeatureRow, len(flags))
+    for i, flag := range flags {
+        rows[i] = FeatureRow{ Flag: flag, 
+            ActiveForYou: handler.Features.IsEnabled(flag.Name) }
+    }
+    return actionresults.NewTemplateAction("admin_features.html", struct {
+        Flags []FeatureRow
+        SaveUrl string
+        KeyOptions []string
+    }{
+        Flags: rows,
+        SaveUrl: mustGenerateUrl(handler.URLGenerator, FeaturesHandler.PostFeature),
+        KeyOptions: []string { features.KeyBySession, features.KeyByUser },
+    })
+}
+
+func (handler FeaturesHandler) PostFeature(
+        ref FeatureReference) actionresults.ActionResult {
+    flags, err := handler.Store.GetFlags(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    for _, before := range flags {
+        if before.Name == ref.Name {
+            flag := before
+            flag.Enabled, flag.Percentage, flag.KeyBy = ref.Enabled, 
+                ref.Percentage, ref.KeyBy
+            if err := handler.Store.SaveFlag(handler.Context, flag); err != nil {
+                return store.ErrorAction(err)
+            }
+            if err := handler.Audit.Record(audit.ActionUpdate, "feature:" + flag.Name, 
+                    0, before, flag.Normalize()); err != nil {
+                return store.ErrorAction(err)
+            }
+            return actionresults.NewRedirectAction(mustGenerateUrl(
+                handler.URLGenerator, AdminHandler.GetSection, "Features"))
+        }
+    }
+    return store.ErrorAction(fmt.Errorf("%w: %v", models.ErrNotFound, ref.Name))
+}
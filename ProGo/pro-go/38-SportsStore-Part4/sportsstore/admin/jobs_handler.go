@@ -0,0 +1,66 @@
+package admin
+
+import (
+    "context"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/jobs"
+    "sportsstore/store"
+)
+
+type JobsHandler struct {
+    Scheduler *jobs.Scheduler
+    handling.URLGenerator
+    Context context.Context
+}
+
+func (handler JobsHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type JobRunRequest struct {
+    Name string
+}
+
+func (handler JobsHandler) GetData() actionresults.ActionResult {
+    counts, err := handler.Scheduler.Queue.GetJobCounts(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    recent, err := handler.Scheduler.Queue.GetJobs(handler.Context, 
+        jobs.JobFilter{ Limit: 50 })
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewTemplateAction("admin_jobs.html", struct {
+        Status jobs.SchedulerStatus
+        Statuses []string
+        Counts map[string]int
+        Jobs []jobs.Job
+        RunUrl, RetryUrl string
+    }{
+        Status: handler.Scheduler.Status(),
+        Statuses: []string { jobs.StatusPending, jobs.StatusRunning, 
+            jobs.StatusSucceeded, jobs.StatusFailed },
+        Counts: counts,
+        Jobs: recent,
+        RunUrl: mustGenerateUrl(handler.URLGenerator, JobsHandler.PostRunJob),
+        RetryUrl: mustGenerateUrl(handler.URLGenerator, JobsHandler.PostRetryJob),
+    })
+}
+
+func (handler JobsHandler) PostRunJob(req JobRunRequest) actionresults.ActionResult {
+    if _, err := handler.Scheduler.Enqueue(handler.Context, req.Name, nil); err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        AdminHandler.GetSection, "Jobs"))
+}
+
+func (handler JobsHandler) PostRetryJob(ref EditReference) actionresults.ActionResult {
+    if err := handler.Scheduler.Retry(handler.Context, ref.ID); err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        AdminHandler.GetSection, "Jobs"))
+}
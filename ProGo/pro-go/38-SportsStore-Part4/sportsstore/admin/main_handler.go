@@ -1,11 +1,39 @@
 package admin
 
 import (
+    "html/template"
+    "platform/authorization"
     "platform/http/actionresults"
     "platform/http/handling"
+    "platform/scaffold"
+    "sportsstore/admin/auth"
 )
 
-var sectionNames = []string { "Products", "Categories", "Orders", "Database"}
+var sectionNames = []string { "Products", "Categories", "Orders", "Database", 
+    "Transfer", "Audit", "Jobs", "Reports", "Shipping", "Rates", "Taxes", "Promotions", 
+    "Reviews", "Features", "Diagnostics"}
+
+var sectionPolicies = map[string]string {
+    "Products": auth.AdminManagePolicy,
+    "Categories": auth.AdminManagePolicy,
+    "Orders": auth.OrdersViewPolicy,
+    "Database": auth.AdminManagePolicy,
+    "Transfer": auth.AdminManagePolicy,
+    "Audit": auth.AdminManagePolicy,
+    "Jobs": auth.AdminManagePolicy,
+    "Reports": auth.AdminManagePolicy,
+    "Shipping": auth.AdminManagePolicy,
+    "Rates": auth.AdminManagePolicy,
+    "Taxes": auth.AdminManagePolicy,
+    "Promotions": auth.AdminManagePolicy,
+    "Reviews": auth.AdminManagePolicy,
+    "Features": auth.AdminManagePolicy,
+    "Diagnostics": auth.AdminManagePolicy,
+}
+
+var managePolicies = map[string]string {
+    authorization.DefaultActionPolicy: auth.AdminManagePolicy,
+}
 
 type AdminHandler struct {
     handling.URLGenerator
@@ -13,17 +41,35 @@ type AdminHandler struct {
 
 type AdminTemplateContext struct {
     Sections []string
+    SectionPolicies map[string]string
     ActiveSection string
     SectionUrlFunc func(string) string
+    Content template.HTML
 }
 
 func (handler AdminHandler) GetSection(section string) actionresults.ActionResult {
-    return actionresults.NewTemplateAction("admin.html", AdminTemplateContext {
+    if model, found := scaffoldSections[section]; found {
+        return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+            scaffold.ScaffoldHandler.GetList, model))
+    }
+    return actionresults.NewTemplateAction("admin.html", 
+        newAdminContext(handler.URLGenerator, section))
+}
+
+func newAdminContext(generator handling.URLGenerator, 
+        section string) AdminTemplateContext {
+    return AdminTemplateContext {
         Sections: sectionNames,
+        SectionPolicies: sectionPolicies,
         ActiveSection: section,
         SectionUrlFunc: func(sec string) string {
-            sectionUrl, _ := handler.GenerateUrl(AdminHandler.GetSection, sec)
+            if model, found := scaffoldSections[sec]; found {
+                listUrl, _ := generator.GenerateUrl(scaffold.ScaffoldHandler.GetList, 
+                    model)
+                return listUrl
+            }
+            sectionUrl, _ := generator.GenerateUrl(AdminHandler.GetSection, sec)
             return sectionUrl
         },
-    })
+    }
 }
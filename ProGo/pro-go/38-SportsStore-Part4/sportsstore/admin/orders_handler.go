@@ -1,31 +1,119 @@
 package admin
 
 import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"platform/authorization"
+	"platform/config"
 	"platform/http/actionresults"
 	"platform/http/handling"
+	"platform/pubsub"
+	"platform/tenants"
+	"platform/templates"
+	"sportsstore/admin/auth"
 	"sportsstore/models"
+	"sportsstore/store"
+	"strings"
+	"time"
 )
 
 type OrdersHandler struct {
-    models.Repository
+    models.RepositoryV2
     handling.URLGenerator
+    templates.TemplateExecutor
+    pubsub.Broker
+    config.Configuration
+    authorization.Authorizer
+    Context context.Context
+}
+
+func (handler OrdersHandler) Policies() map[string]string {
+    return map[string]string {
+        authorization.DefaultActionPolicy: auth.OrdersViewPolicy,
+        "PostOrderToggle": auth.OrdersShipPolicy,
+    }
+}
+
+type OrderRowContext struct {
+    models.Order
+    CallbackUrl string
 }
 
 func (handler OrdersHandler) GetData() actionresults.ActionResult {
+    orders, err := handler.RepositoryV2.GetOrders(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    callbackUrl := mustGenerateUrl(handler.URLGenerator, 
+        OrdersHandler.PostOrderToggle)
+    rows := make([]OrderRowContext, len(orders))
+    for i, order := range orders {
+        rows[i] = OrderRowContext{ Order: order, CallbackUrl: callbackUrl }
+    }
     return actionresults.NewTemplateAction("admin_orders.html", struct {
-        Orders []models.Order
-         CallbackUrl string
+        Orders []OrderRowContext
+        FeedUrl string
     }{
-        Orders: handler.Repository.GetOrders(), 
-        CallbackUrl: mustGenerateUrl(handler.URLGenerator, 
-            OrdersHandler.PostOrderToggle),
+        Orders: rows, 
+        FeedUrl: mustGenerateUrl(handler.URLGenerator, OrdersHandler.GetOrderFeed),
     })
 }
 
+func (handler OrdersHandler) GetOrderFeed() actionresults.ActionResult {
+    heartbeat, err := time.ParseDuration(
+        handler.Configuration.GetStringDefault("orders:feed:heartbeat", "15s"))
+    if err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    callbackUrl := mustGenerateUrl(handler.URLGenerator, 
+        OrdersHandler.PostOrderToggle)
+    return actionresults.NewEventStreamAction(heartbeat, 
+        func(ctx context.Context, send actionresults.EventSender) error {
+            subscription := handler.Broker.Subscribe(
+                tenants.Scope(handler.Context, models.OrderEventsTopic))
+            defer subscription.Close()
+            for {
+                select {
+                    case <-ctx.Done():
+                        return nil
+                    case msg, ok := <-subscription.Messages:
+                        if !ok {
+                            return nil
+                        }
+                        event := msg.Payload.(models.OrderEvent)
+                        var sb strings.Builder
+                        if err := handler.TemplateExecutor.ExecTemplate(&sb, 
+                                "admin_order_row.html", OrderRowContext{ 
+                                    Order: event.Order, 
+                                    CallbackUrl: callbackUrl }); err != nil {
+                            return err
+                        }
+                        if err := send(actionresults.Event{ 
+                                ID: fmt.Sprint(event.Order.ID), Name: event.Kind, 
+                                Data: template.HTML(sb.String()) }); err != nil {
+                            return err
+                        }
+                }
+            }
+        })
+}
+
 func (handler OrdersHandler) PostOrderToggle(ref EditReference) actionresults.ActionResult {
-    order := handler.Repository.GetOrder(ref.ID)
-    order.Shipped = !order.Shipped
-    handler.Repository.SetOrderShipped(&order)
+    order, err := handler.RepositoryV2.GetOrder(handler.Context, ref.ID)
+    if err == nil && !handler.Authorizer.AuthorizeResource(auth.OrdersShipPolicy, 
+            order) {
+        return actionresults.NewStatusErrorAction(http.StatusForbidden, 
+            fmt.Errorf("Shipping status of order %v cannot be changed", order.ID))
+    }
+    if err == nil {
+        order.Shipped = !order.Shipped
+        err = handler.RepositoryV2.SetOrderShipped(handler.Context, &order)
+    }
+    if err != nil {
+        return store.ErrorAction(err)
+    }
     return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
         AdminHandler.GetSection, "Orders"))
 }
@@ -1,16 +1,31 @@
 package admin
 
 import (
+    "context"
+    "fmt"
+    "mime/multipart"
     "sportsstore/models"
+    "sportsstore/store"
+    "platform/config"
     "platform/http/actionresults"
     "platform/http/handling"
+    "platform/media"
     "platform/sessions"
+    "strconv"
+    "time"
 )
 
 type ProductsHandler struct {
-    models.Repository
+    models.RepositoryV2
     handling.URLGenerator
     sessions.Session
+    media.MediaStore
+    config.Configuration
+    Context context.Context
+}
+
+func (handler ProductsHandler) Policies() map[string]string {
+    return managePolicies
 }
 
 type ProductTemplateContext struct {
@@ -18,19 +33,35 @@ type ProductTemplateContext struct {
     EditId int
     EditUrl string 
     SaveUrl string
+    ImageError string
+    ImageUrlFunc func(string) string
 }
 
 const PRODUCT_EDIT_KEY string = "product_edit"
+const PRODUCT_IMAGE_ERROR_KEY string = "product_image_error"
 
 func (handler ProductsHandler) GetData() actionresults.ActionResult {
+    imageError := handler.Session.GetValueDefault(PRODUCT_IMAGE_ERROR_KEY, "").(string)
+    if imageError != "" {
+        handler.Session.SetValue(PRODUCT_IMAGE_ERROR_KEY, "")
+    }
+    products, err := handler.RepositoryV2.GetProducts(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    widths := media.ImageWidths(handler.Configuration)
     return actionresults.NewTemplateAction("admin_products.html", 
             ProductTemplateContext {
-        Products: handler.GetProducts(),
+        Products: products,
         EditId: handler.Session.GetValueDefault(PRODUCT_EDIT_KEY, 0).(int),
         EditUrl: mustGenerateUrl(handler.URLGenerator, 
              ProductsHandler.PostProductEdit),
         SaveUrl: mustGenerateUrl(handler.URLGenerator, 
              ProductsHandler.PostProductSave),
+        ImageError: imageError,
+        ImageUrlFunc: func(image string) string {
+            return handler.MediaStore.URL(media.VariantName(image, widths[0]))
+        },
     })
 }
 
@@ -49,21 +80,62 @@ type ProductSaveReference struct {
     Name, Description string
     Category int
     Price float64
+    Weight float64
+    Image *multipart.FileHeader
 }
 
 func (handler ProductsHandler) PostProductSave(
         p ProductSaveReference) actionresults.ActionResult {
 
-    handler.Repository.SaveProduct(&models.Product{
+    product := &models.Product{
         ID: p.Id, Name: p.Name, Description: p.Description,
         Category: &models.Category{ ID: p.Category },
-        Price: p.Price,
-    })
+        Price: p.Price, Weight: p.Weight,
+    }
+    if err := handler.RepositoryV2.SaveProduct(handler.Context, product); err != nil {
+        return store.ErrorAction(err)
+    }
     handler.Session.SetValue(PRODUCT_EDIT_KEY, 0)
+    if p.Image != nil {
+        if err := handler.saveImage(product.ID, p.Image); err != nil {
+            handler.Session.SetValue(PRODUCT_IMAGE_ERROR_KEY, err.Error())
+            handler.Session.SetValue(PRODUCT_EDIT_KEY, product.ID)
+        }
+    }
     return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
         AdminHandler.GetSection, "Products"))
 }
 
+func (handler ProductsHandler) saveImage(productId int, 
+        header *multipart.FileHeader) error {
+    upload, err := media.ReadImageUpload(header, 
+        int64(handler.Configuration.GetIntDefault("media:maxSize", 5 << 20)),
+        handler.Configuration.GetIntDefault("media:maxDimension", 4096))
+    if err != nil {
+        return err
+    }
+    widths := media.ImageWidths(handler.Configuration)
+    name := fmt.Sprintf("products/%v-%v%v", productId, 
+        strconv.FormatInt(time.Now().UnixNano(), 36), upload.Extension)
+    if err = media.SaveImageVariants(handler.MediaStore, name, upload, 
+            widths); err != nil {
+        media.DeleteImageVariants(handler.MediaStore, name, widths)
+        return err
+    }
+    current, err := handler.RepositoryV2.GetProduct(handler.Context, productId)
+    if err == nil {
+        err = handler.RepositoryV2.SetProductImage(handler.Context, productId, name)
+    }
+    if err != nil {
+        media.DeleteImageVariants(handler.MediaStore, name, widths)
+        return err
+    }
+    if previous := current.Image; previous != "" {
+        media.DeleteImageVariants(handler.MediaStore, previous, widths)
+    }
+    return nil
+}
+
 func mustGenerateUrl(gen handling.URLGenerator, target interface{}, 
         data ...interface{}) string {
     url, err := gen.GenerateUrl(target, data...) 
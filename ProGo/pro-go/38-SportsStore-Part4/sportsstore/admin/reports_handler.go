@@ -0,0 +1,262 @@
+package admin
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "html/template"
+    "io"
+    "net/url"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/templates"
+    "sportsstore/admin/transfer"
+    "sportsstore/models"
+    "sportsstore/reports"
+    "sportsstore/store"
+    "strings"
+    "time"
+)
+
+type ReportsHandler struct {
+    Reports models.ReportRepository
+    models.RepositoryV2
+    handling.URLGenerator
+    templates.TemplateExecutor
+    Context context.Context
+}
+
+func (handler ReportsHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type ReportRequest struct {
+    From, To string
+    Period string
+    Kind string
+    Format string
+    Categories []int `form:"category"`
+}
+
+type ReportTemplateContext struct {
+    ReportRequest
+    Periods []string
+    CategoryList []models.Category
+    Report reports.SalesReport
+    Chart reports.BarChart
+    Error string
+    FilterUrl string
+    ExportUrlFunc func(string) string
+}
+
+type RevenueRecord struct {
+    Period string
+    Start string
+    Orders, Units int
+    Revenue float64
+}
+
+type RankedRecord struct {
+    ID int
+    Name string
+    Orders, Units int
+    Revenue float64
+    Share float64
+}
+
+type RegionRecord struct {
+    Country, State string
+    Orders, Units int
+    Revenue float64
+    Share float64
+}
+
+var reportKinds = []string { "revenue", "products", "categories", "regions" }
+
+const (
+    reportDateFormat = "2006-01-02"
+    reportDefaultDays = 30
+    reportTopItems = 10
+)
+
+func (handler ReportsHandler) GetData() actionresults.ActionResult {
+    context, err := handler.reportContext(ReportRequest{})
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewTemplateAction("admin_reports.html", context)
+}
+
+func (handler ReportsHandler) GetSalesReport(req ReportRequest) actionresults.ActionResult {
+    context, err := handler.reportContext(req)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    var sb strings.Builder
+    if err := handler.TemplateExecutor.ExecTemplate(&sb, "admin_reports.html", 
+            context); err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    adminContext := newAdminContext(handler.URLGenerator, "Reports")
+    adminContext.Content = template.HTML(sb.String())
+    return actionresults.NewTemplateAction("admin.html", adminContext)
+}
+
+func (handler ReportsHandler) GetSalesExport(req ReportRequest) actionresults.ActionResult {
+    if !contains(transfer.Formats, req.Format) {
+        return store.ErrorAction(fmt.Errorf("%w: unsupported format %v", 
+            models.ErrInvalidQuery, req.Format))
+    }
+    if !contains(reportKinds, req.Kind) {
+        return store.ErrorAction(fmt.Errorf("%w: unsupported report %v", 
+            models.ErrInvalidQuery, req.Kind))
+    }
+    report, err := handler.buildReport(req)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    var records interface{}
+    switch req.Kind {
+        case "revenue":
+            revenue := make([]RevenueRecord, len(report.Buckets))
+            for i, b := range report.Buckets {
+                revenue[i] = RevenueRecord{ Period: b.Label, 
+                    Start: b.Start.Format(reportDateFormat), Orders: b.Orders, 
+                    Units: b.Units, Revenue: roundCents(b.Revenue) }
+            }
+            records = revenue
+        case "products", "categories":
+            items := report.TopProducts
+            if req.Kind == "categories" {
+                items = report.TopCategories
+            }
+            ranked := make([]RankedRecord, len(items))
+            for i, item := range items {
+                ranked[i] = RankedRecord{ ID: item.ID, Name: item.Name, 
+                    Orders: item.Orders, Units: item.Units, 
+                    Revenue: roundCents(item.Revenue), Share: roundCents(item.Share) }
+            }
+            records = ranked
+        case "regions":
+            regions := make([]RegionRecord, len(report.Regions))
+            for i, r := range report.Regions {
+                regions[i] = RegionRecord{ Country: r.Country, State: r.State, 
+                    Orders: r.Orders, Units: r.Units, Revenue: roundCents(r.Revenue), 
+                    Share: roundCents(r.Share) }
+            }
+            records = regions
+    }
+    fileName := fmt.Sprintf("sales-%v-%v-%v.%v", req.Kind, 
+        report.From.Format(reportDateFormat), 
+        report.To.AddDate(0, 0, -1).Format(reportDateFormat), req.Format)
+    return actionresults.NewDownloadAction(fileName, transfer.ContentType(req.Format), 
+        func(writer io.Writer) error {
+            return transfer.Write(writer, req.Format, records)
+        })
+}
+
+func (handler ReportsHandler) reportContext(req ReportRequest) (
+        context ReportTemplateContext, err error) {
+    context = ReportTemplateContext{
+        Periods: reports.Periods,
+        FilterUrl: mustGenerateUrl(handler.URLGenerator, ReportsHandler.GetSalesReport),
+    }
+    if context.CategoryList, err = handler.RepositoryV2.GetCategories(
+            handler.Context); err != nil {
+        return
+    }
+    report, err := handler.buildReport(req)
+    if errors.Is(err, models.ErrInvalidQuery) {
+        context.ReportRequest, context.Error, err = req, err.Error(), nil
+        return
+    } else if err != nil {
+        return
+    }
+    req.From = report.From.Format(reportDateFormat)
+    req.To = report.To.AddDate(0, 0, -1).Format(reportDateFormat)
+    req.Period = report.Period
+    context.ReportRequest, context.Report = req, report
+    context.Chart = report.RevenueChart()
+    context.ExportUrlFunc = func(kind string) string {
+        query := url.Values{}
+        query.Set("from", req.From)
+        query.Set("to", req.To)
+        query.Set("period", req.Period)
+        query.Set("kind", kind)
+        query.Set("format", transfer.FormatCSV)
+        for _, id := range req.Categories {
+            query.Add("category", fmt.Sprint(id))
+        }
+        return mustGenerateUrl(handler.URLGenerator, 
+            ReportsHandler.GetSalesExport) + "?" + query.Encode()
+    }
+    return
+}
+
+func (handler ReportsHandler) buildReport(req ReportRequest) (reports.SalesReport, 
+        error) {
+    from, to, err := req.dateRange()
+    if err != nil {
+        return reports.SalesReport{}, err
+    }
+    period := req.Period
+    if period == "" {
+        period = reports.ByDay
+    } else if !reports.ValidPeriod(period) {
+        return reports.SalesReport{}, fmt.Errorf("%w: invalid period %v", 
+            models.ErrInvalidQuery, period)
+    }
+    lines, err := handler.Reports.GetSalesLines(handler.Context, from, to)
+    if err != nil {
+        return reports.SalesReport{}, err
+    }
+    if len(req.Categories) > 0 {
+        filtered := []models.SalesLine {}
+        for _, line := range lines {
+            if req.HasCategory(line.CategoryID) {
+                filtered = append(filtered, line)
+            }
+        }
+        lines = filtered
+    }
+    return reports.BuildSalesReport(lines, from, to, period, reportTopItems), nil
+}
+
+func (req ReportRequest) HasCategory(id int) bool {
+    for _, c := range req.Categories {
+        if c == id {
+            return true
+        }
+    }
+    return false
+}
+
+func (req ReportRequest) dateRange() (from, to time.Time, err error) {
+    now := time.Now()
+    to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).
+        AddDate(0, 0, 1)
+    if req.To != "" {
+        if to, err = time.ParseInLocation(reportDateFormat, req.To, time.Local); err != nil {
+            return from, to, fmt.Errorf("%w: invalid to date %v", 
+                models.ErrInvalidQuery, req.To)
+        }
+        to = to.AddDate(0, 0, 1)
+    }
+    from = to.AddDate(0, 0, -reportDefaultDays)
+    if req.From != "" {
+        if from, err = time.ParseInLocation(reportDateFormat, req.From, 
+                time.Local); err != nil {
+            return from, to, fmt.Errorf("%w: invalid from date %v", 
+                models.ErrInvalidQuery, req.From)
+        }
+    }
+    if !from.Before(to) {
+        err = fmt.Errorf("%w: the from date must not be after the to date", 
+            models.ErrInvalidQuery)
+    }
+    return
+}
+
+func roundCents(value float64) float64 {
+    return float64(int64(value * 100 + 0.5)) / 100
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+    "context"
+    "fmt"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "sportsstore/audit"
+    "sportsstore/models"
+    "sportsstore/store"
+)
+
+type ReviewsHandler struct {
+    Repository models.RepositoryV2
+    Reviews models.ReviewRepository
+    Audit audit.AuditLogger
+    handling.URLGenerator
+    Context context.Context
+}
+
+func (handler ReviewsHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type ReviewStatusReference struct {
+    ID int
+    Status string
+}
+
+type ReviewRow struct {
+    models.Review
+    ProductName string
+}
+
+func (handler ReviewsHandler) GetData() actionresults.ActionResult {
+    pending, err := handler.Reviews.GetReviews(handler.Context, models.ReviewPending)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    products, err := handler.Repository.GetProducts(handler.Context)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    names := map[int]string {}
+    for _, p := range products {
+        names[p.ID] = p.Name
+    }
+    rows := make([]ReviewRow, len(pending))
+    for i, review := range pending {
+        rows[i] = ReviewRow{ Review: review, ProductName: names[review.ProductID] }
+    }
+    return actionresults.NewTemplateAction("admin_reviews.html", struct {
+        Reviews []ReviewRow
+        StatusUrl string
+        Approved, Rejected string
+    }{
+        Reviews: rows,
+        StatusUrl: mustGenerateUrl(handler.URLGenerator, 
+            ReviewsHandler.PostReviewStatus),
+        Approved: models.ReviewApproved,
+        Rejected: models.ReviewRejected,
+    })
+}
+
+func (handler ReviewsHandler) PostReviewStatus(
+        ref ReviewStatusReference) actionresults.ActionResult {
+    if ref.Status != models.ReviewApproved && ref.Status != models.ReviewRejected {
+        return store.ErrorAction(fmt.Errorf("%w: unknown review status %v", 
+            models.ErrInvalidQuery, ref.Status))
+    }
+    before, err := handler.Reviews.GetReview(handler.Context, ref.ID)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    if err := handler.Reviews.SetReviewStatus(handler.Context, ref.ID, 
+            ref.Status); err != nil {
+        return store.ErrorAction(err)
+    }
+    after := before
+    after.Status = ref.Status
+    if err := handler.Audit.Record(audit.ActionModerate, "review", ref.ID, before, 
+            after); err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        AdminHandler.GetSection, "Reviews"))
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+    "context"
+    "html/template"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/scaffold"
+    "platform/services"
+    "sportsstore/admin/auth"
+    "sportsstore/models"
+    "sportsstore/store"
+)
+
+var scaffoldSections = map[string]string {
+    "Shipping": "shipping",
+    "Promotions": "promotions",
+    "Rates": "shippingrates",
+    "Taxes": "taxrates",
+}
+
+func RegisterScaffoldServices() {
+    scaffold.RegisterModels(
+        scaffold.NewModel("shipping", models.ShippingMethod{}, 
+            func(repo models.ShippingRepository) scaffold.Store {
+                return shippingStore{ repo }
+            }).WithTitle("Shipping").WithPolicy(auth.AdminManagePolicy).
+            WithErrorAction(store.ErrorAction),
+        scaffold.NewModel("promotions", models.Promotion{}, 
+            func(repo models.PromotionRepository) scaffold.Store {
+                return promotionStore{ repo }
+            }).WithTitle("Promotions").WithPolicy(auth.AdminManagePolicy).
+            WithErrorAction(store.ErrorAction),
+        scaffold.NewModel("shippingrates", models.ShippingRate{}, 
+            func(repo models.PricingRepository) scaffold.Store {
+                return shippingRateStore{ repo }
+            }).WithTitle("Shipping Rates").WithPolicy(auth.AdminManagePolicy).
+            WithErrorAction(store.ErrorAction),
+        scaffold.NewModel("taxrates", models.TaxRate{}, 
+            func(repo models.PricingRepository) scaffold.Store {
+                return taxRateStore{ repo }
+            }).WithTitle("Tax Rates").WithPolicy(auth.AdminManagePolicy).
+            WithErrorAction(store.ErrorAction),
+    )
+    err := services.AddScoped(func(generator handling.URLGenerator) scaffold.PageRenderer {
+        return adminPageRenderer{ generator }
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
+
+type adminPageRenderer struct {
+    handling.URLGenerator
+}
+
+func (renderer adminPageRenderer) RenderPage(model *scaffold.Model, 
+        content template.HTML) actionresults.ActionResult {
+    context := newAdminContext(renderer.URLGenerator, model.Title)
+    context.Content = content
+    return actionresults.NewTemplateAction("admin.html", context)
+}
+
+type shippingStore struct {
+    models.ShippingRepository
+}
+
+func (s shippingStore) GetAll(ctx context.Context) (interface{}, error) {
+    return s.GetShippingMethods(ctx)
+}
+
+func (s shippingStore) Get(ctx context.Context, id int) (interface{}, error) {
+    return s.GetShippingMethod(ctx, id)
+}
+
+func (s shippingStore) Save(ctx context.Context, item interface{}) error {
+    return s.SaveShippingMethod(ctx, item.(*models.ShippingMethod))
+}
+
+func (s shippingStore) Delete(ctx context.Context, id int) error {
+    return s.DeleteShippingMethod(ctx, id)
+}
+
+type promotionStore struct {
+    models.PromotionRepository
+}
+
+func (s promotionStore) GetAll(ctx context.Context) (interface{}, error) {
+    return s.GetPromotions(ctx)
+}
+
+func (s promotionStore) Get(ctx context.Context, id int) (interface{}, error) {
+    return s.GetPromotion(ctx, id)
+}
+
+func (s promotionStore) Save(ctx context.Context, item interface{}) error {
+    return s.SavePromotion(ctx, item.(*models.Promotion))
+}
+
+func (s promotionStore) Delete(ctx context.Context, id int) error {
+    return s.DeletePromotion(ctx, id)
+}
+
+type shippingRateStore struct {
+    models.PricingRepository
+}
+
+func (s shippingRateStore) GetAll(ctx context.Context) (interface{}, error) {
+    return s.GetShippingRates(ctx)
+}
+
+func (s shippingRateStore) Get(ctx context.Context, id int) (interface{}, error) {
+    return s.GetShippingRate(ctx, id)
+}
+
+func (s shippingRateStore) Save(ctx context.Context, item interface{}) error {
+    return s.SaveShippingRate(ctx, item.(*models.ShippingRate))
+}
+
+func (s shippingRateStore) Delete(ctx context.Context, id int) error {
+    return s.DeleteShippingRate(ctx, id)
+}
+
+type taxRateStore struct {
+    models.PricingRepository
+}
+
+func (s taxRateStore) GetAll(ctx context.Context) (interface{}, error) {
+    return s.GetTaxRates(ctx)
+}
+
+func (s taxRateStore) Get(ctx context.Context, id int) (interface{}, error) {
+    return s.GetTaxRate(ctx, id)
+}
+
+func (s taxRateStore) Save(ctx context.Context, item interface{}) error {
+    return s.SaveTaxRate(ctx, item.(*models.TaxRate))
+}
+
+func (s taxRateStore) Delete(ctx context.Context, id int) error {
+    return s.DeleteTaxRate(ctx, id)
+}
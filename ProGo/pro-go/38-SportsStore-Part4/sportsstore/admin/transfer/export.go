@@ -0,0 +1,32 @@
+package transfer
+
+import (
+    "context"
+    "fmt"
+    "sportsstore/models"
+)
+
+var Entities = []string { "products", "categories", "orders" }
+
+var Formats = []string { FormatCSV, FormatJSON }
+
+func Export(ctx context.Context, repo models.RepositoryV2, 
+        entity string) (records interface{}, err error) {
+    switch entity {
+        case "products":
+            var products []models.Product
+            products, err = repo.GetProducts(ctx)
+            records = ProductRecords(products)
+        case "categories":
+            var categories []models.Category
+            categories, err = repo.GetCategories(ctx)
+            records = CategoryRecords(categories)
+        case "orders":
+            var orders []models.Order
+            orders, err = repo.GetOrders(ctx)
+            records = OrderRecords(orders)
+        default:
+            err = fmt.Errorf("%w: unknown export %v", models.ErrNotFound, entity)
+    }
+    return
+}
@@ -0,0 +1,181 @@
+package transfer
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "reflect"
+    "strconv"
+    "strings"
+)
+
+const (
+    FormatCSV = "csv"
+    FormatJSON = "json"
+)
+
+func ContentType(format string) string {
+    if format == FormatJSON {
+        return "application/json"
+    }
+    return "text/csv"
+}
+
+func Write(writer io.Writer, format string, records interface{}) error {
+    switch format {
+        case FormatJSON:
+            encoder := json.NewEncoder(writer)
+            encoder.SetIndent("", "  ")
+            return encoder.Encode(records)
+        case FormatCSV:
+            return writeCSV(writer, reflect.ValueOf(records))
+        default:
+            return fmt.Errorf("Unsupported format: %v", format)
+    }
+}
+
+func writeCSV(writer io.Writer, records reflect.Value) error {
+    csvWriter := csv.NewWriter(writer)
+    recordType := records.Type().Elem()
+    header := make([]string, recordType.NumField())
+    for i := range header {
+        header[i] = recordType.Field(i).Name
+    }
+    csvWriter.Write(header)
+    for i := 0; i < records.Len(); i++ {
+        record := records.Index(i)
+        line := make([]string, record.NumField())
+        for j := range line {
+            line[j] = formatValue(record.Field(j))
+        }
+        csvWriter.Write(line)
+    }
+    csvWriter.Flush()
+    return csvWriter.Error()
+}
+
+const formulaPrefixes = "=+-@\t\r"
+
+func formatValue(val reflect.Value) string {
+    switch val.Kind() {
+        case reflect.Float64:
+            return strconv.FormatFloat(val.Float(), 'f', -1, 64)
+        case reflect.String:
+            return escapeFormula(val.String())
+        default:
+            return fmt.Sprint(val.Interface())
+    }
+}
+
+func escapeFormula(val string) string {
+    if val != "" && strings.ContainsRune(formulaPrefixes, rune(val[0])) {
+        return "'" + val
+    }
+    return val
+}
+
+func unescapeFormula(val string) string {
+    if len(val) > 1 && val[0] == '\'' && 
+            strings.ContainsRune(formulaPrefixes, rune(val[1])) {
+        return val[1:]
+    }
+    return val
+}
+
+type rowHandler func(row int, record reflect.Value, err error)
+
+func read(reader io.Reader, format string, recordType reflect.Type, 
+        handler rowHandler) error {
+    switch format {
+        case FormatJSON:
+            return readJSON(reader, recordType, handler)
+        case FormatCSV:
+            return readCSV(reader, recordType, handler)
+        default:
+            return fmt.Errorf("Unsupported format: %v", format)
+    }
+}
+
+func readJSON(reader io.Reader, recordType reflect.Type, handler rowHandler) error {
+    raw := []json.RawMessage {}
+    if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+        return fmt.Errorf("The file is not a JSON array: %v", err)
+    }
+    for i, data := range raw {
+        record := reflect.New(recordType)
+        err := json.Unmarshal(data, record.Interface())
+        handler(i + 1, record.Elem(), err)
+    }
+    return nil
+}
+
+func readCSV(reader io.Reader, recordType reflect.Type, handler rowHandler) error {
+    csvReader := csv.NewReader(reader)
+    csvReader.FieldsPerRecord = -1
+    csvReader.TrimLeadingSpace = true
+    header, err := csvReader.Read()
+    if err == io.EOF {
+        return errors.New("The file is empty")
+    } else if err != nil {
+        return err
+    }
+    columns := make([]int, len(header))
+    for i, name := range header {
+        columns[i] = -1
+        for j := 0; j < recordType.NumField(); j++ {
+            if strings.EqualFold(strings.TrimSpace(name), recordType.Field(j).Name) {
+                columns[i] = j
+            }
+        }
+    }
+    for row := 2; ; row++ {
+        line, err := csvReader.Read()
+        if err == io.EOF {
+            return nil
+        }
+        record := reflect.New(recordType).Elem()
+        if err == nil {
+            for i, val := range line {
+                if i < len(columns) && columns[i] >= 0 {
+                    if fieldErr := setValue(record.Field(columns[i]), 
+                            unescapeFormula(strings.TrimSpace(val))); 
+                            fieldErr != nil && err == nil {
+                        err = fmt.Errorf("%v: %v", header[i], fieldErr)
+                    }
+                }
+            }
+        }
+        handler(row, record, err)
+    }
+}
+
+func setValue(field reflect.Value, val string) error {
+    if val == "" {
+        return nil
+    }
+    switch field.Kind() {
+        case reflect.String:
+            field.SetString(val)
+        case reflect.Int:
+            iVal, err := strconv.Atoi(val)
+            if err != nil {
+                return errors.New("A whole number is required")
+            }
+            field.SetInt(int64(iVal))
+        case reflect.Float64:
+            fVal, err := strconv.ParseFloat(strings.TrimPrefix(val, "$"), 64)
+            if err != nil {
+                return errors.New("A number is required")
+            }
+            field.SetFloat(fVal)
+        case reflect.Bool:
+            bVal, err := strconv.ParseBool(val)
+            if err != nil {
+                return errors.New("A true or false value is required")
+            }
+            field.SetBool(bVal)
+    }
+    return nil
+}
@@ -0,0 +1,67 @@
+package transfer
+
+import (
+    "fmt"
+    "io"
+    "platform/validation"
+    "reflect"
+    "sportsstore/models"
+    "strings"
+)
+
+func ReadCategories(reader io.Reader, format string, 
+        validator validation.Validator) (rows []models.ImportCategory, 
+            invalid []models.ImportRow, err error) {
+    err = read(reader, format, reflect.TypeOf(CategoryRecord{}), 
+        func(row int, val reflect.Value, rowErr error) {
+            record := val.Interface().(CategoryRecord)
+            if rowErr == nil {
+                rowErr = validateRecord(validator, &record)
+            }
+            if rowErr == nil {
+                rows = append(rows, record.toImport(row))
+            } else {
+                invalid = append(invalid, invalidRow(row, record.ID, record.Name, 
+                    rowErr))
+            }
+        })
+    return
+}
+
+func ReadProducts(reader io.Reader, format string, 
+        validator validation.Validator) (rows []models.ImportProduct, 
+            invalid []models.ImportRow, err error) {
+    err = read(reader, format, reflect.TypeOf(ProductRecord{}), 
+        func(row int, val reflect.Value, rowErr error) {
+            record := val.Interface().(ProductRecord)
+            if rowErr == nil {
+                rowErr = validateRecord(validator, &record)
+            }
+            if rowErr == nil && record.CategoryID == 0 && record.Category == "" {
+                rowErr = fmt.Errorf("Category: A category ID or name is required")
+            }
+            if rowErr == nil {
+                rows = append(rows, record.toImport(row))
+            } else {
+                invalid = append(invalid, invalidRow(row, record.ID, record.Name, 
+                    rowErr))
+            }
+        })
+    return
+}
+
+func validateRecord(validator validation.Validator, record interface{}) error {
+    if ok, errs := validator.Validate(record); !ok {
+        messages := make([]string, len(errs))
+        for i, e := range errs {
+            messages[i] = fmt.Sprintf("%v: %v", e.FieldName, e.Error)
+        }
+        return fmt.Errorf("%v", strings.Join(messages, ", "))
+    }
+    return nil
+}
+
+func invalidRow(row, id int, name string, err error) models.ImportRow {
+    return models.ImportRow{ Row: row, Action: models.ImportInvalid, ID: id, 
+        Name: name, Error: err.Error() }
+}
@@ -0,0 +1,85 @@
+package transfer
+
+import (
+    "sportsstore/models"
+    "time"
+)
+
+type CategoryRecord struct {
+    ID int
+    Name string `validation:"required"`
+}
+
+type ProductRecord struct {
+    ID int
+    Name string `validation:"required"`
+    Description string
+    CategoryID int
+    Category string
+    Price float64 `validation:"min:0"`
+}
+
+type OrderRecord struct {
+    OrderID int
+    Name, Email, StreetAddr, City, State, Zip, Country string
+    Shipped bool
+    Created string
+    ProductID int
+    Product string
+    Quantity int
+    Price float64
+}
+
+func CategoryRecords(categories []models.Category) []CategoryRecord {
+    records := make([]CategoryRecord, len(categories))
+    for i, c := range categories {
+        records[i] = CategoryRecord{ ID: c.ID, Name: c.CategoryName }
+    }
+    return records
+}
+
+func ProductRecords(products []models.Product) []ProductRecord {
+    records := make([]ProductRecord, len(products))
+    for i, p := range products {
+        records[i] = ProductRecord{ ID: p.ID, Name: p.Name, 
+            Description: p.Description, Price: p.Price }
+        if p.Category != nil {
+            records[i].CategoryID = p.Category.ID
+            records[i].Category = p.Category.CategoryName
+        }
+    }
+    return records
+}
+
+func OrderRecords(orders []models.Order) []OrderRecord {
+    records := []OrderRecord {}
+    for _, o := range orders {
+        order := OrderRecord{
+            OrderID: o.ID, Name: o.Name, Email: o.Email, StreetAddr: o.StreetAddr, 
+            City: o.City, State: o.State, Zip: o.Zip, Country: o.Country, 
+            Shipped: o.Shipped, Created: o.Created.Format(time.RFC3339), 
+        }
+        if len(o.Products) == 0 {
+            records = append(records, order)
+        }
+        for _, sel := range o.Products {
+            record := order
+            record.ProductID, record.Product = sel.Product.ID, sel.Product.Name
+            record.Quantity, record.Price = sel.Quantity, sel.Product.Price
+            records = append(records, record)
+        }
+    }
+    return records
+}
+
+func (r CategoryRecord) toImport(row int) models.ImportCategory {
+    return models.ImportCategory{ Row: row, 
+        Category: models.Category{ ID: r.ID, CategoryName: r.Name }}
+}
+
+func (r ProductRecord) toImport(row int) models.ImportProduct {
+    return models.ImportProduct{ Row: row, Product: models.Product{
+        ID: r.ID, Name: r.Name, Description: r.Description, Price: r.Price,
+        Category: &models.Category{ ID: r.CategoryID, CategoryName: r.Category },
+    }}
+}
@@ -0,0 +1,115 @@
+package transfer
+
+import (
+    "bytes"
+    "platform/validation"
+    "sportsstore/models"
+    "strings"
+    "testing"
+    "time"
+)
+
+var testProducts = []models.Product {
+    { ID: 1, Name: "Kayak", Description: "A boat, for one person", Price: 275,
+        Category: &models.Category{ ID: 1, CategoryName: "Watersports" } },
+    { ID: 2, Name: "=HYPERLINK(\"http://evil\")", Description: "-2+3", Price: 48.95,
+        Category: &models.Category{ ID: 1, CategoryName: "@Watersports" } },
+}
+
+func newValidator() validation.Validator {
+    return validation.NewDefaultValidator(validation.DefaultValidators())
+}
+
+func TestProductRoundTrip(t *testing.T) {
+    firstRows := map[string]int { FormatCSV: 2, FormatJSON: 1 }
+    for _, format := range Formats {
+        var buffer bytes.Buffer
+        if err := Write(&buffer, format, ProductRecords(testProducts)); err != nil {
+            t.Fatal(err)
+        }
+        rows, invalid, err := ReadProducts(&buffer, format, newValidator())
+        if err != nil || len(invalid) != 0 || len(rows) != len(testProducts) {
+            t.Fatalf("%v: unexpected import: %v %v %v", format, rows, invalid, err)
+        }
+        for i, row := range rows {
+            p := testProducts[i]
+            if row.Row != firstRows[format] + i || 
+                    row.ID != p.ID || row.Name != p.Name || 
+                    row.Description != p.Description || row.Price != p.Price ||
+                    row.Category.ID != p.Category.ID || 
+                    row.Category.CategoryName != p.Category.CategoryName {
+                t.Fatalf("%v: product did not round trip: %+v", format, row)
+            }
+        }
+    }
+}
+
+func TestCategoryRoundTrip(t *testing.T) {
+    categories := []models.Category { { ID: 1, CategoryName: "Watersports" }, 
+        { ID: 3, CategoryName: "Chess" } }
+    for _, format := range Formats {
+        var buffer bytes.Buffer
+        if err := Write(&buffer, format, CategoryRecords(categories)); err != nil {
+            t.Fatal(err)
+        }
+        rows, invalid, err := ReadCategories(&buffer, format, newValidator())
+        if err != nil || len(invalid) != 0 || len(rows) != 2 || rows[1].ID != 3 ||
+                rows[1].CategoryName != "Chess" {
+            t.Fatalf("%v: unexpected import: %v %v %v", format, rows, invalid, err)
+        }
+    }
+}
+
+func TestCSVFormulaEscaping(t *testing.T) {
+    var buffer bytes.Buffer
+    if err := Write(&buffer, FormatCSV, ProductRecords(testProducts)); err != nil {
+        t.Fatal(err)
+    }
+    lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+    if len(lines) != 3 || !strings.HasPrefix(lines[2], 
+            `2,"'=HYPERLINK(""http://evil"")",'-2+3,1,'@Watersports,48.95`) {
+        t.Fatalf("Expected formula values to be escaped: %v", lines)
+    }
+    if strings.Contains(lines[1], "'") {
+        t.Fatalf("Unexpected escaping of plain values: %v", lines[1])
+    }
+}
+
+func TestReadProductsInvalidRows(t *testing.T) {
+    data := "ID,Name,Price,CategoryID\n1,Kayak,abc,1\n2,,10,1\n3,Ball,10,\n4,Cap,16,3\n"
+    rows, invalid, err := ReadProducts(strings.NewReader(data), FormatCSV, 
+        newValidator())
+    if err != nil || len(rows) != 1 || rows[0].ID != 4 || len(invalid) != 3 {
+        t.Fatalf("Unexpected import: %v %v %v", rows, invalid, err)
+    }
+    for i, row := range invalid {
+        if row.Row != i + 2 || row.Action != models.ImportInvalid || row.Error == "" {
+            t.Fatalf("Unexpected invalid row: %+v", row)
+        }
+    }
+    if _, _, err := ReadProducts(strings.NewReader(""), FormatCSV, 
+            newValidator()); err == nil {
+        t.Fatal("Expected error for empty file")
+    }
+    if _, _, err := ReadProducts(strings.NewReader("{}"), FormatJSON, 
+            newValidator()); err == nil {
+        t.Fatal("Expected error for JSON object")
+    }
+}
+
+func TestOrderRecords(t *testing.T) {
+    created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+    records := OrderRecords([]models.Order {
+        { ID: 1, ShippingDetails: models.ShippingDetails{ Name: "Alice" }, 
+            Created: created, Products: []models.ProductSelection {
+                { Quantity: 2, Product: testProducts[0] },
+                { Quantity: 1, Product: testProducts[1] } } },
+        { ID: 2, ShippingDetails: models.ShippingDetails{ Name: "Bob" }, 
+            Created: created },
+    })
+    if len(records) != 3 || records[1].Quantity != 1 || records[1].Name != "Alice" ||
+            records[2].OrderID != 2 || records[2].ProductID != 0 || 
+            records[2].Created != "2026-03-01T12:00:00Z" {
+        t.Fatalf("Unexpected order records: %+v", records)
+    }
+}
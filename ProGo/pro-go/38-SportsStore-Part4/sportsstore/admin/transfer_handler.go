@@ -0,0 +1,184 @@
+package admin
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "html/template"
+    "io"
+    "mime/multipart"
+    "path/filepath"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/templates"
+    "platform/validation"
+    "sort"
+    "sportsstore/admin/transfer"
+    "sportsstore/models"
+    "sportsstore/store"
+    "strings"
+)
+
+const maxImportSize = 4 * 1024 * 1024
+
+var importEntities = []string { "products", "categories" }
+
+type TransferHandler struct {
+    models.RepositoryV2
+    models.BulkRepository
+    handling.URLGenerator
+    validation.Validator
+    templates.TemplateExecutor
+    Context context.Context
+}
+
+func (handler TransferHandler) Policies() map[string]string {
+    return managePolicies
+}
+
+type TransferTemplateContext struct {
+    Entities, ImportEntities, Formats []string
+    ExportUrlFunc func(string, string) string
+    ImportUrl string
+}
+
+func (handler TransferHandler) GetData() actionresults.ActionResult {
+    return actionresults.NewTemplateAction("admin_transfer.html", 
+            TransferTemplateContext{
+        Entities: transfer.Entities,
+        ImportEntities: importEntities,
+        Formats: transfer.Formats,
+        ExportUrlFunc: func(entity, format string) string {
+            return mustGenerateUrl(handler.URLGenerator, 
+                TransferHandler.GetExport, entity, format)
+        },
+        ImportUrl: mustGenerateUrl(handler.URLGenerator, 
+            TransferHandler.PostImport),
+    })
+}
+
+func (handler TransferHandler) GetExport(entity, 
+        format string) actionresults.ActionResult {
+    if !contains(transfer.Formats, format) {
+        return actionresults.NewErrorAction(
+            fmt.Errorf("Unsupported format: %v", format))
+    }
+    records, err := transfer.Export(handler.Context, handler.RepositoryV2, entity)
+    if err != nil {
+        return store.ErrorAction(err)
+    }
+    return actionresults.NewDownloadAction(fmt.Sprintf("%v.%v", entity, format),
+        transfer.ContentType(format), func(writer io.Writer) error {
+            return transfer.Write(writer, format, records)
+        })
+}
+
+type ImportRequest struct {
+    Entity, Format string
+    File *multipart.FileHeader
+    Content string
+    DryRun bool
+}
+
+type ImportTemplateContext struct {
+    ImportRequest
+    Summary models.ImportSummary
+    Error string
+    ImportUrl, BackUrl string
+}
+
+func (handler TransferHandler) PostImport(req ImportRequest) actionresults.ActionResult {
+    context := ImportTemplateContext{
+        ImportRequest: req,
+        ImportUrl: mustGenerateUrl(handler.URLGenerator, TransferHandler.PostImport),
+        BackUrl: mustGenerateUrl(handler.URLGenerator, 
+            AdminHandler.GetSection, "Transfer"),
+    }
+    if req.File != nil {
+        content, err := readImportFile(req.File)
+        if err != nil {
+            context.Error = err.Error()
+        }
+        context.Content = content
+        if req.Format == "" {
+            req.Format = strings.TrimPrefix(
+                strings.ToLower(filepath.Ext(req.File.Filename)), ".")
+            context.Format = req.Format
+        }
+    }
+    if context.Error == "" {
+        context.Summary, context.Error = handler.runImport(req.Entity, req.Format, 
+            context.Content, req.DryRun)
+    }
+    var sb strings.Builder
+    if err := handler.TemplateExecutor.ExecTemplate(&sb, 
+            "admin_transfer_result.html", context); err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    adminContext := newAdminContext(handler.URLGenerator, "Transfer")
+    adminContext.Content = template.HTML(sb.String())
+    return actionresults.NewTemplateAction("admin.html", adminContext)
+}
+
+func (handler TransferHandler) runImport(entity, format, content string, 
+        dryRun bool) (summary models.ImportSummary, errMsg string) {
+    if strings.TrimSpace(content) == "" {
+        return summary, "No data was supplied"
+    } else if !contains(transfer.Formats, format) {
+        return summary, fmt.Sprintf("Unsupported format: %v", format)
+    }
+    var invalid []models.ImportRow
+    var err error
+    switch entity {
+        case "categories":
+            var rows []models.ImportCategory
+            rows, invalid, err = transfer.ReadCategories(strings.NewReader(content), 
+                format, handler.Validator)
+            if err == nil {
+                summary = handler.BulkRepository.ImportCategories(rows, 
+                    dryRun || len(invalid) > 0)
+            }
+        case "products":
+            var rows []models.ImportProduct
+            rows, invalid, err = transfer.ReadProducts(strings.NewReader(content), 
+                format, handler.Validator)
+            if err == nil {
+                summary = handler.BulkRepository.ImportProducts(rows, 
+                    dryRun || len(invalid) > 0)
+            }
+        default:
+            err = fmt.Errorf("Unknown import: %v", entity)
+    }
+    if err != nil {
+        return summary, err.Error()
+    }
+    for _, row := range invalid {
+        summary.Add(row)
+    }
+    sort.SliceStable(summary.Rows, func(i, j int) bool {
+        return summary.Rows[i].Row < summary.Rows[j].Row
+    })
+    return
+}
+
+func readImportFile(header *multipart.FileHeader) (string, error) {
+    if header.Size > maxImportSize {
+        return "", errors.New("The file is too large")
+    }
+    file, err := header.Open()
+    if err != nil {
+        return "", err
+    }
+    defer file.Close()
+    data, err := io.ReadAll(file)
+    return string(data), err
+}
+
+func contains(values []string, val string) bool {
+    for _, v := range values {
+        if v == val {
+            return true
+        }
+    }
+    return false
+}
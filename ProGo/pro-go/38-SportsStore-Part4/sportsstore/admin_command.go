@@ -0,0 +1,66 @@
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "platform/authorization"
+    "platform/validation"
+    "sportsstore/admin/auth"
+    "sportsstore/models"
+)
+
+const ADMIN_PASSWORD_ENV = "SPORTSSTORE_ADMIN_PASSWORD"
+
+type adminAccount struct {
+    Name string `validation:"required"`
+    Email string `validation:"email"`
+    Password string `validation:"required,min:8"`
+}
+
+func runCreateAdminCommand(args []string, accounts models.AccountRepository,
+        validator validation.Validator) error {
+    flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+    details := adminAccount{}
+    flags.StringVar(&details.Name, "name", "", "The account name")
+    flags.StringVar(&details.Email, "email", "", "The email address")
+    flags.StringVar(&details.Password, "password", os.Getenv(ADMIN_PASSWORD_ENV), 
+        "The password, defaults to " + ADMIN_PASSWORD_ENV)
+    if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+        return usageError("create-admin")
+    }
+    if valid, errs := validator.Validate(details); !valid {
+        return fmt.Errorf("%v: %v", errs[0].FieldName, errs[0].Error)
+    }
+    hash, err := authorization.HashPassword(details.Password)
+    if err != nil {
+        return err
+    }
+    account, found := accounts.GetAccountByName(details.Name)
+    if found && details.Email == "" {
+        details.Email = account.Email
+    }
+    account.Name, account.Email, account.PasswordHash = details.Name, 
+        details.Email, hash
+    if !hasRole(account.Roles, auth.ADMIN_ROLE) {
+        account.Roles = append(account.Roles, auth.ADMIN_ROLE)
+    }
+    if err := accounts.SaveAccount(&account); err != nil {
+        return fmt.Errorf("The account could not be saved: %w", err)
+    }
+    if found {
+        fmt.Printf("Updated administrator account %v (%v)\n", account.Name, account.ID)
+    } else {
+        fmt.Printf("Created administrator account %v (%v)\n", account.Name, account.ID)
+    }
+    return nil
+}
+
+func hasRole(roles []string, role string) bool {
+    for _, r := range roles {
+        if r == role {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+    "context"
+    "platform/authorization/identity"
+    "platform/logging"
+    "platform/services"
+    "sportsstore/models"
+)
+
+func RegisterAuditService() {
+    services.AddScoped(func (ctx context.Context, user identity.User,
+            repo models.AuditRepository, logger logging.Logger) AuditLogger {
+        return &SqlAuditLogger{ Context: ctx, User: user, Repository: repo, 
+            Logger: logger }
+    })
+    services.Decorate(func (repo models.RepositoryV2, 
+            logger AuditLogger) models.RepositoryV2 {
+        return &AuditingRepository{ RepositoryV2: repo, Audit: logger }
+    })
+    services.Decorate(func (repo models.ShippingRepository, 
+            logger AuditLogger) models.ShippingRepository {
+        return &AuditingShippingRepository{ ShippingRepository: repo, Audit: logger }
+    })
+    services.Decorate(func (repo models.PromotionRepository, 
+            logger AuditLogger) models.PromotionRepository {
+        return &AuditingPromotionRepository{ PromotionRepository: repo, 
+            Audit: logger }
+    })
+    services.Decorate(func (repo models.PricingRepository, 
+            logger AuditLogger) models.PricingRepository {
+        return &AuditingPricingRepository{ PricingRepository: repo, Audit: logger }
+    })
+    services.Decorate(func (bulk models.BulkRepository, ctx context.Context,
+            repo models.RepositoryV2, logger AuditLogger) models.BulkRepository {
+        return &AuditingBulkRepository{ BulkRepository: bulk, Repository: repo, 
+            Audit: logger, Context: ctx }
+    })
+}
@@ -0,0 +1,258 @@
+package audit
+
+import (
+    "context"
+    "errors"
+    "net/http/httptest"
+    "platform/authorization/identity"
+    "platform/config"
+    "platform/logging"
+    "platform/pipeline"
+    "sportsstore/models"
+    "testing"
+    "time"
+)
+
+func TestDiff(t *testing.T) {
+    created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+    before := models.Product{ ID: 1, Name: "Kayak", Price: 275,
+        Category: &models.Category{ ID: 1, CategoryName: "Watersports" }}
+    after := before
+    after.Price, after.Category = 299.5, &models.Category{ ID: 2, 
+        CategoryName: "Boats" }
+    changes := Diff(before, after)
+    expected := map[string]models.AuditChange {
+        "Price": { Field: "Price", Before: "275", After: "299.5" },
+        "Category.ID": { Field: "Category.ID", Before: "1", After: "2" },
+        "Category.CategoryName": { Field: "Category.CategoryName", 
+            Before: "Watersports", After: "Boats" },
+    }
+    if len(changes) != len(expected) {
+        t.Fatalf("Unexpected changes: %v", changes)
+    }
+    for _, change := range changes {
+        if expected[change.Field] != change {
+            t.Fatalf("Unexpected change: %+v", change)
+        }
+    }
+    if changes := Diff(nil, models.Category{ ID: 3, CategoryName: "Golf" }); 
+            len(changes) != 2 || changes[0].Before != "" || changes[1].After != "Golf" {
+        t.Fatalf("Expected creation to report all fields: %v", changes)
+    }
+    if changes := Diff(models.Category{ ID: 3 }, nil); len(changes) != 2 || 
+            changes[0].After != "" {
+        t.Fatalf("Expected removal to report all fields: %v", changes)
+    }
+    order := struct { Created time.Time; Lines []int }{ created, []int { 1, 2 }}
+    changed := order
+    changed.Lines = []int { 1 }
+    if changes := Diff(order, changed); len(changes) != 1 || 
+            changes[0].Field != "Lines[1]" || changes[0].After != "" {
+        t.Fatalf("Expected slice change, got %v", changes)
+    }
+    if changes := Diff(order, order); len(changes) != 0 {
+        t.Fatalf("Expected no changes, got %v", changes)
+    }
+}
+
+type testRepository struct {
+    models.RepositoryV2
+    products map[int]models.Product
+    categories []models.Category
+    saveErr error
+}
+
+func (repo *testRepository) GetProduct(ctx context.Context, 
+        id int) (models.Product, error) {
+    if p, found := repo.products[id]; found {
+        return p, nil
+    }
+    return models.Product{}, models.ErrNotFound
+}
+
+func (repo *testRepository) SaveProduct(ctx context.Context, 
+        p *models.Product) error {
+    if repo.saveErr != nil {
+        return repo.saveErr
+    }
+    if p.ID == 0 {
+        p.ID = len(repo.products) + 1
+    }
+    repo.products[p.ID] = *p
+    return nil
+}
+
+func (repo *testRepository) GetCategories(ctx context.Context) ([]models.Category, 
+        error) {
+    return repo.categories, nil
+}
+
+func (repo *testRepository) SaveCategory(ctx context.Context, 
+        c *models.Category) error {
+    for i := range repo.categories {
+        if repo.categories[i].ID == c.ID {
+            repo.categories[i] = *c
+            return nil
+        }
+    }
+    c.ID = len(repo.categories) + 1
+    repo.categories = append(repo.categories, *c)
+    return nil
+}
+
+type testAuditRepository struct {
+    entries []models.AuditEntry
+    err error
+}
+
+func (repo *testAuditRepository) SaveAuditEntry(ctx context.Context, 
+        entry *models.AuditEntry) error {
+    if repo.err == nil {
+        repo.entries = append(repo.entries, *entry)
+    }
+    return repo.err
+}
+
+func (repo *testAuditRepository) GetAuditEntries(ctx context.Context, 
+        filter models.AuditFilter) ([]models.AuditEntry, error) {
+    return repo.entries, nil
+}
+
+func newTestAudit(ctx context.Context) (*testRepository, *testAuditRepository, 
+        *AuditingRepository) {
+    logger := logging.NewDefaultLogger(config.NewMapConfig(map[string]interface{} {
+        "logging": map[string]interface{} { "level": "none" } }))
+    inner := &testRepository{ products: map[int]models.Product {
+        1: { ID: 1, Name: "Kayak", Price: 275 },
+    }, categories: []models.Category {{ ID: 1, CategoryName: "Watersports" }}}
+    auditRepo := &testAuditRepository{}
+    return inner, auditRepo, &AuditingRepository{ RepositoryV2: inner,
+        Audit: &SqlAuditLogger{ Context: ctx, Repository: auditRepo, Logger: logger,
+            User: identity.NewBasicUser(7, "Alice", "Administrator") }}
+}
+
+func TestAuditingRepository(t *testing.T) {
+    req := httptest.NewRequest("POST", "/admin/products", nil)
+    req.RemoteAddr = "10.0.0.1:1234"
+    ctx := pipeline.WithRequest(context.Background(), req)
+    inner, auditRepo, repo := newTestAudit(ctx)
+
+    p := models.Product{ ID: 1, Name: "Kayak", Price: 300 }
+    if err := repo.SaveProduct(ctx, &p); err != nil {
+        t.Fatal(err)
+    }
+    c := models.Category{ CategoryName: "Golf" }
+    if err := repo.SaveCategory(ctx, &c); err != nil {
+        t.Fatal(err)
+    }
+    if len(auditRepo.entries) != 2 {
+        t.Fatalf("Expected two audit entries, got %v", auditRepo.entries)
+    }
+    update, create := auditRepo.entries[0], auditRepo.entries[1]
+    if update.Action != ActionUpdate || update.Entity != "product" || 
+            update.EntityID != 1 || len(update.Changes) != 1 ||
+            update.Changes[0] != (models.AuditChange{ Field: "Price", 
+                Before: "275", After: "300" }) {
+        t.Fatalf("Unexpected update entry: %+v", update)
+    }
+    if update.ActorID != 7 || update.ActorName != "Alice" || 
+            update.Method != "POST" || update.Path != "/admin/products" ||
+            update.RemoteAddr != "10.0.0.1:1234" {
+        t.Fatalf("Expected request details to be captured: %+v", update)
+    }
+    if create.Action != ActionCreate || create.Entity != "category" || 
+            create.EntityID != 2 {
+        t.Fatalf("Unexpected create entry: %+v", create)
+    }
+
+    inner.saveErr = errors.New("save failed")
+    if err := repo.SaveProduct(ctx, &p); err != inner.saveErr || 
+            len(auditRepo.entries) != 2 {
+        t.Fatalf("Expected failed saves not to be audited: %v", err)
+    }
+}
+
+func TestAuditFailureIsReported(t *testing.T) {
+    ctx := context.Background()
+    inner, auditRepo, repo := newTestAudit(ctx)
+    auditRepo.err = errors.New("audit table missing")
+    p := models.Product{ ID: 1, Name: "Kayak", Price: 300 }
+    err := repo.SaveProduct(ctx, &p)
+    if !errors.Is(err, ErrAuditFailed) {
+        t.Fatalf("Expected audit failure to be returned, got %v", err)
+    }
+    if inner.products[1].Price != 300 {
+        t.Fatal("Expected the change itself to be saved")
+    }
+    auditRepo.err = nil
+    logger := repo.Audit.(*SqlAuditLogger)
+    logger.User = nil
+    if err := logger.Record(ActionSeed, "database", 0, nil, nil); err != nil ||
+            auditRepo.entries[0].ActorName != "anonymous" {
+        t.Fatalf("Expected anonymous entry, got %v %v", auditRepo.entries, err)
+    }
+}
+
+type testPricingRepository struct {
+    models.PricingRepository
+    rates map[int]models.TaxRate
+}
+
+func (repo *testPricingRepository) GetTaxRate(ctx context.Context, 
+        id int) (models.TaxRate, error) {
+    if rate, ok := repo.rates[id]; ok {
+        return rate, nil
+    }
+    return models.TaxRate{}, errors.New("not found")
+}
+
+func (repo *testPricingRepository) SaveTaxRate(ctx context.Context, 
+        rate *models.TaxRate) error {
+    if rate.ID == 0 {
+        rate.ID = len(repo.rates) + 1
+    }
+    repo.rates[rate.ID] = *rate
+    return nil
+}
+
+func (repo *testPricingRepository) DeleteTaxRate(ctx context.Context, id int) error {
+    delete(repo.rates, id)
+    return nil
+}
+
+func TestAuditingPricingRepository(t *testing.T) {
+    ctx := context.Background()
+    _, auditRepo, audited := newTestAudit(ctx)
+    repo := &AuditingPricingRepository{ Audit: audited.Audit,
+        PricingRepository: &testPricingRepository{ rates: map[int]models.TaxRate {} }}
+
+    rate := models.TaxRate{ Name: "VAT", Country: "GB", Rate: 20 }
+    if err := repo.SaveTaxRate(ctx, &rate); err != nil {
+        t.Fatal(err)
+    }
+    rate.Rate = 17.5
+    if err := repo.SaveTaxRate(ctx, &rate); err != nil {
+        t.Fatal(err)
+    }
+    if err := repo.DeleteTaxRate(ctx, rate.ID); err != nil {
+        t.Fatal(err)
+    }
+    if len(auditRepo.entries) != 3 {
+        t.Fatalf("Expected three audit entries, got %v", auditRepo.entries)
+    }
+    create, update, remove := auditRepo.entries[0], auditRepo.entries[1], 
+        auditRepo.entries[2]
+    if create.Action != ActionCreate || create.Entity != "tax_rate" || 
+            create.EntityID != 1 || len(create.Changes) == 0 {
+        t.Fatalf("Unexpected create entry: %+v", create)
+    }
+    if update.Action != ActionUpdate || len(update.Changes) != 1 ||
+            update.Changes[0] != (models.AuditChange{ Field: "Rate", 
+                Before: "20", After: "17.5" }) {
+        t.Fatalf("Unexpected update entry: %+v", update)
+    }
+    if remove.Action != ActionDelete || remove.EntityID != 1 || 
+            len(remove.Changes) == 0 {
+        t.Fatalf("Unexpected delete entry: %+v", remove)
+    }
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+    "fmt"
+    "reflect"
+    "sportsstore/models"
+    "strconv"
+    "time"
+)
+
+type field struct {
+    name, value string
+}
+
+func Diff(before, after interface{}) (changes []models.AuditChange) {
+    beforeFields, afterFields := flatten(before), flatten(after)
+    beforeMap := map[string]string {}
+    for _, f := range beforeFields {
+        beforeMap[f.name] = f.value
+    }
+    seen := map[string]bool {}
+    for _, f := range afterFields {
+        seen[f.name] = true
+        if old, found := beforeMap[f.name]; !found || old != f.value {
+            changes = append(changes, models.AuditChange{ Field: f.name, 
+                Before: old, After: f.value })
+        }
+    }
+    for _, f := range beforeFields {
+        if !seen[f.name] {
+            changes = append(changes, models.AuditChange{ Field: f.name, 
+                Before: f.value })
+        }
+    }
+    return
+}
+
+func flatten(data interface{}) (fields []field) {
+    if data != nil {
+        flattenValue("", reflect.ValueOf(data), &fields)
+    }
+    return
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func flattenValue(name string, val reflect.Value, fields *[]field) {
+    switch val.Kind() {
+        case reflect.Ptr, reflect.Interface:
+            if !val.IsNil() {
+                flattenValue(name, val.Elem(), fields)
+            }
+        case reflect.Struct:
+            if val.Type() == timeType {
+                *fields = append(*fields, field{ name, 
+                    val.Interface().(time.Time).Format(time.RFC3339) })
+                return
+            }
+            for i := 0; i < val.NumField(); i++ {
+                structField := val.Type().Field(i)
+                if structField.PkgPath == "" {
+                    flattenValue(join(name, structField.Name), val.Field(i), fields)
+                }
+            }
+        case reflect.Slice, reflect.Array:
+            for i := 0; i < val.Len(); i++ {
+                flattenValue(fmt.Sprintf("%v[%v]", name, i), val.Index(i), fields)
+            }
+        case reflect.Float32, reflect.Float64:
+            *fields = append(*fields, field{ name, 
+                strconv.FormatFloat(val.Float(), 'f', -1, 64) })
+        default:
+            *fields = append(*fields, field{ name, fmt.Sprint(val.Interface()) })
+    }
+}
+
+func join(prefix, name string) string {
+    if prefix == "" {
+        return name
+    }
+    return prefix + "." + name
+}
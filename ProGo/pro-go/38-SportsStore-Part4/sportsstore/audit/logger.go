@@ -0,0 +1,63 @@
+package audit
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "platform/authorization/identity"
+    "platform/logging"
+    "platform/pipeline"
+    "sportsstore/models"
+    "time"
+)
+
+const (
+    ActionCreate = "create"
+    ActionUpdate = "update"
+    ActionDelete = "delete"
+    ActionModerate = "moderate"
+    ActionImport = "import"
+    ActionInitialize = "initialize"
+    ActionSeed = "seed"
+    ActionMigrate = "migrate"
+    ActionRollback = "rollback"
+)
+
+var ErrAuditFailed = errors.New("The change was saved but could not be audited")
+
+type AuditLogger interface {
+    Record(action, entity string, entityID int, before, after interface{}) error
+}
+
+type SqlAuditLogger struct {
+    Context context.Context
+    User identity.User
+    Repository models.AuditRepository
+    Logger logging.Logger
+}
+
+func (l *SqlAuditLogger) Record(action, entity string, entityID int, 
+        before, after interface{}) error {
+    entry := models.AuditEntry{
+        Time: time.Now().UTC(),
+        ActorName: "anonymous",
+        Action: action,
+        Entity: entity,
+        EntityID: entityID,
+        Changes: Diff(before, after),
+    }
+    if l.User != nil && l.User.IsAuthenticated() {
+        entry.ActorID, entry.ActorName = l.User.GetID(), l.User.GetDisplayName()
+    }
+    if req, found := pipeline.RequestFromContext(l.Context); found {
+        entry.Method, entry.Path = req.Method, req.URL.Path
+        entry.RemoteAddr, entry.UserAgent = req.RemoteAddr, req.UserAgent()
+    }
+    err := l.Repository.SaveAuditEntry(l.Context, &entry)
+    if err != nil {
+        l.Logger.Warnf("Cannot record audit entry for %v %v %v: %v", action, 
+            entity, entityID, err.Error())
+        return fmt.Errorf("%w: %v", ErrAuditFailed, err)
+    }
+    return nil
+}
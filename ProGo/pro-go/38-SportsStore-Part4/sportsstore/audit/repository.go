@@ -0,0 +1,161 @@
+package audit
+
+import (
+    "context"
+    "sportsstore/models"
+)
+
+type AuditingRepository struct {
+    models.RepositoryV2
+    Audit AuditLogger
+}
+
+func (repo *AuditingRepository) SaveProduct(ctx context.Context, 
+        p *models.Product) error {
+    action, before := ActionCreate, interface{}(nil)
+    if p.ID > 0 {
+        if existing, err := repo.RepositoryV2.GetProduct(ctx, p.ID); err == nil {
+            action, before = ActionUpdate, existing
+        }
+    }
+    if err := repo.RepositoryV2.SaveProduct(ctx, p); err != nil {
+        return err
+    }
+    return repo.Audit.Record(action, "product", p.ID, before, 
+        repo.product(ctx, *p))
+}
+
+func (repo *AuditingRepository) SetProductImage(ctx context.Context, id int, 
+        image string) error {
+    before, err := repo.RepositoryV2.GetProduct(ctx, id)
+    if err != nil {
+        return err
+    }
+    if err = repo.RepositoryV2.SetProductImage(ctx, id, image); err == nil {
+        err = repo.Audit.Record(ActionUpdate, "product", id, before, 
+            repo.product(ctx, before))
+    }
+    return err
+}
+
+func (repo *AuditingRepository) SaveCategory(ctx context.Context, 
+        c *models.Category) error {
+    action, before := ActionCreate, interface{}(nil)
+    if existing, found := repo.category(ctx, c.ID); found {
+        action, before = ActionUpdate, existing
+    }
+    if err := repo.RepositoryV2.SaveCategory(ctx, c); err != nil {
+        return err
+    }
+    after, _ := repo.category(ctx, c.ID)
+    return repo.Audit.Record(action, "category", c.ID, before, after)
+}
+
+func (repo *AuditingRepository) SetOrderShipped(ctx context.Context, 
+        o *models.Order) error {
+    before, err := repo.RepositoryV2.GetOrder(ctx, o.ID)
+    if err != nil {
+        return err
+    }
+    if err = repo.RepositoryV2.SetOrderShipped(ctx, o); err == nil {
+        after := before
+        after.Shipped = o.Shipped
+        err = repo.Audit.Record(ActionUpdate, "order", o.ID, before, after)
+    }
+    return err
+}
+
+func (repo *AuditingRepository) Init(ctx context.Context) error {
+    err := repo.RepositoryV2.Init(ctx)
+    if err == nil {
+        err = repo.Audit.Record(ActionInitialize, "database", 0, nil, nil)
+    }
+    return err
+}
+
+func (repo *AuditingRepository) Seed(ctx context.Context) error {
+    err := repo.RepositoryV2.Seed(ctx)
+    if err == nil {
+        err = repo.Audit.Record(ActionSeed, "database", 0, nil, nil)
+    }
+    return err
+}
+
+func (repo *AuditingRepository) product(ctx context.Context, 
+        p models.Product) models.Product {
+    if stored, err := repo.RepositoryV2.GetProduct(ctx, p.ID); err == nil {
+        return stored
+    }
+    return p
+}
+
+func (repo *AuditingRepository) category(ctx context.Context, 
+        id int) (models.Category, bool) {
+    if id > 0 {
+        if categories, err := repo.RepositoryV2.GetCategories(ctx); err == nil {
+            for _, c := range categories {
+                if c.ID == id {
+                    return c, true
+                }
+            }
+        }
+    }
+    return models.Category{}, false
+}
+
+type AuditingBulkRepository struct {
+    models.BulkRepository
+    Repository models.RepositoryV2
+    Audit AuditLogger
+    Context context.Context
+}
+
+func (repo *AuditingBulkRepository) ImportCategories(rows []models.ImportCategory, 
+        dryRun bool) (summary models.ImportSummary) {
+    before, _ := repo.Repository.GetCategories(repo.Context)
+    summary = repo.BulkRepository.ImportCategories(rows, dryRun)
+    if summary.Committed {
+        after, _ := repo.Repository.GetCategories(repo.Context)
+        existing := map[int]models.Category {}
+        for _, c := range before {
+            existing[c.ID] = c
+        }
+        for _, c := range after {
+            if old, found := existing[c.ID]; !found {
+                repo.record(&summary, "category", c.ID, nil, c)
+            } else if old != c {
+                repo.record(&summary, "category", c.ID, old, c)
+            }
+        }
+    }
+    return
+}
+
+func (repo *AuditingBulkRepository) ImportProducts(rows []models.ImportProduct, 
+        dryRun bool) (summary models.ImportSummary) {
+    before, _ := repo.Repository.GetProducts(repo.Context)
+    summary = repo.BulkRepository.ImportProducts(rows, dryRun)
+    if summary.Committed {
+        after, _ := repo.Repository.GetProducts(repo.Context)
+        existing := map[int]models.Product {}
+        for _, p := range before {
+            existing[p.ID] = p
+        }
+        for _, p := range after {
+            if old, found := existing[p.ID]; !found {
+                repo.record(&summary, "product", p.ID, nil, p)
+            } else if changes := Diff(old, p); len(changes) > 0 {
+                repo.record(&summary, "product", p.ID, old, p)
+            }
+        }
+    }
+    return
+}
+
+func (repo *AuditingBulkRepository) record(summary *models.ImportSummary, 
+        entity string, id int, before, after interface{}) {
+    err := repo.Audit.Record(ActionImport, entity, id, before, after)
+    if err != nil && summary.AuditError == "" {
+        summary.AuditError = err.Error()
+    }
+}
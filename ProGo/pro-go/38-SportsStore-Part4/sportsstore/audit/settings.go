@@ -0,0 +1,134 @@
+package audit
+
+import (
+    "context"
+    "sportsstore/models"
+)
+
+type loader func(id int) (interface{}, error)
+
+func recordSave(audit AuditLogger, entity string, id *int, load loader,
+        save func() error) error {
+    action, before := ActionCreate, interface{}(nil)
+    if *id > 0 {
+        if existing, err := load(*id); err == nil {
+            action, before = ActionUpdate, existing
+        }
+    }
+    if err := save(); err != nil {
+        return err
+    }
+    after, _ := load(*id)
+    return audit.Record(action, entity, *id, before, after)
+}
+
+func recordDelete(audit AuditLogger, entity string, id int, load loader,
+        remove func() error) error {
+    before, _ := load(id)
+    if err := remove(); err != nil {
+        return err
+    }
+    return audit.Record(ActionDelete, entity, id, before, nil)
+}
+
+type AuditingShippingRepository struct {
+    models.ShippingRepository
+    Audit AuditLogger
+}
+
+func (repo *AuditingShippingRepository) load(ctx context.Context) loader {
+    return func(id int) (interface{}, error) {
+        return repo.ShippingRepository.GetShippingMethod(ctx, id)
+    }
+}
+
+func (repo *AuditingShippingRepository) SaveShippingMethod(ctx context.Context,
+        method *models.ShippingMethod) error {
+    return recordSave(repo.Audit, "shipping_method", &method.ID, repo.load(ctx),
+        func() error {
+            return repo.ShippingRepository.SaveShippingMethod(ctx, method)
+        })
+}
+
+func (repo *AuditingShippingRepository) DeleteShippingMethod(ctx context.Context,
+        id int) error {
+    return recordDelete(repo.Audit, "shipping_method", id, repo.load(ctx),
+        func() error {
+            return repo.ShippingRepository.DeleteShippingMethod(ctx, id)
+        })
+}
+
+type AuditingPromotionRepository struct {
+    models.PromotionRepository
+    Audit AuditLogger
+}
+
+func (repo *AuditingPromotionRepository) load(ctx context.Context) loader {
+    return func(id int) (interface{}, error) {
+        return repo.PromotionRepository.GetPromotion(ctx, id)
+    }
+}
+
+func (repo *AuditingPromotionRepository) SavePromotion(ctx context.Context,
+        promotion *models.Promotion) error {
+    return recordSave(repo.Audit, "promotion", &promotion.ID, repo.load(ctx),
+        func() error {
+            return repo.PromotionRepository.SavePromotion(ctx, promotion)
+        })
+}
+
+func (repo *AuditingPromotionRepository) DeletePromotion(ctx context.Context,
+        id int) error {
+    return recordDelete(repo.Audit, "promotion", id, repo.load(ctx), func() error {
+        return repo.PromotionRepository.DeletePromotion(ctx, id)
+    })
+}
+
+type AuditingPricingRepository struct {
+    models.PricingRepository
+    Audit AuditLogger
+}
+
+func (repo *AuditingPricingRepository) loadShippingRate(ctx context.Context) loader {
+    return func(id int) (interface{}, error) {
+        return repo.PricingRepository.GetShippingRate(ctx, id)
+    }
+}
+
+func (repo *AuditingPricingRepository) loadTaxRate(ctx context.Context) loader {
+    return func(id int) (interface{}, error) {
+        return repo.PricingRepository.GetTaxRate(ctx, id)
+    }
+}
+
+func (repo *AuditingPricingRepository) SaveShippingRate(ctx context.Context,
+        rate *models.ShippingRate) error {
+    return recordSave(repo.Audit, "shipping_rate", &rate.ID,
+        repo.loadShippingRate(ctx), func() error {
+            return repo.PricingRepository.SaveShippingRate(ctx, rate)
+        })
+}
+
+func (repo *AuditingPricingRepository) DeleteShippingRate(ctx context.Context,
+        id int) error {
+    return recordDelete(repo.Audit, "shipping_rate", id, repo.loadShippingRate(ctx),
+        func() error {
+            return repo.PricingRepository.DeleteShippingRate(ctx, id)
+        })
+}
+
+func (repo *AuditingPricingRepository) SaveTaxRate(ctx context.Context,
+        rate *models.TaxRate) error {
+    return recordSave(repo.Audit, "tax_rate", &rate.ID, repo.loadTaxRate(ctx),
+        func() error {
+            return repo.PricingRepository.SaveTaxRate(ctx, rate)
+        })
+}
+
+func (repo *AuditingPricingRepository) DeleteTaxRate(ctx context.Context,
+        id int) error {
+    return recordDelete(repo.Audit, "tax_rate", id, repo.loadTaxRate(ctx),
+        func() error {
+            return repo.PricingRepository.DeleteTaxRate(ctx, id)
+        })
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "os"
+    "platform/services"
+    "platform/tenants"
+    "strings"
+    "text/tabwriter"
+)
+
+type command struct {
+    name, usage, description string
+    run interface{}
+}
+
+var commands []command
+
+func init() {
+    commands = []command {
+        { "serve", "", "Start the HTTP server and background jobs", runServeCommand },
+        { "migrate", "[status|up|down [steps]|verify]", 
+            "Show or apply database migrations", runMigrateCommand },
+        { "seed", "[--reset]", "Load the seed data into the database", runSeedCommand },
+        { "create-admin", "--name=name [--email=email] [--password=password]",
+            "Create or update an administrator account", runCreateAdminCommand },
+        { "export", "[--format=csv|json] [--out=file] products|categories|orders",
+            "Export catalog or order data", runExportCommand },
+        { "check-config", "", "Validate the configuration for every tenant", 
+            runCheckConfigCommand },
+        { "routes", "", "List the routes handled by the application", 
+            runRoutesCommand },
+    }
+}
+
+func runCommand(args []string) error {
+    name := "serve"
+    if len(args) > 0 {
+        name, args = args[0], args[1:]
+    }
+    if name == "help" || name == "--help" || name == "-h" {
+        return printUsage()
+    }
+    for _, c := range commands {
+        if c.name == name {
+            ctx, args, err := commandContext(args)
+            if err != nil {
+                return err
+            }
+            results, err := services.CallForContext(ctx, c.run, args)
+            if err == nil && len(results) > 0 && results[0] != nil {
+                err = results[0].(error)
+            }
+            return err
+        }
+    }
+    printUsage()
+    return fmt.Errorf("Unknown command: %v", name)
+}
+
+func commandContext(args []string) (ctx context.Context, rest []string, err error) {
+    ctx, rest = context.Background(), args
+    if len(args) > 0 && strings.HasPrefix(args[0], "--tenant=") {
+        var registry *tenants.Registry
+        if err = services.GetService(&registry); err != nil {
+            return
+        }
+        name := strings.TrimPrefix(args[0], "--tenant=")
+        tenant, found := registry.Get(name)
+        if !found {
+            return ctx, rest, fmt.Errorf("Unknown tenant: %v", name)
+        }
+        ctx, rest = tenants.NewContext(ctx, tenant), args[1:]
+    }
+    return services.NewServiceScope(ctx), rest, nil
+}
+
+func printUsage() error {
+    writer := tabwriter.NewWriter(os.Stderr, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(writer, "Usage: sportsstore [command] [--tenant=name] [arguments]")
+    fmt.Fprintln(writer)
+    for _, c := range commands {
+        fmt.Fprintf(writer, "  %v %v\t%v\n", c.name, c.usage, c.description)
+    }
+    return writer.Flush()
+}
+
+func usageError(name string) error {
+    for _, c := range commands {
+        if c.name == name {
+            return fmt.Errorf("Usage: sportsstore %v [--tenant=name] %v", name, c.usage)
+        }
+    }
+    return errors.New("Unknown command")
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "platform/config"
+    "platform/tenants"
+    "sportsstore/models/repo"
+    "time"
+)
+
+func runCheckConfigCommand(args []string, cfg config.Configuration, 
+        registry *tenants.Registry) error {
+    if len(args) > 0 {
+        return usageError("check-config")
+    }
+    problems := checkServerConfig(cfg)
+    for _, tenant := range registry.Tenants() {
+        for _, problem := range checkTenantConfig(tenant.Configuration) {
+            problems = append(problems, fmt.Sprintf("%v: %v", tenant.Name, problem))
+        }
+    }
+    for _, problem := range problems {
+        fmt.Println(problem)
+    }
+    if len(problems) > 0 {
+        return fmt.Errorf("Found %v configuration problem(s)", len(problems))
+    }
+    fmt.Printf("Configuration is valid for %v tenant(s)\n", len(registry.Tenants()))
+    return nil
+}
+
+func checkServerConfig(cfg config.Configuration) (problems []string) {
+    if cfg.GetBoolDefault("http:enableHttps", false) {
+        for _, key := range []string { "http:httpsCert", "http:httpsKey" } {
+            problems = append(problems, checkFile(cfg, key)...)
+        }
+    }
+    if !cfg.GetBoolDefault("http:enableHttp", true) && 
+            !cfg.GetBoolDefault("http:enableHttps", false) {
+        problems = append(problems, "Neither HTTP nor HTTPS is enabled")
+    }
+    for _, key := range []string { "jobs:pollInterval", "jobs:timeout", 
+            "jobs:backoff", "jobs:maxBackoff", "jobs:retention", "api:rateLimit:window" } {
+        if val, found := cfg.GetString(key); found {
+            if _, err := time.ParseDuration(val); err != nil {
+                problems = append(problems, fmt.Sprintf("Invalid duration for %v: %v", 
+                    key, val))
+            }
+        }
+    }
+    return
+}
+
+func checkTenantConfig(cfg config.Configuration) (problems []string) {
+    problems = repo.CheckConfig(cfg)
+    pattern := cfg.GetStringDefault("templates:path", "templates/*.html")
+    if matches, err := filepath.Glob(pattern); err != nil || len(matches) == 0 {
+        problems = append(problems, fmt.Sprintf("No templates match: %v", pattern))
+    }
+    if theme, found := cfg.GetString("templates:theme"); found {
+        path := filepath.Join(cfg.GetStringDefault("templates:themes", 
+            "templates/themes"), theme)
+        if info, err := os.Stat(path); err != nil || !info.IsDir() {
+            problems = append(problems, fmt.Sprintf("Cannot read theme: %v", path))
+        }
+    }
+    if cfg.GetStringDefault("sessions:key", "") == "" {
+        problems = append(problems, "Missing session key: sessions:key")
+    }
+    return
+}
+
+func checkFile(cfg config.Configuration, key string) []string {
+    filename, found := cfg.GetString(key)
+    if !found {
+        return []string { fmt.Sprintf("Missing setting: %v", key) }
+    } else if _, err := os.Stat(filename); err != nil {
+        return []string { fmt.Sprintf("Cannot read %v: %v", key, filename) }
+    }
+    return nil
+}
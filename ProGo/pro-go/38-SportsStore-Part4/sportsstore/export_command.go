@@ -0,0 +1,39 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "io"
+    "os"
+    "sportsstore/admin/transfer"
+    "sportsstore/models/repo"
+)
+
+func runExportCommand(args []string, ctx context.Context, 
+        sqlRepo *repo.SqlRepository) (err error) {
+    flags := flag.NewFlagSet("export", flag.ContinueOnError)
+    format := flags.String("format", transfer.FormatCSV, "The output format")
+    out := flags.String("out", "", "The output file, defaults to stdout")
+    if err = flags.Parse(args); err != nil || flags.NArg() != 1 || 
+            !(*format == transfer.FormatCSV || *format == transfer.FormatJSON) {
+        return usageError("export")
+    }
+    records, err := transfer.Export(ctx, sqlRepo, flags.Arg(0))
+    if err != nil {
+        return
+    }
+    var writer io.Writer = os.Stdout
+    if *out != "" {
+        var file *os.File
+        if file, err = os.Create(*out); err != nil {
+            return
+        }
+        defer func() {
+            if closeErr := file.Close(); err == nil {
+                err = closeErr
+            }
+        }()
+        writer = file
+    }
+    return transfer.Write(writer, *format, records)
+}
@@ -2,7 +2,12 @@ module sportsstore
 
 go 1.17
 
-require platform v1.0.0
+require (
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/lib/pq v1.10.9
+	modernc.org/sqlite v1.13.1
+	platform v1.0.0
+)
 
 require (
 	github.com/gorilla/securecookie v1.1.1 // indirect
@@ -21,7 +26,6 @@ require (
 	modernc.org/mathutil v1.4.1 // indirect
 	modernc.org/memory v1.0.5 // indirect
 	modernc.org/opt v0.1.1 // indirect
-	modernc.org/sqlite v1.13.1 // indirect
 	modernc.org/strutil v1.1.1 // indirect
 	modernc.org/token v1.0.0 // indirect
 )
@@ -1,6 +1,10 @@
 package main
 
 import (
+    "context"
+    "fmt"
+    "os"
+    "strings"
     "sync"
     "platform/http"
     "platform/http/handling"
@@ -14,62 +18,159 @@ import (
     "sportsstore/admin"
     "platform/authorization"
     "sportsstore/admin/auth"
+    "platform/media"
+    "platform/mail"
+    "platform/jobs"
+    "platform/config"
+    "platform/pubsub"
+    "platform/health"
+    "platform/features"
+    "platform/scaffold"
+    "platform/security"
+    "platform/tenants"
+    "sportsstore/audit"
+    "sportsstore/notify"
+    "sportsstore/pricing"
+    "sportsstore/reviews"
 )
 
 func registerServices() {
     services.RegisterDefaultServices()
+    tenants.RegisterTenantServices()
     //repo.RegisterMemoryRepoService()
     repo.RegisterSqlRepositoryService()
+    repo.RegisterCachingRepositoryService()
     sessions.RegisterSessionService()
     cart.RegisterCartService()
     authorization.RegisterDefaultSignInService()
     authorization.RegisterDefaultUserService()
     auth.RegisterUserStoreService()
+    auth.RegisterPolicyServices()
+    admin.RegisterScaffoldServices()
+    pricing.RegisterPricingService()
+    reviews.RegisterReviewServices()
+    media.RegisterLocalMediaStore()
+    audit.RegisterAuditService()
+    mail.RegisterMailService()
+    jobs.RegisterJobService()
+    repo.RegisterSqlJobQueueService()
+    features.RegisterFeatureServices()
+    repo.RegisterSqlFeatureStoreService()
+    pubsub.RegisterPubSubService()
+    repo.RegisterPublishingRepositoryService()
+    health.RegisterHealthServices()
+    security.RegisterSecurityServices()
+}
+
+func startJobs(scheduler *jobs.Scheduler, cfg config.Configuration) *sync.WaitGroup {
+    handlers := []struct { name string; handler interface{} } {
+        { "outbox", notify.SendPendingEmails },
+        { "carts", cart.CleanupAbandonedCarts },
+    }
+    for _, h := range handlers {
+        handler := h.handler
+        err := scheduler.Handle(h.name, func(job jobs.Job, ctx context.Context, 
+                registry *tenants.Registry) error {
+            return registry.CallForEach(ctx, handler, job)
+        })
+        if err != nil {
+            panic(err)
+        }
+        if spec, found := cfg.GetString("jobs:schedules:" + h.name); found {
+            if err := scheduler.Schedule(h.name, spec, nil); err != nil {
+                panic(err)
+            }
+        }
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    http.OnShutdown(cancel)
+    return scheduler.Start(ctx)
 }
 
 func createPipeline() pipeline.RequestPipeline {
-    return pipeline.CreatePipeline(
+    return pipeline.CreatePipeline(createComponents()...)
+}
+
+func createComponents() []interface{} {
+    return []interface{} {
         &basic.ServicesComponent{},
+        security.NewSecurityHeadersComponent("security:headers"),
+        &health.HealthComponent{},
         &basic.LoggingComponent{},
         &basic.ErrorComponent{},
+        &tenants.TenantComponent{},
         &basic.StaticFileComponent{},
+        &media.MediaComponent{},
         &sessions.SessionComponent{},
+        &features.FeatureComponent{},
 
 
-        authorization.NewAuthComponent(
-            "admin", 
-            authorization.NewRoleCondition("Administrator"),
+        handling.NewRouteGroup("admin",
+            authorization.NewAuthorizationComponent(
+                authorization.NewPolicyCondition(auth.AdminAccessPolicy)),
+        ).AddHandlers(
             admin.AdminHandler{},            
             admin.ProductsHandler{},
             admin.CategoriesHandler{},           
             admin.OrdersHandler{},            
-            admin.DatabaseHandler{},     
+            admin.DatabaseHandler{},
+            admin.TransferHandler{},
+            admin.AuditHandler{},
+            admin.JobsHandler{},
+            admin.ReportsHandler{},
+            admin.ReviewsHandler{},
+            admin.FeaturesHandler{},
+            admin.DiagnosticsHandler{},
             admin.SignOutHandler{},
+        ).AddHandlerEntries(
+            handling.HandlerEntry{ "scaffold", scaffold.ScaffoldHandler{} },
         ).AddFallback("/admin/section/", "^/admin[/]?$"),
+
+        handling.NewRouteGroup("api",
+            security.NewCorsComponent("api:cors"),
+            &basic.JsonErrorComponent{},
+            basic.NewRateLimitComponent("api:rateLimit"),
+        ).AddHandlers(store.RestHandler{}),
         
         handling.NewRouter(
             handling.HandlerEntry{ "",  store.ProductHandler{}},
             handling.HandlerEntry{ "",  store.CategoryHandler{}},
             handling.HandlerEntry{ "", store.CartHandler{}},            
             handling.HandlerEntry{ "", store.OrderHandler{}},            
+            handling.HandlerEntry{ "", store.ReviewHandler{}},
+            handling.HandlerEntry{ "account", store.AccountHandler{}},
             // handling.HandlerEntry{ "admin", admin.AdminHandler{}},            
             // handling.HandlerEntry{ "admin", admin.ProductsHandler{}},   
             // handling.HandlerEntry{ "admin", admin.CategoriesHandler{}},           
             // handling.HandlerEntry{ "admin", admin.OrdersHandler{}},            
             // handling.HandlerEntry{ "admin", admin.DatabaseHandler{}},                  
             handling.HandlerEntry{ "", admin.AuthenticationHandler{}},
-            handling.HandlerEntry{ "api", store.RestHandler{}},
         ).AddMethodAlias("/", store.ProductHandler.GetProducts, 0, 1).
             AddMethodAlias("/products[/]?[A-z0-9]*?", 
-                store.ProductHandler.GetProducts, 0, 1),    )
+                store.ProductHandler.GetProducts, 0, 1),
+    }
 }
 
 func main() {
     registerServices()
+    if err := runCommand(os.Args[1:]); err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+}
+
+func runServeCommand(args []string) error {
+    if len(args) > 0 {
+        return fmt.Errorf("Unexpected arguments: %v", strings.Join(args, " "))
+    }
+    jobResults, err := services.Call(startJobs)
+    if (err != nil) {
+        return err
+    }
     results, err := services.Call(http.Serve, createPipeline())
     if (err == nil) {
         (results[0].(*sync.WaitGroup)).Wait()
-    } else {
-        panic(err)
-    }    
+        (jobResults[0].(*sync.WaitGroup)).Wait()
+    }
+    return err
 }
@@ -0,0 +1,954 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "os"
+    "path/filepath"
+    "platform/config"
+    "platform/features"
+    "platform/health"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/mail"
+    "platform/media"
+    "platform/pipeline"
+    "platform/pipeline/basic"
+    "platform/platformtest"
+    "platform/pubsub"
+    "platform/tenants"
+    "platform/validation"
+    "sportsstore/admin/auth"
+    "sportsstore/models"
+    "sportsstore/models/repo"
+    "sportsstore/notify"
+    "sportsstore/store"
+    "strings"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func newStoreHarness(t *testing.T, 
+        overrides ...map[string]interface{}) *platformtest.Harness {
+    base, err := config.Load("config.json")
+    if err != nil {
+        t.Fatal(err)
+    }
+    dir := t.TempDir()
+    values := map[string]interface{} {
+        "logging:level": "none",
+        "sql:connection_str": filepath.Join(dir, "store.db"),
+        "sql:always_reset": true,
+        "mail:type": "memory",
+        "jobs:queue": "memory",
+        "tenants:stores:outdoors:config:sql:connection_str":
+            filepath.Join(dir, "outdoors.db"),
+    }
+    for _, o := range overrides {
+        for key, val := range o {
+            values[key] = val
+        }
+    }
+    h := platformtest.New(t, config.NewOverlayConfig(base, 
+        platformtest.ConfigValues(values)))
+    registerServices()
+    h.Pipeline = createPipeline()
+    return h
+}
+
+func TestProductList(t *testing.T) {
+    h := newStoreHarness(t)
+    response := h.NewClient().Get("/products/0/1")
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    action, _ := response.Action()
+    data := platformtest.AssertTemplate(t, action.Result, "product_list.html")
+    if products := data.(store.ProductTemplateContext).Products; len(products) == 0 {
+        t.Fatal("Expected products to be listed")
+    }
+    if !strings.Contains(response.Body.String(), "SPORTS STORE") {
+        t.Fatal("Expected default store layout")
+    }
+}
+
+func TestCheckout(t *testing.T) {
+    h := newStoreHarness(t)
+    client := h.NewClient()
+    platformtest.AssertRedirect(t,
+        client.PostForm("/addtocart", url.Values{ "id": { "1" } }), "/cart")
+
+    invalid := client.PostForm("/checkout", url.Values{ "name": { "Bob" } })
+    platformtest.AssertRedirect(t, invalid, "/checkout")
+    action, _ := client.Follow(invalid).Action()
+    data := platformtest.AssertTemplate(t, action.Result, "checkout.html")
+    if len(data.(store.OrderTemplateContext).ValidationErrors) == 0 {
+        t.Fatal("Expected validation errors")
+    }
+
+    response := client.PostForm("/checkout", url.Values{
+        "name": { "Bob" }, "email": { "bob@example.com" },
+        "streetaddr": { "1 Main St" }, "city": { "Town" }, "state": { "NY" },
+        "zip": { "12345" }, "country": { "USA" },
+    })
+    location := response.Header().Get("Location")
+    if !strings.HasPrefix(location, "/summary/") {
+        t.Fatalf("Expected redirect to order summary, got %v", location)
+    }
+    var repo models.RepositoryV2
+    h.GetService(&repo)
+    orders, err := repo.GetOrders(h.Context())
+    if err != nil {
+        t.Fatal(err)
+    }
+    order := orders[len(orders) - 1]
+    if order.Name != "Bob" || len(order.Products) != 1 ||
+            order.Products[0].ID != 1 {
+        t.Fatalf("Unexpected order: %+v", order)
+    }
+    if order.Subtotal != 275 || order.Shipping != 12.95 || order.Tax != 25.56 ||
+            order.TaxIncluded || order.Total != 313.51 {
+        t.Fatalf("Unexpected order totals: %+v", order.OrderTotals)
+    }
+    if !strings.Contains(client.Get(location).Body.String(), "$313.51") {
+        t.Fatal("Expected order totals in summary")
+    }
+    if strings.Contains(h.NewClient().Get(location).Body.String(), "$313.51") {
+        t.Fatal("Expected order details to be hidden from other sessions")
+    }
+    if strings.Contains(client.Get("/cart").Body.String(), order.Products[0].Name) {
+        t.Fatal("Expected cart to be empty after checkout")
+    }
+
+    product := order.Products[0].Product
+    product.Price = 300
+    if err := repo.SaveProduct(h.Context(), &product); err != nil {
+        t.Fatal(err)
+    }
+    worker := newOutboxWorker(h)
+    if sent, err := worker.ProcessPending(h.Context()); err != nil || sent == 0 {
+        t.Fatalf("Expected order email to be sent: %v %v", sent, err)
+    }
+    messages := worker.Mailer.(*mail.MemoryMailer).Messages()
+    if body := messages[len(messages) - 1].Body; !strings.Contains(body, "$275.00") ||
+            strings.Contains(body, "$300.00") {
+        t.Fatalf("Expected the email to use the price at order time: %v", body)
+    }
+}
+
+func auditActions(t *testing.T, h *platformtest.Harness, entity string) []string {
+    var repo models.AuditRepository
+    h.GetService(&repo)
+    entries, err := repo.GetAuditEntries(h.Context(), models.AuditFilter{ Entity: entity })
+    if err != nil {
+        t.Fatal(err)
+    }
+    actions := []string {}
+    for i := len(entries) - 1; i >= 0; i-- {
+        actions = append(actions, fmt.Sprint(entries[i].Action, ":", 
+            entries[i].EntityID, ":", entries[i].ActorName))
+    }
+    return actions
+}
+
+func newOutboxWorker(h *platformtest.Harness) *notify.OutboxWorker {
+    worker := &notify.OutboxWorker{}
+    h.GetService(&worker.Outbox)
+    h.GetService(&worker.Mailer)
+    h.GetService(&worker.TemplateExecutor)
+    h.GetService(&worker.Logger)
+    h.GetService(&worker.Configuration)
+    return worker
+}
+
+type blockingMailer struct {
+    mail.Mailer
+    started, release chan struct{}
+    blocked int32
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mail.Message) error {
+    if atomic.CompareAndSwapInt32(&m.blocked, 0, 1) {
+        close(m.started)
+        <- m.release
+    }
+    return m.Mailer.Send(ctx, msg)
+}
+
+func TestOutboxConcurrentWorkers(t *testing.T) {
+    h := newStoreHarness(t)
+    var repo models.RepositoryV2
+    h.GetService(&repo)
+    product, err := repo.GetProduct(h.Context(), 1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := repo.SaveOrder(h.Context(), &models.Order{
+            ShippingDetails: models.ShippingDetails{ Name: "Bob", 
+                Email: "bob@example.com" },
+            Products: []models.ProductSelection {{ Quantity: 1, Product: product }},
+        }); err != nil {
+        t.Fatal(err)
+    }
+    first, second := newOutboxWorker(h), newOutboxWorker(h)
+    memory := first.Mailer.(*mail.MemoryMailer)
+    blocking := &blockingMailer{ Mailer: memory, started: make(chan struct{}),
+        release: make(chan struct{}) }
+    first.Mailer, second.Mailer = blocking, blocking
+    results := make(chan int, 1)
+    go func() {
+        sent, _ := first.ProcessPending(h.Context())
+        results <- sent
+    }()
+    <- blocking.started
+    if sent, err := second.ProcessPending(h.Context()); err != nil || sent != 0 {
+        t.Fatalf("Expected claimed email to be skipped: %v %v", sent, err)
+    }
+    close(blocking.release)
+    if sent := <- results; sent != 1 || len(memory.Messages()) != 1 {
+        t.Fatalf("Expected one email to be sent, got %v %v", sent, 
+            len(memory.Messages()))
+    }
+    if sent, err := second.ProcessPending(h.Context()); err != nil || sent != 0 {
+        t.Fatalf("Expected no pending emails: %v %v", sent, err)
+    }
+}
+
+func TestCheckoutPricing(t *testing.T) {
+    h := newStoreHarness(t)
+    client := h.NewClient()
+    client.PostForm("/addtocart", url.Values{ "id": { "2" } })
+    details := url.Values{
+        "name": { "Hana" }, "email": { "hana@example.com" },
+        "streetaddr": { "1-1 Chiyoda" }, "city": { "Tokyo" }, "state": { "Tokyo" },
+        "zip": { "100-0001" }, "country": { "JP" },
+        "promotioncode": { "NOSUCHCODE" },
+    }
+    invalid := client.PostForm("/checkout", details)
+    platformtest.AssertRedirect(t, invalid, "/checkout")
+    action, _ := client.Follow(invalid).Action()
+    data := platformtest.AssertTemplate(t, action.Result, "checkout.html")
+    if len(data.(store.OrderTemplateContext).ValidationErrors) == 0 {
+        t.Fatal("Expected promotion code validation error")
+    }
+
+    details.Del("promotioncode")
+    details.Set("shippingmethodid", "2")
+    response := client.PostForm("/checkout", details)
+    if !strings.HasPrefix(response.Header().Get("Location"), "/summary/") {
+        t.Fatalf("Expected redirect to order summary, got %v", 
+            response.Header().Get("Location"))
+    }
+    var repo models.RepositoryV2
+    h.GetService(&repo)
+    orders, err := repo.GetOrders(h.Context())
+    if err != nil {
+        t.Fatal(err)
+    }
+    order := orders[len(orders) - 1]
+    if order.ShippingMethodID != 2 || order.Shipping != 14.95 ||
+            order.TaxName != "Consumption Tax" || !order.TaxIncluded ||
+            order.Tax != 5.81 || order.Total != 63.9 {
+        t.Fatalf("Unexpected order totals: %+v", order)
+    }
+}
+
+func TestProductReviews(t *testing.T) {
+    h := newStoreHarness(t)
+    var repo models.RepositoryV2
+    h.GetService(&repo)
+    ball, err := repo.GetProduct(h.Context(), 3)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := repo.SaveOrder(h.Context(), &models.Order{ AccountID: 2, 
+            ShippingDetails: models.ShippingDetails{ Name: "Fred" },
+            Products: []models.ProductSelection { { Quantity: 1, Product: ball } }, 
+        }); err != nil {
+        t.Fatal(err)
+    }
+    if body := h.NewClient().Get("/reviews/1").Body.String(); 
+            !strings.Contains(body, "perfect for calm lakes") ||
+            !strings.Contains(body, "Sign in") {
+        t.Fatal("Expected approved reviews and sign in prompt")
+    }
+
+    customer := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 2, Name: "Fred", Roles: []string { auth.CUSTOMER_ROLE } }))
+    reviewErrors := func(values url.Values) [][]string {
+        response := customer.PostForm("/review", values)
+        platformtest.AssertRedirect(t, response, "/reviews/" + values.Get("productid"))
+        action, _ := customer.Follow(response).Action()
+        data := platformtest.AssertTemplate(t, action.Result, "product_reviews.html")
+        return data.(store.ReviewTemplateContext).ValidationErrors
+    }
+    if errs := reviewErrors(url.Values{ "productid": { "3" }, "rating": { "1" },
+            "body": { "Visit http://spam.example.com for CASINO bonuses" } }); 
+            len(errs) != 2 {
+        t.Fatalf("Expected link and spam errors, got %v", errs)
+    }
+    if errs := reviewErrors(url.Values{ "productid": { "4" }, "rating": { "4" },
+            "body": { "Looks great on the pitch" } }); len(errs) != 1 {
+        t.Fatalf("Expected purchase error, got %v", errs)
+    }
+    if errs := reviewErrors(url.Values{ "productid": { "3" }, "rating": { "3" },
+            "body": { "Decent ball, a little light" } }); len(errs) != 0 {
+        t.Fatalf("Unexpected validation errors: %v", errs)
+    }
+    if errs := reviewErrors(url.Values{ "productid": { "3" }, "rating": { "5" },
+            "body": { "Changed my mind, it is great" } }); len(errs) != 1 {
+        t.Fatalf("Expected duplicate review error, got %v", errs)
+    }
+
+    var reviews models.ReviewRepository
+    h.GetService(&reviews)
+    pending, err := reviews.GetReviews(h.Context(), models.ReviewPending)
+    if err != nil || len(pending) != 1 {
+        t.Fatalf("Expected one pending review: %v %v", pending, err)
+    }
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { "Administrator" } }))
+    platformtest.AssertRedirect(t, admin.PostForm("/admin/reviewstatus", url.Values{
+        "id": { fmt.Sprint(pending[0].ID) }, "status": { models.ReviewApproved } }), 
+        "/admin/section/Reviews")
+    if ball, err = repo.GetProduct(h.Context(), 3); err != nil || 
+            ball.ReviewCount != 1 || ball.Rating != 3 {
+        t.Fatalf("Expected approved review in product rating: %+v", ball)
+    }
+    if actions := fmt.Sprint(auditActions(t, h, "review")); actions !=
+            fmt.Sprintf("[moderate:%v:Alice]", pending[0].ID) {
+        t.Fatalf("Unexpected review audit entries: %v", actions)
+    }
+    action, _ := h.NewClient().Get("/products/0/1?sort=rating").Action()
+    data := platformtest.AssertTemplate(t, action.Result, "product_list.html")
+    if products := data.(store.ProductTemplateContext).Products; len(products) == 0 ||
+            products[0].ID != 1 || products[2].ID != 3 {
+        t.Fatalf("Unexpected rating order: %+v", products)
+    }
+}
+
+func TestFeatureFlags(t *testing.T) {
+    h := newStoreHarness(t)
+    customer := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 2, Name: "Fred", Roles: []string { auth.CUSTOMER_ROLE } }))
+    customer.PostForm("/addtocart", url.Values{ "id": { "1" } })
+    checkout := func() store.OrderTemplateContext {
+        action, _ := customer.Get("/checkout").Action()
+        return platformtest.AssertTemplate(t, action.Result, 
+            "checkout.html").(store.OrderTemplateContext)
+    }
+    if checkout().Quote != nil {
+        t.Fatal("Expected new checkout to be dark launched")
+    }
+
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { auth.ADMIN_ROLE } }))
+    bucket := features.Bucket(store.NEW_CHECKOUT_FEATURE, "user:2")
+    setFlag := func(percentage int) {
+        platformtest.AssertRedirect(t, admin.PostForm("/admin/feature", url.Values{
+            "name": { store.NEW_CHECKOUT_FEATURE }, "enabled": { "true" }, 
+            "percentage": { fmt.Sprint(percentage) }, "keyby": { features.KeyByUser },
+        }), "/admin/section/Features")
+    }
+    setFlag(bucket + 1)
+    if quote := checkout().Quote; quote == nil || quote.Subtotal != 275 {
+        t.Fatalf("Expected new checkout with order summary: %+v", quote)
+    }
+    if body := customer.Get("/checkout").Body.String(); 
+            !strings.Contains(body, "Order summary") {
+        t.Fatal("Expected order summary in checkout page")
+    }
+    setFlag(bucket)
+    if checkout().Quote != nil {
+        t.Fatal("Expected user to be outside the rollout")
+    }
+
+    var flags features.FlagStore
+    h.GetService(&flags)
+    stored, err := flags.GetFlags(h.Context())
+    if err != nil || len(stored) != 1 || stored[0].Percentage != bucket ||
+            stored[0].KeyBy != features.KeyByUser || stored[0].Description == "" {
+        t.Fatalf("Unexpected stored flags: %+v %v", stored, err)
+    }
+    if body := admin.Get("/admin/section/Features").Body.String(); 
+            !strings.Contains(body, store.NEW_CHECKOUT_FEATURE) {
+        t.Fatal("Expected feature flag in admin section")
+    }
+    if actions := fmt.Sprint(auditActions(t, h, 
+            "feature:" + store.NEW_CHECKOUT_FEATURE)); actions != 
+            "[update:0:Alice update:0:Alice]" {
+        t.Fatalf("Unexpected feature audit entries: %v", actions)
+    }
+}
+
+func TestAdminSignIn(t *testing.T) {
+    h := newStoreHarness(t)
+    client := h.NewClient()
+    platformtest.AssertRedirect(t, client.Get("/admin/section/Products"),
+        "/signin")
+    platformtest.AssertRedirect(t, client.PostForm("/signin", url.Values{
+        "username": { "Alice" }, "password": { "wrong" } }), "/signin")
+    platformtest.AssertRedirect(t, client.PostForm("/signin", url.Values{
+        "username": { "Alice" }, "password": { "mysecret" } }), "/admin/section/")
+    platformtest.AssertStatus(t, client.Get("/admin/section/Products"),
+        http.StatusOK)
+    platformtest.AssertRedirect(t, h.NewClient().Get("/admin/section/Products"),
+        "/signin")
+}
+
+func TestAuthenticateAs(t *testing.T) {
+    h := newStoreHarness(t)
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { "Administrator" } }))
+    response := admin.Get("/admin/section/Orders")
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    customer := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 2, Name: "Bob", Roles: []string { auth.CUSTOMER_ROLE } }))
+    platformtest.AssertRedirect(t, customer.Get("/admin/section/Orders"), "/signin")
+}
+
+func TestTenantStorefront(t *testing.T) {
+    h := newStoreHarness(t)
+    response := h.NewClient().Get("/outdoors/products/0/1")
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    if body := response.Body.String(); !strings.Contains(body, "OUTDOOR GEAR CO.") ||
+            !strings.Contains(body, "/outdoors/addtocart") {
+        t.Fatal("Expected themed storefront with prefixed URLs")
+    }
+}
+
+func TestTenantServices(t *testing.T) {
+    dir := t.TempDir()
+    h := newStoreHarness(t, map[string]interface{} {
+        "tenants:stores:outdoors:config:mail:from": "orders@outdoors.example",
+        "tenants:stores:outdoors:config:media:path": filepath.Join(dir, "media"),
+    })
+    var registry *tenants.Registry
+    h.GetService(&registry)
+    type tenantServices struct {
+        repository *repo.SqlRepository
+        mailer mail.Mailer
+        media media.MediaStore
+        flags features.FlagStore
+    }
+    resolve := func() map[string]tenantServices {
+        resolved := map[string]tenantServices {}
+        err := registry.CallForEach(h.Context(), func(ctx context.Context,
+                repository *repo.SqlRepository, mailer mail.Mailer, 
+                store media.MediaStore, flags features.FlagStore) {
+            tenant, _ := tenants.FromContext(ctx)
+            resolved[tenant.Name] = tenantServices{ repository, mailer, store, flags }
+        })
+        if err != nil {
+            t.Fatal(err)
+        }
+        return resolved
+    }
+    first, second := resolve(), resolve()
+    main, outdoors := first["sportsstore"], first["outdoors"]
+    if !strings.HasSuffix(outdoors.repository.GetStringDefault("sql:connection_str", 
+            ""), "outdoors.db") || main.repository.DB == outdoors.repository.DB {
+        t.Fatal("Expected tenant database to be selected")
+    }
+    if outdoors.mailer.(*mail.MemoryMailer).From != "orders@outdoors.example" ||
+            main.mailer.(*mail.MemoryMailer).From == "orders@outdoors.example" {
+        t.Fatal("Expected tenant mail settings")
+    }
+    if second["outdoors"].mailer != outdoors.mailer || 
+            second["sportsstore"].mailer != main.mailer {
+        t.Fatal("Expected one mailer per tenant")
+    }
+    if second["outdoors"].media != outdoors.media || main.media == outdoors.media {
+        t.Fatal("Expected one media store per tenant")
+    }
+    flag := features.Flag{ Name: store.NEW_CHECKOUT_FEATURE, Enabled: true, 
+        Percentage: 100 }
+    if err := outdoors.flags.SaveFlag(h.Context(), flag); err != nil {
+        t.Fatal(err)
+    }
+    for name, services := range second {
+        flags, err := services.flags.GetFlags(h.Context())
+        if err != nil || len(flags) != 1 {
+            t.Fatalf("Unexpected flags for %v: %+v %v", name, flags, err)
+        }
+        if flags[0].Enabled != (name == "outdoors") {
+            t.Fatalf("Expected flag override only for outdoors, got %+v in %v",
+                flags[0], name)
+        }
+    }
+}
+
+func TestInvokeHandler(t *testing.T) {
+    h := newStoreHarness(t)
+    platformtest.AssertTemplate(t, h.Invoke(store.CartHandler.GetCart), "cart.html")
+    platformtest.AssertRedirectAction(t,
+        h.Invoke(store.CartHandler.PostAddToCart, store.CartProductReference{ ID: 1 }),
+        "/cart")
+}
+
+type bindingRequest struct {
+    models.ShippingDetails
+    Category *models.Category
+    Categories []int `form:"category"`
+    Lines []models.ProductSelection
+    Tags map[string]string
+    Shipped time.Time
+    Express bool
+    Page int `query:"page"`
+}
+
+type bindingHandler struct {}
+
+func (handler bindingHandler) PostBind(req bindingRequest) actionresults.ActionResult {
+    return actionresults.NewJsonAction(req)
+}
+
+func (handler bindingHandler) GetBind(id int, req bindingRequest) actionresults.ActionResult {
+    req.Category = &models.Category{ ID: id }
+    return actionresults.NewJsonAction(req)
+}
+
+func TestFormBinding(t *testing.T) {
+    h := newStoreHarness(t)
+    h.Build(&basic.ServicesComponent{},
+        handling.NewRouter(handling.HandlerEntry{ "test", bindingHandler{} }))
+    client := h.NewClient()
+    response := client.PostForm("/test/bind?page=3&name=Ignored", url.Values{
+        "name": { "Bob" }, "ShippingDetails.City": { "Town" },
+        "category.id": { "2" }, "category.categoryname": { "Soccer" },
+        "category": { "1", "3" }, "lines[1].quantity": { "4" },
+        "lines[1].product.id": { "7" }, "tags[Colour]": { "red" },
+        "shipped": { "2026-03-01" }, "express": { "on" }, "page": { "9" },
+    })
+    action, _ := response.Action()
+    req := platformtest.AssertJson(t, action.Result).(bindingRequest)
+    if req.Name != "Bob" || req.City != "Town" || req.Page != 3 || !req.Express {
+        t.Fatalf("Unexpected simple values: %+v", req)
+    }
+    if req.Category == nil || req.Category.ID != 2 || 
+            req.Category.CategoryName != "Soccer" {
+        t.Fatalf("Unexpected nested value: %+v", req.Category)
+    }
+    if len(req.Categories) != 2 || req.Categories[1] != 3 {
+        t.Fatalf("Unexpected slice: %v", req.Categories)
+    }
+    if len(req.Lines) != 2 || req.Lines[1].Quantity != 4 || 
+            req.Lines[1].Product.ID != 7 {
+        t.Fatalf("Unexpected indexed values: %+v", req.Lines)
+    }
+    if req.Tags["Colour"] != "red" || req.Shipped.Day() != 1 {
+        t.Fatalf("Unexpected map or time values: %v %v", req.Tags, req.Shipped)
+    }
+
+    action, _ = client.Get("/test/bind/5?page=2&category=4&city=Town").Action()
+    req = platformtest.AssertJson(t, action.Result).(bindingRequest)
+    if req.Category.ID != 5 || req.Page != 2 || req.City != "Town" || 
+            len(req.Categories) != 1 {
+        t.Fatalf("Unexpected query values: %+v", req)
+    }
+
+    response = client.Post("/test/bind?page=4", "application/json; charset=utf-8",
+        strings.NewReader(`{ "Name": "Alice", "Page": 1, "Categories": [ 6 ] }`))
+    action, _ = response.Action()
+    req = platformtest.AssertJson(t, action.Result).(bindingRequest)
+    if req.Name != "Alice" || req.Page != 4 || len(req.Categories) != 1 {
+        t.Fatalf("Unexpected JSON values: %+v", req)
+    }
+    platformtest.AssertStatus(t, 
+        client.PostForm("/test/bind", url.Values{ "shipped": { "soon" } }),
+        http.StatusInternalServerError)
+}
+
+func TestReportCategoryFilter(t *testing.T) {
+    h := newStoreHarness(t)
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { "Administrator" } }))
+    response := admin.Get("/admin/salesreport?category=1&category=3")
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    if !strings.Contains(response.Body.String(), "category=1&amp;category=3") {
+        t.Fatal("Expected category filter to be preserved in export links")
+    }
+}
+
+func TestApiRouteGroup(t *testing.T) {
+    h := newStoreHarness(t, map[string]interface{} { "api:rateLimit:requests": 2 })
+    client := h.NewClient()
+    platformtest.AssertStatus(t, client.Get("/api/product/1"), http.StatusOK)
+    response := client.Get("/api/product/1000")
+    platformtest.AssertStatus(t, response, http.StatusNotFound)
+    if response.Header().Get("Content-Type") != "application/json" ||
+            !strings.Contains(response.Body.String(), `"status":404`) {
+        t.Fatalf("Expected JSON error, got %v", response.Body.String())
+    }
+    response = client.Get("/api/product/1")
+    platformtest.AssertStatus(t, response, http.StatusTooManyRequests)
+    if response.Header().Get("Retry-After") == "" {
+        t.Fatal("Expected Retry-After header")
+    }
+    platformtest.AssertStatus(t, client.Get("/products/0/1"), http.StatusOK)
+}
+
+func TestApiWritePolicies(t *testing.T) {
+    h := newStoreHarness(t)
+    put := func(client *platformtest.Client, body string) *platformtest.Response {
+        req := httptest.NewRequest(http.MethodPut, "/api/product", strings.NewReader(body))
+        req.Header.Set("Content-Type", "application/json")
+        return client.Do(req)
+    }
+    created := `{ "Name": "Paddle", "Price": 12, "CategoryID": 1 }`
+    updated := `{ "ID": 1, "Name": "Kayak", "Price": 1, "CategoryID": 1 }`
+
+    anonymous := h.NewClient()
+    platformtest.AssertRedirect(t, anonymous.Post("/api/product", "application/json",
+        strings.NewReader(created)), "/signin")
+    platformtest.AssertRedirect(t, put(anonymous, updated), "/signin")
+    fulfilment := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 2, Name: "Fred", Roles: []string { auth.FULFILMENT_ROLE } }))
+    platformtest.AssertStatus(t, fulfilment.Post("/api/product", "application/json",
+        strings.NewReader(created)), http.StatusForbidden)
+    platformtest.AssertStatus(t, put(fulfilment, updated), http.StatusForbidden)
+
+    var repo models.RepositoryV2
+    h.GetService(&repo)
+    if kayak, err := repo.GetProduct(h.Context(), 1); err != nil || kayak.Price != 275 {
+        t.Fatalf("Expected rejected writes to leave product unchanged: %+v %v", 
+            kayak, err)
+    }
+    platformtest.AssertStatus(t, anonymous.Get("/api/product/1"), http.StatusOK)
+
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { auth.ADMIN_ROLE } }))
+    platformtest.AssertStatus(t, admin.Post("/api/product", "application/json",
+        strings.NewReader(created)), http.StatusOK)
+    platformtest.AssertStatus(t, put(admin, updated), http.StatusOK)
+    if kayak, err := repo.GetProduct(h.Context(), 1); err != nil || kayak.Price != 1 {
+        t.Fatalf("Expected admin write to be saved: %+v %v", kayak, err)
+    }
+}
+
+func TestSecurityHeaders(t *testing.T) {
+    h := newStoreHarness(t)
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { auth.ADMIN_ROLE } }))
+    response := admin.Do(httptest.NewRequest(http.MethodGet, 
+        "https://example.com/admin/section/Orders", nil))
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    for name, val := range map[string]string {
+        "X-Frame-Options": "DENY",
+        "X-Content-Type-Options": "nosniff",
+        "Referrer-Policy": "strict-origin-when-cross-origin",
+        "Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+    } {
+        if response.Header().Get(name) != val {
+            t.Fatalf("Expected %v header %v, got %v", name, val, 
+                response.Header().Get(name))
+        }
+    }
+    policy := response.Header().Get("Content-Security-Policy")
+    start := strings.Index(policy, "'nonce-")
+    if start == -1 {
+        t.Fatalf("Expected nonce in content security policy: %v", policy)
+    }
+    nonce := policy[start + 7 : start + 7 + strings.Index(policy[start + 7:], "'")]
+    if !strings.Contains(response.Body.String(), `<script nonce="` + nonce + `">`) {
+        t.Fatal("Expected script nonce to match content security policy")
+    }
+    next := admin.Get("/admin/section/Orders")
+    if next.Header().Get("Strict-Transport-Security") != "" ||
+            strings.Contains(next.Header().Get("Content-Security-Policy"), nonce) {
+        t.Fatal("Expected HSTS only over TLS and a new nonce for each request")
+    }
+}
+
+func TestApiCors(t *testing.T) {
+    h := newStoreHarness(t)
+    client := h.NewClient()
+    preflight := func(origin, method, headers string) *platformtest.Response {
+        req := httptest.NewRequest(http.MethodOptions, "/api/product/1", nil)
+        req.Header.Set("Origin", origin)
+        req.Header.Set("Access-Control-Request-Method", method)
+        req.Header.Set("Access-Control-Request-Headers", headers)
+        return client.Do(req)
+    }
+    response := preflight("http://localhost:3000", "PUT", "content-type")
+    platformtest.AssertStatus(t, response, http.StatusNoContent)
+    if response.Header().Get("Access-Control-Allow-Origin") != "http://localhost:3000" ||
+            response.Header().Get("Access-Control-Allow-Credentials") != "" ||
+            !strings.Contains(response.Header().Get("Access-Control-Allow-Methods"), 
+                "PUT") ||
+            response.Header().Get("Access-Control-Max-Age") != "600" {
+        t.Fatalf("Unexpected preflight headers: %v", response.Header())
+    }
+    platformtest.AssertStatus(t, preflight("https://evil.example.com", "GET", ""),
+        http.StatusForbidden)
+    platformtest.AssertStatus(t, preflight("http://localhost:3000", "DELETE", ""),
+        http.StatusForbidden)
+    platformtest.AssertStatus(t, preflight("http://localhost:3000", "GET", 
+        "X-Custom"), http.StatusForbidden)
+
+    req := httptest.NewRequest(http.MethodGet, "/api/product/1", nil)
+    req.Header.Set("Origin", "http://localhost:3000")
+    response = client.Do(req)
+    platformtest.AssertStatus(t, response, http.StatusOK)
+    if response.Header().Get("Access-Control-Allow-Origin") != "http://localhost:3000" ||
+            !strings.Contains(response.Header().Get("Access-Control-Expose-Headers"),
+                "X-RateLimit-Remaining") {
+        t.Fatalf("Unexpected CORS headers: %v", response.Header())
+    }
+    if client.Get("/products/0/1").Header().Get("Access-Control-Allow-Origin") != "" {
+        t.Fatal("Expected CORS headers only for API routes")
+    }
+}
+
+func TestFulfilmentPolicies(t *testing.T) {
+    h := newStoreHarness(t)
+    client := h.NewClient()
+    platformtest.AssertRedirect(t, client.PostForm("/signin", url.Values{
+        "username": { "Fred" }, "password": { "mysecret" } }), "/admin/section/")
+
+    orders := client.Get("/admin/section/Orders")
+    platformtest.AssertStatus(t, orders, http.StatusOK)
+    if body := orders.Body.String(); !strings.Contains(body, "/admin/ordertoggle") ||
+            strings.Contains(body, "/admin/section/Products") {
+        t.Fatal("Expected order shipping controls without product section")
+    }
+    products := client.Get("/admin/section/Products")
+    if !strings.Contains(products.Body.String(), "not permitted") {
+        t.Fatal("Expected products section to be hidden")
+    }
+    platformtest.AssertStatus(t, client.PostForm("/admin/productsave", url.Values{
+        "id": { "1" }, "name": { "Kayak" }, "price": { "1" } }), http.StatusForbidden)
+
+    toggle := url.Values{ "id": { "1" } }
+    platformtest.AssertRedirect(t, client.PostForm("/admin/ordertoggle", toggle), 
+        "/admin/section/Orders")
+    platformtest.AssertStatus(t, client.PostForm("/admin/ordertoggle", toggle), 
+        http.StatusForbidden)
+
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { auth.ADMIN_ROLE } }))
+    platformtest.AssertRedirect(t, admin.PostForm("/admin/ordertoggle", toggle), 
+        "/admin/section/Orders")
+}
+
+func TestOrderFeed(t *testing.T) {
+    h := newStoreHarness(t)
+    var broker pubsub.Broker
+    h.GetService(&broker)
+    user := auth.NewAccountUser(models.Account{ ID: 1, Name: "Alice", 
+        Roles: []string { auth.ADMIN_ROLE } })
+    shutdown := make(chan struct{})
+    req := httptest.NewRequest(http.MethodGet, "/admin/orderfeed", nil)
+    req = req.WithContext(pipeline.WithShutdown(req.Context(), shutdown))
+    feed := make(chan *platformtest.Response)
+    go func() {
+        feed <- h.NewClient().AuthenticateAs(user).Do(req)
+    }()
+    for deadline := time.Now().Add(5 * time.Second); 
+            broker.(*pubsub.MemoryBroker).SubscriberCount(models.OrderEventsTopic) == 0; {
+        if time.Now().After(deadline) {
+            t.Fatal("Expected the feed to subscribe to order events")
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    platformtest.AssertRedirect(t, h.NewClient().AuthenticateAs(user).PostForm(
+        "/admin/ordertoggle", url.Values{ "id": { "1" } }), "/admin/section/Orders")
+    close(shutdown)
+    var response *platformtest.Response
+    select {
+        case response = <- feed:
+        case <- time.After(5 * time.Second):
+            t.Fatal("Expected the feed to end on shutdown")
+    }
+    body := response.Body.String()
+    if response.Header().Get("Content-Type") != "text/event-stream" ||
+            !strings.HasPrefix(body, ": connected") || 
+            !strings.Contains(body, "id: 1\nevent: ") {
+        t.Fatalf("Unexpected feed: %q", body)
+    }
+    if count := broker.(*pubsub.MemoryBroker).SubscriberCount(
+            models.OrderEventsTopic); count != 0 {
+        t.Fatalf("Expected the feed to unsubscribe, got %v subscribers", count)
+    }
+}
+
+func TestScaffoldShippingMethods(t *testing.T) {
+    h := newStoreHarness(t)
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { auth.ADMIN_ROLE } }))
+    platformtest.AssertRedirect(t, admin.Get("/admin/section/Shipping"), 
+        "/admin/scaffold/list/shipping")
+
+    list := admin.Get("/admin/scaffold/list/shipping?sort=Price&desc=true")
+    platformtest.AssertStatus(t, list, http.StatusOK)
+    body := list.Body.String()
+    if !strings.Contains(body, "Delivery Days") || 
+            strings.Index(body, "Express") > strings.Index(body, "Standard") {
+        t.Fatal("Expected shipping methods sorted by descending price")
+    }
+
+    invalid := admin.PostForm("/admin/scaffold/save", url.Values{
+        "_model": { "shipping" }, "name": { "" }, "price": { "-1" } })
+    platformtest.AssertStatus(t, invalid, http.StatusOK)
+    if body := invalid.Body.String(); !strings.Contains(body, "A value is required") ||
+            !strings.Contains(body, "The minimum value is 0") {
+        t.Fatal("Expected validation errors")
+    }
+
+    platformtest.AssertRedirect(t, admin.PostForm("/admin/scaffold/save", url.Values{
+        "_model": { "shipping" }, "name": { "Freight" }, "price": { "49.5" },
+        "deliverydays": { "10" }, "active": { "on" } }), 
+        "/admin/scaffold/list/shipping")
+    filtered := admin.Get("/admin/scaffold/list/shipping?filter=freight")
+    if body := filtered.Body.String(); !strings.Contains(body, "49.50") || 
+            strings.Contains(body, "Express") {
+        t.Fatal("Expected filtered list with new shipping method")
+    }
+    edit := admin.Get("/admin/scaffold/edit/shipping/3")
+    platformtest.AssertStatus(t, edit, http.StatusOK)
+    if !strings.Contains(edit.Body.String(), `value="Freight"`) {
+        t.Fatal("Expected edit form to be populated")
+    }
+    platformtest.AssertRedirect(t, admin.PostForm("/admin/scaffold/save", url.Values{
+        "_model": { "shipping" }, "_id": { "3" }, "name": { "Pallet" }, 
+        "price": { "49.5" }, "deliverydays": { "10" } }), 
+        "/admin/scaffold/list/shipping")
+    platformtest.AssertRedirect(t, admin.PostForm("/admin/scaffold/delete", url.Values{
+        "_model": { "shipping" }, "_id": { "3" } }), "/admin/scaffold/list/shipping")
+    platformtest.AssertStatus(t, admin.Get("/admin/scaffold/edit/shipping/3"),
+        http.StatusNotFound)
+    if actions := fmt.Sprint(auditActions(t, h, "shipping_method")); actions !=
+            "[create:3:Alice update:3:Alice delete:3:Alice]" {
+        t.Fatalf("Unexpected shipping audit entries: %v", actions)
+    }
+
+    fred := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 2, Name: "Fred", Roles: []string { auth.FULFILMENT_ROLE } }))
+    platformtest.AssertStatus(t, fred.Get("/admin/scaffold/list/shipping"), 
+        http.StatusForbidden)
+    platformtest.AssertStatus(t, admin.Get("/admin/scaffold/list/unknown"), 
+        http.StatusNotFound)
+}
+
+type failingAccounts struct {
+    models.AccountRepository
+}
+
+func (failingAccounts) GetAccountByName(name string) (models.Account, bool) {
+    return models.Account{}, false
+}
+
+func (failingAccounts) SaveAccount(*models.Account) error {
+    return models.ErrConflict
+}
+
+func TestCreateAdminSaveError(t *testing.T) {
+    err := runCreateAdminCommand([]string { "--name=Ops", "--password=opsecret1" },
+        failingAccounts{}, 
+        validation.NewDefaultValidator(validation.DefaultValidators()))
+    if !errors.Is(err, models.ErrConflict) {
+        t.Fatalf("Expected save error to be returned, got %v", err)
+    }
+}
+
+func TestManagementCommands(t *testing.T) {
+    h := newStoreHarness(t, map[string]interface{} { 
+        "admin:database:allowReset": false })
+    if err := runCommand([]string { "seed", "--reset" }); err != nil {
+        t.Fatal(err)
+    }
+    if err := runCommand([]string { "create-admin", "--name=Ops", 
+            "--password=short" }); err == nil {
+        t.Fatal("Expected password validation error")
+    }
+    if err := runCommand([]string { "create-admin", "--name=Ops", 
+            "--email=ops@example.com", "--password=opsecret1" }); err != nil {
+        t.Fatal(err)
+    }
+    client := h.NewClient()
+    platformtest.AssertRedirect(t, client.PostForm("/signin", url.Values{
+        "username": { "Ops" }, "password": { "opsecret1" } }), "/admin/section/")
+    platformtest.AssertStatus(t, client.PostForm("/admin/databaseinit", nil), 
+        http.StatusForbidden)
+
+    out := filepath.Join(t.TempDir(), "categories.json")
+    if err := runCommand([]string { "export", "--format=json", "--out=" + out, 
+            "categories" }); err != nil {
+        t.Fatal(err)
+    }
+    if data, err := os.ReadFile(out); err != nil || 
+            !strings.Contains(string(data), "Watersports") {
+        t.Fatalf("Unexpected export: %v %v", string(data), err)
+    }
+    if err := runCommand([]string { "unknown" }); err == nil {
+        t.Fatal("Expected error for unknown command")
+    }
+
+    aliases := 0
+    for _, route := range handling.DescribeRoutes(createComponents()...) {
+        if route.Handler == "Alias" && route.Target == "/products/0/1" {
+            aliases++
+        }
+    }
+    if aliases != 2 {
+        t.Fatalf("Expected 2 alias routes, got %v", aliases)
+    }
+}
+
+func TestHealthEndpoints(t *testing.T) {
+    h := newStoreHarness(t, map[string]interface{} {
+        "health:checks:sql:timeout": "30s" })
+    client := h.NewClient()
+    live := client.Get("/healthz")
+    platformtest.AssertStatus(t, live, http.StatusOK)
+    if body := live.Body.String(); live.Header().Get("Content-Type") != 
+            "application/json" || !strings.Contains(body, `"name":"sessions"`) ||
+            strings.Contains(body, `"name":"sql"`) {
+        t.Fatalf("Expected liveness report, got %v", body)
+    }
+    ready := client.Get("/readyz")
+    platformtest.AssertStatus(t, ready, http.StatusOK)
+    for _, check := range []string { "sql", "templates", "sessions" } {
+        if !strings.Contains(ready.Body.String(), `"name":"` + check + `","status":"up"`) {
+            t.Fatalf("Expected %v check in readiness report, got %v", check, 
+                ready.Body.String())
+        }
+    }
+
+    var monitor *health.Monitor
+    h.GetService(&monitor)
+    monitor.SetReady(false)
+    platformtest.AssertStatus(t, client.Get("/readyz"), http.StatusServiceUnavailable)
+    platformtest.AssertStatus(t, client.Get("/healthz"), http.StatusOK)
+    monitor.SetReady(true)
+
+    admin := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 1, Name: "Alice", Roles: []string { auth.ADMIN_ROLE } }))
+    diagnostics := admin.Get("/admin/section/Diagnostics")
+    platformtest.AssertStatus(t, diagnostics, http.StatusOK)
+    if body := diagnostics.Body.String(); !strings.Contains(body, "Goroutines") ||
+            !strings.Contains(body, "sessions:key") || 
+            strings.Contains(body, "MY_SESSION_KEY") {
+        t.Fatal("Expected diagnostics with redacted secrets")
+    }
+    fred := h.NewClient().AuthenticateAs(auth.NewAccountUser(models.Account{
+        ID: 2, Name: "Fred", Roles: []string { auth.FULFILMENT_ROLE } }))
+    if strings.Contains(fred.Get("/admin/section/Diagnostics").Body.String(), 
+            "Goroutines") {
+        t.Fatal("Expected diagnostics to require admin access")
+    }
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+    h := newStoreHarness(t, map[string]interface{} {
+        "health:checks:sql:timeout": "1ns" })
+    report := h.NewClient().Get("/readyz")
+    platformtest.AssertStatus(t, report, http.StatusServiceUnavailable)
+    if !strings.Contains(report.Body.String(), `"name":"sql","status":"down"`) {
+        t.Fatalf("Expected sql check to time out, got %v", report.Body.String())
+    }
+    platformtest.AssertStatus(t, h.NewClient().Get("/products/0/1"), http.StatusOK)
+}
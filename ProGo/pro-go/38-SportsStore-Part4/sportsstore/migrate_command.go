@@ -0,0 +1,73 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "platform/config"
+    "platform/logging"
+    "sportsstore/models/repo"
+    "strconv"
+    "text/tabwriter"
+)
+
+func runMigrateCommand(args []string, cfg config.Configuration, 
+        logger logging.Logger) error {
+    migrator, err := repo.OpenMigrator(cfg, logger)
+    if err != nil {
+        return err
+    }
+    ctx := context.Background()
+    command := "status"
+    if len(args) > 0 {
+        command = args[0]
+    }
+    switch command {
+        case "status":
+            return printMigrationStatus(ctx, migrator)
+        case "up":
+            count, err := migrator.Up(ctx)
+            fmt.Printf("Applied %v migration(s)\n", count)
+            return err
+        case "down", "rollback":
+            steps := 1
+            if len(args) > 1 {
+                if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+                    return fmt.Errorf("Invalid number of steps: %v", args[1])
+                }
+            }
+            count, err := migrator.Down(ctx, steps)
+            fmt.Printf("Rolled back %v migration(s)\n", count)
+            return err
+        case "verify":
+            if err = migrator.Verify(ctx); err == nil {
+                fmt.Println("All applied migrations match their files")
+            }
+            return err
+        default:
+            return usageError("migrate")
+    }
+}
+
+func printMigrationStatus(ctx context.Context, migrator *repo.Migrator) error {
+    status, err := migrator.Status(ctx)
+    if err != nil {
+        return err
+    }
+    writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+    for _, s := range status {
+        state, appliedAt := "pending", ""
+        if s.Applied {
+            state = "applied"
+            appliedAt = s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+        }
+        if s.Modified {
+            state = "modified"
+        } else if s.Missing {
+            state = "missing"
+        }
+        fmt.Fprintf(writer, "%04d\t%v\t%v\t%v\n", s.Version, s.Name, state, appliedAt)
+    }
+    return writer.Flush()
+}
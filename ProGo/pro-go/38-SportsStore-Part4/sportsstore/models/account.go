@@ -0,0 +1,33 @@
+package models
+
+import (
+    "context"
+    "time"
+)
+
+type Account struct {
+    ID int
+    Name string
+    Email string
+    PasswordHash string `json:"-"`
+    Roles []string
+}
+
+type AccountRepository interface {
+
+    GetAccount(id int) (account Account, found bool)
+    GetAccountByName(name string) (account Account, found bool)
+    SaveAccount(*Account) error
+
+    GetShippingDetails(accountId int) (details ShippingDetails, found bool)
+    SaveShippingDetails(accountId int, details ShippingDetails)
+
+    GetAccountOrders(accountId int) []Order
+
+    GetCartLines(accountId int) []ProductSelection
+    SaveCartLines(accountId int, lines []ProductSelection)
+}
+
+type AbandonedCartRepository interface {
+    DeleteAbandonedCartLines(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+    "context"
+    "time"
+)
+
+type AuditChange struct {
+    Field string
+    Before, After string
+}
+
+type AuditEntry struct {
+    ID int
+    Time time.Time
+    ActorID int
+    ActorName string
+    Action string
+    Entity string
+    EntityID int
+    Changes []AuditChange
+    Method, Path string
+    RemoteAddr, UserAgent string
+}
+
+type AuditFilter struct {
+    Actor, Action, Entity string
+    EntityID int
+    From, To time.Time
+    Limit int
+}
+
+type AuditRepository interface {
+
+    SaveAuditEntry(ctx context.Context, entry *AuditEntry) error
+    GetAuditEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+}
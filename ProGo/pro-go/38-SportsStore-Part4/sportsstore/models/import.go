@@ -0,0 +1,51 @@
+package models
+
+const (
+    ImportCreate = "create"
+    ImportUpdate = "update"
+    ImportInvalid = "invalid"
+)
+
+type ImportRow struct {
+    Row int
+    Action string
+    ID int
+    Name string
+    Error string
+}
+
+type ImportSummary struct {
+    Rows []ImportRow
+    Created, Updated, Failed int
+    DryRun bool
+    Committed bool
+    AuditError string
+}
+
+func (summary *ImportSummary) Add(row ImportRow) {
+    switch row.Action {
+        case ImportCreate:
+            summary.Created++
+        case ImportUpdate:
+            summary.Updated++
+        default:
+            summary.Failed++
+    }
+    summary.Rows = append(summary.Rows, row)
+}
+
+type BulkRepository interface {
+
+    ImportCategories(rows []ImportCategory, dryRun bool) ImportSummary
+    ImportProducts(rows []ImportProduct, dryRun bool) ImportSummary
+}
+
+type ImportCategory struct {
+    Row int
+    Category
+}
+
+type ImportProduct struct {
+    Row int
+    Product
+}
@@ -1,14 +1,34 @@
 package models
 
+import "time"
+
 type Order struct {
     ID int
     ShippingDetails
     Products []ProductSelection
     Shipped bool
+    AccountID int
+    Created time.Time
+    ShippingMethodID int
+    ShippingMethod string
+    PromotionCode string
+    OrderTotals
+}
+
+type OrderTotals struct {
+    Subtotal float64
+    Shipping float64
+    Discount float64
+    Tax float64
+    Total float64
+    TaxName string
+    TaxRate float64
+    TaxIncluded bool
 }
 
 type ShippingDetails struct {
     Name string `validation:"required"`
+    Email string `validation:"required,email"`
     StreetAddr string `validation:"required"`
     City string `validation:"required"`
     State string `validation:"required"`
@@ -20,3 +40,21 @@ type ProductSelection struct{
     Quantity int
     Product
 }
+
+func (ps ProductSelection) GetLineTotal() float64 {
+    return ps.Price * float64(ps.Quantity)
+}
+
+func (o Order) GetSubtotal() (total float64) {
+    for _, ps := range o.Products {
+        total += ps.GetLineTotal()
+    }
+    return
+}
+
+func (o Order) GetTotal() float64 {
+    if o.Subtotal == 0 && o.Total == 0 {
+        return o.GetSubtotal()
+    }
+    return o.Total
+}
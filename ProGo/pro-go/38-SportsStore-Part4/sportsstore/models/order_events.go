@@ -0,0 +1,13 @@
+package models
+
+const OrderEventsTopic = "orders"
+
+const (
+    OrderCreatedEvent = "order-created"
+    OrderUpdatedEvent = "order-updated"
+)
+
+type OrderEvent struct {
+    Kind string
+    Order Order
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+    "context"
+    "time"
+)
+
+const (
+    OrderConfirmationEmail = "order_confirmation"
+    OrderShippedEmail = "order_shipped"
+)
+
+const (
+    EmailPending = "pending"
+    EmailSending = "sending"
+    EmailSent = "sent"
+    EmailFailed = "failed"
+)
+
+type OutboxEmail struct {
+    ID int
+    Kind string
+    OrderID int
+    Recipient string
+    Created time.Time
+    Status string
+    Attempts int
+    NextAttempt time.Time
+    LastError string
+}
+
+type OutboxRepository interface {
+    GetPendingEmails(ctx context.Context, due time.Time, limit int) ([]OutboxEmail, error)
+    ClaimEmail(ctx context.Context, email *OutboxEmail, now time.Time, 
+        lease time.Duration) (bool, error)
+    UpdateEmail(ctx context.Context, email *OutboxEmail) error
+    GetOrder(ctx context.Context, id int) (Order, error)
+}
@@ -0,0 +1,35 @@
+package models
+
+import "context"
+
+type ShippingRate struct {
+    ID int
+    MethodID int `validation:"min:1"`
+    Country string
+    State string
+    MaxWeight float64 `validation:"min:0"`
+    Price float64 `validation:"min:0"`
+}
+
+type TaxRate struct {
+    ID int
+    Name string `validation:"required"`
+    Country string `validation:"required"`
+    State string
+    Rate float64 `validation:"min:0"`
+    Inclusive bool
+    TaxShipping bool
+}
+
+type PricingRepository interface {
+
+    GetShippingRates(ctx context.Context) ([]ShippingRate, error)
+    GetShippingRate(ctx context.Context, id int) (ShippingRate, error)
+    SaveShippingRate(ctx context.Context, rate *ShippingRate) error
+    DeleteShippingRate(ctx context.Context, id int) error
+
+    GetTaxRates(ctx context.Context) ([]TaxRate, error)
+    GetTaxRate(ctx context.Context, id int) (TaxRate, error)
+    SaveTaxRate(ctx context.Context, rate *TaxRate) error
+    DeleteTaxRate(ctx context.Context, id int) error
+}
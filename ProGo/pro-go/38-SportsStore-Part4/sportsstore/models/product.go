@@ -5,5 +5,9 @@ type Product struct {
     Name string
     Description string 
     Price float64       
+    Image string
+    Weight float64
+    Rating float64
+    ReviewCount int
     *Category
 }
@@ -0,0 +1,59 @@
+package models
+
+import (
+    "errors"
+    "fmt"
+)
+
+var ErrInvalidQuery = errors.New("The query is not valid")
+
+const (
+    SortByID = "id"
+    SortByName = "name"
+    SortByPrice = "price"
+    SortByRating = "rating"
+)
+
+type ProductKey struct {
+    ID int
+    Name string
+    Price float64
+    Rating float64
+}
+
+func (p Product) Key() ProductKey {
+    return ProductKey{ ID: p.ID, Name: p.Name, Price: p.Price, Rating: p.Rating }
+}
+
+func ValidSortBy(sortBy string) bool {
+    return sortBy == SortByID || sortBy == SortByName || sortBy == SortByPrice ||
+        sortBy == SortByRating
+}
+
+type ProductQuery struct {
+    CategoryID int
+    SortBy string
+    After, Before *ProductKey
+    Limit int
+    IncludeTotal bool
+}
+
+func (q ProductQuery) Validate() error {
+    switch {
+        case !ValidSortBy(q.SortBy):
+            return fmt.Errorf("%w: cannot sort by %v", ErrInvalidQuery, q.SortBy)
+        case q.After != nil && q.Before != nil:
+            return fmt.Errorf("%w: cannot page both before and after a product",
+                ErrInvalidQuery)
+        case q.Limit < 1:
+            return fmt.Errorf("%w: page size must be positive", ErrInvalidQuery)
+    }
+    return nil
+}
+
+type ProductKeysetPage struct {
+    Products []Product
+    HasNext, HasPrev bool
+    Total int
+    TotalIncluded bool
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+    "context"
+    "time"
+)
+
+type Promotion struct {
+    ID int
+    Code string `validation:"required"`
+    Description string `scaffold:"nolist"`
+    DiscountPercent int `validation:"min:0"`
+    Starts time.Time
+    Ends time.Time
+    Active bool
+}
+
+func (p Promotion) IsCurrent(now time.Time) bool {
+    return p.Active && (p.Starts.IsZero() || !now.Before(p.Starts)) &&
+        (p.Ends.IsZero() || now.Before(p.Ends.AddDate(0, 0, 1)))
+}
+
+type PromotionRepository interface {
+
+    GetPromotions(ctx context.Context) ([]Promotion, error)
+    GetPromotion(ctx context.Context, id int) (Promotion, error)
+    SavePromotion(ctx context.Context, promotion *Promotion) error
+    DeletePromotion(ctx context.Context, id int) error
+}
@@ -0,0 +1,248 @@
+package repo
+
+import (
+    "context"
+    "fmt"
+    "platform/cache"
+    "platform/config"
+    "sportsstore/models"
+    "time"
+)
+
+const (
+    productKeys = "product:"
+    categoryKeys = "category:"
+)
+
+type RepositoryCache struct {
+    *cache.Cache
+    config config.Configuration
+}
+
+func NewRepositoryCache(config config.Configuration) *RepositoryCache {
+    rc := &RepositoryCache{
+        Cache: cache.New(config.GetIntDefault("cache:size", 1000)),
+        config: config,
+    }
+    if timeout, err := time.ParseDuration(config.GetStringDefault(
+            "cache:loadTimeout", "30s")); err == nil {
+        rc.SetLoadTimeout(timeout)
+    }
+    return rc
+}
+
+func (rc *RepositoryCache) TTL(method string) time.Duration {
+    val, found := rc.config.GetString("cache:ttl:" + method)
+    if !found {
+        val = rc.config.GetStringDefault("cache:ttl:default", "1m")
+    }
+    if duration, err := time.ParseDuration(val); err == nil {
+        return duration
+    }
+    return 0
+}
+
+type CachingRepository struct {
+    models.RepositoryV2
+    Cache *RepositoryCache
+}
+
+func (repo *CachingRepository) load(ctx context.Context, method, key string,
+        loader func(context.Context) (interface{}, error)) (interface{}, error) {
+    return repo.Cache.GetOrLoad(ctx, key, repo.Cache.TTL(method), loader)
+}
+
+func (repo *CachingRepository) GetProduct(ctx context.Context,
+        id int) (models.Product, error) {
+    val, err := repo.load(ctx, "GetProduct", fmt.Sprint(productKeys, "id:", id),
+        func(ctx context.Context) (interface{}, error) {
+            return repo.RepositoryV2.GetProduct(ctx, id)
+        })
+    if err != nil {
+        return models.Product{}, err
+    }
+    return copyProduct(val.(models.Product)), nil
+}
+
+func (repo *CachingRepository) GetProducts(ctx context.Context) ([]models.Product,
+        error) {
+    val, err := repo.load(ctx, "GetProducts", productKeys + "all",
+        func(ctx context.Context) (interface{}, error) {
+            return repo.RepositoryV2.GetProducts(ctx)
+        })
+    if err != nil {
+        return nil, err
+    }
+    return copyProducts(val.([]models.Product)), nil
+}
+
+type productPage struct {
+    products []models.Product
+    total int
+}
+
+func (repo *CachingRepository) GetProductPage(ctx context.Context, page,
+        pageSize int) ([]models.Product, int, error) {
+    return repo.loadPage(ctx, "GetProductPage",
+        fmt.Sprint(productKeys, "page:", page, ":", pageSize),
+        func(ctx context.Context) ([]models.Product, int, error) {
+            return repo.RepositoryV2.GetProductPage(ctx, page, pageSize)
+        })
+}
+
+func (repo *CachingRepository) GetProductPageCategory(ctx context.Context,
+        categoryId, page, pageSize int) ([]models.Product, int, error) {
+    return repo.loadPage(ctx, "GetProductPageCategory",
+        fmt.Sprint(productKeys, "category:", categoryId, ":", page, ":", pageSize),
+        func(ctx context.Context) ([]models.Product, int, error) {
+            return repo.RepositoryV2.GetProductPageCategory(ctx, categoryId,
+                page, pageSize)
+        })
+}
+
+func (repo *CachingRepository) GetProductPageSorted(ctx context.Context,
+        categoryId int, sortBy string, page, pageSize int) ([]models.Product, int, 
+            error) {
+    return repo.loadPage(ctx, "GetProductPageSorted",
+        fmt.Sprint(productKeys, "sorted:", sortBy, ":", categoryId, ":", page, ":", 
+            pageSize),
+        func(ctx context.Context) ([]models.Product, int, error) {
+            return repo.RepositoryV2.GetProductPageSorted(ctx, categoryId, sortBy,
+                page, pageSize)
+        })
+}
+
+func (repo *CachingRepository) loadPage(ctx context.Context, method, key string,
+        loader func(context.Context) ([]models.Product, int, error)) ([]models.Product,
+            int, error) {
+    val, err := repo.load(ctx, method, key, func(ctx context.Context) (interface{},
+            error) {
+        products, total, err := loader(ctx)
+        return productPage{ products: products, total: total }, err
+    })
+    if err != nil {
+        return nil, 0, err
+    }
+    page := val.(productPage)
+    return copyProducts(page.products), page.total, nil
+}
+
+func (repo *CachingRepository) GetProductsKeyset(ctx context.Context,
+        query models.ProductQuery) (models.ProductKeysetPage, error) {
+    key := fmt.Sprintf("%vkeyset:%v:%v:%v:%v", productKeys, query.CategoryID,
+        query.SortBy, query.Limit, query.IncludeTotal)
+    if query.After != nil {
+        key += fmt.Sprintf(":after:%+v", *query.After)
+    } else if query.Before != nil {
+        key += fmt.Sprintf(":before:%+v", *query.Before)
+    }
+    val, err := repo.load(ctx, "GetProductsKeyset", key,
+        func(ctx context.Context) (interface{}, error) {
+            return repo.RepositoryV2.GetProductsKeyset(ctx, query)
+        })
+    if err != nil {
+        return models.ProductKeysetPage{}, err
+    }
+    page := val.(models.ProductKeysetPage)
+    page.Products = copyProducts(page.Products)
+    return page, nil
+}
+
+func (repo *CachingRepository) GetCategories(ctx context.Context) ([]models.Category,
+        error) {
+    val, err := repo.load(ctx, "GetCategories", categoryKeys + "all",
+        func(ctx context.Context) (interface{}, error) {
+            return repo.RepositoryV2.GetCategories(ctx)
+        })
+    if err != nil {
+        return nil, err
+    }
+    return append([]models.Category(nil), val.([]models.Category)...), nil
+}
+
+func (repo *CachingRepository) SaveProduct(ctx context.Context,
+        p *models.Product) error {
+    defer repo.Cache.Invalidate(productKeys)
+    return repo.RepositoryV2.SaveProduct(ctx, p)
+}
+
+func (repo *CachingRepository) SetProductImage(ctx context.Context, id int,
+        image string) error {
+    defer repo.Cache.Invalidate(productKeys)
+    return repo.RepositoryV2.SetProductImage(ctx, id, image)
+}
+
+func (repo *CachingRepository) SaveCategory(ctx context.Context,
+        c *models.Category) error {
+    defer repo.Cache.Invalidate(categoryKeys, productKeys)
+    return repo.RepositoryV2.SaveCategory(ctx, c)
+}
+
+func (repo *CachingRepository) Init(ctx context.Context) error {
+    defer repo.Cache.Clear()
+    return repo.RepositoryV2.Init(ctx)
+}
+
+func (repo *CachingRepository) Seed(ctx context.Context) error {
+    defer repo.Cache.Clear()
+    return repo.RepositoryV2.Seed(ctx)
+}
+
+type CachingBulkRepository struct {
+    models.BulkRepository
+    Cache *RepositoryCache
+}
+
+func (repo *CachingBulkRepository) ImportCategories(rows []models.ImportCategory,
+        dryRun bool) (summary models.ImportSummary) {
+    summary = repo.BulkRepository.ImportCategories(rows, dryRun)
+    if summary.Committed {
+        repo.Cache.Invalidate(categoryKeys, productKeys)
+    }
+    return
+}
+
+func (repo *CachingBulkRepository) ImportProducts(rows []models.ImportProduct,
+        dryRun bool) (summary models.ImportSummary) {
+    summary = repo.BulkRepository.ImportProducts(rows, dryRun)
+    if summary.Committed {
+        repo.Cache.Invalidate(productKeys)
+    }
+    return
+}
+
+type CachingReviewRepository struct {
+    models.ReviewRepository
+    Cache *RepositoryCache
+}
+
+func (repo *CachingReviewRepository) SaveReview(ctx context.Context,
+        review *models.Review) error {
+    defer repo.Cache.Invalidate(productKeys)
+    return repo.ReviewRepository.SaveReview(ctx, review)
+}
+
+func (repo *CachingReviewRepository) SetReviewStatus(ctx context.Context, id int,
+        status string) error {
+    defer repo.Cache.Invalidate(productKeys)
+    return repo.ReviewRepository.SetReviewStatus(ctx, id, status)
+}
+
+func copyProducts(products []models.Product) []models.Product {
+    if products == nil {
+        return nil
+    }
+    copies := make([]models.Product, len(products))
+    for i, p := range products {
+        copies[i] = copyProduct(p)
+    }
+    return copies
+}
+
+func copyProduct(p models.Product) models.Product {
+    if p.Category != nil {
+        category := *p.Category
+        p.Category = &category
+    }
+    return p
+}
@@ -0,0 +1,98 @@
+package repo
+
+import (
+    "context"
+    "path/filepath"
+    "sportsstore/models"
+    "sync"
+    "testing"
+    "time"
+)
+
+type countingRepository struct {
+    models.RepositoryV2
+    mutex sync.Mutex
+    calls map[string]int
+}
+
+func (repo *countingRepository) count(method string) {
+    repo.mutex.Lock()
+    defer repo.mutex.Unlock()
+    repo.calls[method]++
+}
+
+func (repo *countingRepository) GetCategories(ctx context.Context) ([]models.Category,
+        error) {
+    repo.count("GetCategories")
+    time.Sleep(10 * time.Millisecond)
+    return repo.RepositoryV2.GetCategories(ctx)
+}
+
+func (repo *countingRepository) GetProductPageCategory(ctx context.Context,
+        categoryId, page, pageSize int) ([]models.Product, int, error) {
+    repo.count("GetProductPageCategory")
+    return repo.RepositoryV2.GetProductPageCategory(ctx, categoryId, page, pageSize)
+}
+
+func TestCachingRepository(t *testing.T) {
+    sqlRepo := newContractRepo(t, contractBackend{ dialect: "sqlite",
+        dsn: filepath.Join(t.TempDir(), "cache.db") })
+    counter := &countingRepository{ RepositoryV2: sqlRepo, calls: map[string]int {} }
+    rc := NewRepositoryCache(testConfig{ Configuration: sqlRepo.Configuration,
+        values: map[string]string { "cache:ttl:default": "1m" }})
+    repo := &CachingRepository{ RepositoryV2: counter, Cache: rc }
+    ctx := context.Background()
+
+    var wg sync.WaitGroup
+    for i := 0; i < 10; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if _, err := repo.GetCategories(ctx); err != nil {
+                t.Error(err)
+            }
+        }()
+    }
+    wg.Wait()
+    if calls := counter.calls["GetCategories"]; calls != 1 {
+        t.Fatalf("Expected concurrent reads to share one load, got %v", calls)
+    }
+
+    for i := 0; i < 3; i++ {
+        if _, _, err := repo.GetProductPageCategory(ctx, 1, 1, 4); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if calls := counter.calls["GetProductPageCategory"]; calls != 1 {
+        t.Fatalf("Expected repeated page reads to hit the cache, got %v", calls)
+    }
+    if stats := rc.Stats(); stats.Hits < 2 || stats.Misses < 2 {
+        t.Fatalf("Unexpected cache stats: %+v", stats)
+    }
+
+    c := models.Category{ ID: 1, CategoryName: "Boats" }
+    if err := repo.SaveCategory(ctx, &c); err != nil {
+        t.Fatal(err)
+    }
+    categories, err := repo.GetCategories(ctx)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if counter.calls["GetCategories"] != 2 || categories[0].CategoryName != "Boats" {
+        t.Fatalf("Expected SaveCategory to invalidate categories: %v", categories)
+    }
+    products, _, err := repo.GetProductPageCategory(ctx, 1, 1, 4)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if counter.calls["GetProductPageCategory"] != 2 ||
+            products[0].CategoryName != "Boats" {
+        t.Fatalf("Expected SaveCategory to invalidate products: %v", products)
+    }
+
+    products[0].Category.CategoryName = "Changed"
+    products, _, err = repo.GetProductPageCategory(ctx, 1, 1, 4)
+    if err != nil || products[0].CategoryName != "Boats" {
+        t.Fatalf("Expected cached categories to be copied: %v %v", products, err)
+    }
+}
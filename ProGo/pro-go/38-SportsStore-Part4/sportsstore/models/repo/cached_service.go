@@ -0,0 +1,44 @@
+package repo
+
+import (
+    "platform/config"
+    "platform/services"
+    "sportsstore/models"
+    "sync"
+)
+
+func RegisterCachingRepositoryService() {
+    caches := map[string]*RepositoryCache {}
+    lock := sync.Mutex {}
+    services.AddScoped(func (config config.Configuration) *RepositoryCache {
+        lock.Lock()
+        defer lock.Unlock()
+        cache, found := caches[databaseKey(config)]
+        if !found {
+            cache = NewRepositoryCache(config)
+            caches[databaseKey(config)] = cache
+        }
+        return cache
+    })
+    services.Decorate(func (repo models.RepositoryV2, cache *RepositoryCache, 
+            config config.Configuration) models.RepositoryV2 {
+        if !config.GetBoolDefault("cache:enabled", true) {
+            return repo
+        }
+        return &CachingRepository{ RepositoryV2: repo, Cache: cache }
+    })
+    services.Decorate(func (repo models.BulkRepository, cache *RepositoryCache, 
+            config config.Configuration) models.BulkRepository {
+        if !config.GetBoolDefault("cache:enabled", true) {
+            return repo
+        }
+        return &CachingBulkRepository{ BulkRepository: repo, Cache: cache }
+    })
+    services.Decorate(func (repo models.ReviewRepository, cache *RepositoryCache, 
+            config config.Configuration) models.ReviewRepository {
+        if !config.GetBoolDefault("cache:enabled", true) {
+            return repo
+        }
+        return &CachingReviewRepository{ ReviewRepository: repo, Cache: cache }
+    })
+}
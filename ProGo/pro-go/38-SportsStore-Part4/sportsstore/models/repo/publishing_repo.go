@@ -0,0 +1,38 @@
+package repo
+
+import (
+    "context"
+    "platform/pubsub"
+    "sportsstore/models"
+)
+
+type PublishingRepository struct {
+    models.RepositoryV2
+    Broker pubsub.Broker
+    Topic string
+}
+
+func (repo *PublishingRepository) SaveOrder(ctx context.Context,
+        o *models.Order) error {
+    if err := repo.RepositoryV2.SaveOrder(ctx, o); err != nil {
+        return err
+    }
+    repo.publish(models.OrderCreatedEvent, o)
+    return nil
+}
+
+func (repo *PublishingRepository) SetOrderShipped(ctx context.Context,
+        o *models.Order) error {
+    if err := repo.RepositoryV2.SetOrderShipped(ctx, o); err != nil {
+        return err
+    }
+    repo.publish(models.OrderUpdatedEvent, o)
+    return nil
+}
+
+func (repo *PublishingRepository) publish(kind string, o *models.Order) {
+    order := *o
+    order.Products = append([]models.ProductSelection(nil), o.Products...)
+    repo.Broker.Publish(repo.Topic,
+        models.OrderEvent{ Kind: kind, Order: order })
+}
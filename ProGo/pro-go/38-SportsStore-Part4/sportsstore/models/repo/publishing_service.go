@@ -0,0 +1,17 @@
+package repo
+
+import (
+    "context"
+    "platform/pubsub"
+    "platform/services"
+    "platform/tenants"
+    "sportsstore/models"
+)
+
+func RegisterPublishingRepositoryService() {
+    services.Decorate(func (repo models.RepositoryV2, broker pubsub.Broker,
+            ctx context.Context) models.RepositoryV2 {
+        return &PublishingRepository{ RepositoryV2: repo, Broker: broker,
+            Topic: tenants.Scope(ctx, models.OrderEventsTopic) }
+    })
+}
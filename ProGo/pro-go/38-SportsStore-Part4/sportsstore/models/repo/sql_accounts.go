@@ -0,0 +1,103 @@
+package repo
+
+import (
+    "database/sql"
+    "sportsstore/models"
+    "strings"
+)
+
+func (repo *SqlRepository) GetAccount(id int) (models.Account, bool) {
+    return repo.scanAccount("GetAccount", 
+        repo.Commands.GetAccount.QueryRowContext(repo.Context, id))
+}
+
+func (repo *SqlRepository) GetAccountByName(name string) (models.Account, bool) {
+    return repo.scanAccount("GetAccountByName", 
+        repo.Commands.GetAccountByName.QueryRowContext(repo.Context, name))
+}
+
+func (repo *SqlRepository) scanAccount(name string, 
+        row *sql.Row) (account models.Account, found bool) {
+    if row.Err() == nil {
+        var roles string
+        err := row.Scan(&account.ID, &account.Name, &account.Email, 
+            &account.PasswordHash, &roles)
+        if err == nil {
+            account.Roles = splitRoles(roles)
+            found = true
+        } else if err != sql.ErrNoRows {
+            repo.Logger.Panicf("Cannot scan account data: %v", err.Error())
+        }
+    } else {
+        repo.Logger.Panicf("Cannot exec %v command: %v", name, row.Err().Error())
+    }
+    return
+}
+
+func (repo *SqlRepository) SaveAccount(a *models.Account) error {
+    roles := strings.Join(a.Roles, ",")
+    return repo.withRetry(repo.Context, func() error {
+        if (a.ID == 0) {
+            id, err := repo.execInsert(repo.Context, repo.Commands.SaveAccount, 
+                a.Name, a.Email, a.PasswordHash, roles)
+            if err == nil {
+                a.ID = int(id)
+            }
+            return err
+        }
+        result, err := repo.Commands.UpdateAccount.ExecContext(repo.Context, a.Name, 
+            a.Email, a.PasswordHash, roles, a.ID)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) GetShippingDetails(accountId int) (
+        details models.ShippingDetails, found bool) {
+    row := repo.Commands.GetShippingDetails.QueryRowContext(repo.Context, accountId)
+    if row.Err() == nil {
+        err := row.Scan(&details.Name, &details.StreetAddr, &details.City, 
+            &details.State, &details.Zip, &details.Country)
+        if err == nil {
+            found = true
+        } else if err != sql.ErrNoRows {
+            repo.Logger.Panicf("Cannot scan address data: %v", err.Error())
+        }
+    } else {
+        repo.Logger.Panicf("Cannot exec GetShippingDetails command: %v", 
+            row.Err().Error())
+    }
+    return
+}
+
+func (repo *SqlRepository) SaveShippingDetails(accountId int, 
+        details models.ShippingDetails) {
+    _, err := repo.Commands.SaveShippingDetails.ExecContext(repo.Context, accountId, 
+        details.Name, details.StreetAddr, details.City, details.State, 
+        details.Zip, details.Country)
+    if err != nil {
+        repo.Logger.Panicf("Cannot exec SaveShippingDetails command: %v", 
+            err.Error())
+    }
+}
+
+func (repo *SqlRepository) GetAccountOrders(accountId int) []models.Order {
+    orders, err := repo.queryOrders(repo.Context, repo.Commands.GetAccountOrders, 
+        repo.Commands.GetAccountOrdersLines, accountId)
+    if err != nil {
+        repo.Logger.Panicf("Cannot exec GetAccountOrders command: %v", err.Error())
+    }
+    return orders
+}
+
+func splitRoles(roles string) []string {
+    result := []string {}
+    for _, role := range strings.Split(roles, ",") {
+        if role = strings.TrimSpace(role); role != "" {
+            result = append(result, role)
+        }
+    }
+    return result
+}
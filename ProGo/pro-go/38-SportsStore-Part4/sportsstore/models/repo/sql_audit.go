@@ -0,0 +1,67 @@
+package repo
+
+import (
+    "context"
+    "encoding/json"
+    "sportsstore/models"
+    "time"
+)
+
+var auditMaxTime = time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (repo *SqlRepository) SaveAuditEntry(ctx context.Context, 
+        entry *models.AuditEntry) error {
+    changes, err := json.Marshal(entry.Changes)
+    if err != nil {
+        return err
+    }
+    return repo.withRetry(ctx, func() error {
+        id, err := repo.execInsert(ctx, repo.Commands.SaveAuditEntry, 
+            entry.Time.UTC(), entry.ActorID, entry.ActorName, entry.Action, 
+            entry.Entity, entry.EntityID, string(changes), entry.Method, 
+            entry.Path, entry.RemoteAddr, entry.UserAgent)
+        if err == nil {
+            entry.ID = int(id)
+        }
+        return err
+    })
+}
+
+func (repo *SqlRepository) GetAuditEntries(ctx context.Context, 
+        filter models.AuditFilter) (entries []models.AuditEntry, err error) {
+    to := filter.To
+    if to.IsZero() {
+        to = auditMaxTime
+    }
+    if filter.Limit <= 0 {
+        filter.Limit = 100
+    }
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetAuditEntries.QueryContext(ctx, 
+            filter.Actor, filter.Actor, filter.Action, filter.Action, 
+            filter.Entity, filter.Entity, filter.EntityID, filter.EntityID,
+            filter.From.UTC(), to.UTC(), filter.Limit)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        entries = []models.AuditEntry {}
+        for rows.Next() {
+            entry := models.AuditEntry{}
+            var changes string
+            err = rows.Scan(&entry.ID, &entry.Time, &entry.ActorID, 
+                &entry.ActorName, &entry.Action, &entry.Entity, &entry.EntityID, 
+                &changes, &entry.Method, &entry.Path, &entry.RemoteAddr, 
+                &entry.UserAgent)
+            if err == nil {
+                err = json.Unmarshal([]byte(changes), &entry.Changes)
+            }
+            if err != nil {
+                return err
+            }
+            entries = append(entries, entry)
+        }
+        return rows.Err()
+    })
+    return
+}
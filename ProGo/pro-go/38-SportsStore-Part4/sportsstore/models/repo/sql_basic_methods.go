@@ -1,46 +1,49 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "sportsstore/models"
+)
 
-func (repo *SqlRepository) GetProduct(id int) (p models.Product) {
-    row := repo.Commands.GetProduct.QueryRowContext(repo.Context, id)
-    if row.Err() == nil {
-        var err error
-        if p, err = scanProduct(row); err != nil {
-            repo.Logger.Panicf("Cannot scan data: %v", err.Error())    
-        }
-    } else {
-        repo.Logger.Panicf("Cannot exec GetProduct command: %v", row.Err().Error())
-    }
+func (repo *SqlRepository) GetProduct(ctx context.Context, 
+        id int) (p models.Product, err error) {
+    err = repo.withRetry(ctx, func() (err error) {
+        p, err = scanProduct(repo.Commands.GetProduct.QueryRowContext(ctx, id))
+        return
+    })
     return
 }
 
-func (repo *SqlRepository) GetProducts() (results []models.Product) {
-    rows, err := repo.Commands.GetProducts.QueryContext(repo.Context)
-    if err == nil {
-        if results, err = scanProducts(rows); err != nil {
-            repo.Logger.Panicf("Cannot scan data: %v", err.Error())                    
-            return
+func (repo *SqlRepository) GetProducts(ctx context.Context) (
+        results []models.Product, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetProducts.QueryContext(ctx)
+        if err == nil {
+            defer rows.Close()
+            results, err = scanProducts(rows)
         }
-    } else {
-        repo.Logger.Panicf("Cannot exec GetProducts command: %v", err)   
-    }
+        return err
+    })
     return
 }
 
-func (repo *SqlRepository) GetCategories() []models.Category {
-    results := make([]models.Category, 0, 10)
-    rows, err := repo.Commands.GetCategories.QueryContext(repo.Context)
-    if err == nil {
+func (repo *SqlRepository) GetCategories(ctx context.Context) (
+        results []models.Category, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetCategories.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        results = make([]models.Category, 0, 10)
         for rows.Next() {
             c := models.Category{}
             if err := rows.Scan(&c.ID, &c.CategoryName); err != nil {
-                repo.Logger.Panicf("Cannot scan data: %v", err.Error())                    
+                return err
             }
             results = append(results, c)
         }
-    } else {
-        repo.Logger.Panicf("Cannot exec GetCategories command: %v", err)
-    }
-    return results
+        return rows.Err()
+    })
+    return
 }
@@ -0,0 +1,74 @@
+package repo
+
+import (
+    "context"
+    "sportsstore/models"
+    "time"
+)
+
+func (repo *SqlRepository) GetCartLines(accountId int) []models.ProductSelection {
+    lines := []models.ProductSelection {}
+    rows, err := repo.Commands.GetCartLines.QueryContext(repo.Context, accountId)
+    if err == nil {
+        for rows.Next() {
+            ps := models.ProductSelection { 
+                Product: models.Product{ Category: &models.Category{}},
+            }
+            err = rows.Scan(&ps.Quantity, &ps.Product.ID, &ps.Product.Name, 
+                &ps.Product.Description, &ps.Product.Price, &ps.Product.Weight,
+                &ps.Product.Category.ID, &ps.Product.Category.CategoryName)
+            if err == nil {
+                lines = append(lines, ps)
+            } else {
+                repo.Logger.Panicf("Cannot scan cart line data: %v", err.Error())
+            }
+        }
+    } else {
+        repo.Logger.Panicf("Cannot exec GetCartLines command: %v", err.Error())
+    }
+    return lines
+}
+
+func (repo *SqlRepository) SaveCartLines(accountId int, 
+        lines []models.ProductSelection) {
+    tx, err := repo.DB.Begin()
+    if err != nil {
+        repo.Logger.Panicf("Cannot create transaction: %v", err.Error())
+        return
+    }
+    _, err = tx.StmtContext(repo.Context, 
+        repo.Commands.ClearCartLines).Exec(accountId)
+    if err != nil {
+        tx.Rollback()
+        repo.Logger.Panicf("Cannot exec ClearCartLines command: %v", err.Error())
+        return
+    }
+    statement := tx.StmtContext(repo.Context, repo.Commands.SaveCartLine)
+    updated := time.Now().UTC()
+    for _, line := range lines {
+        _, err := statement.Exec(accountId, line.Product.ID, line.Quantity, updated)
+        if err != nil {
+            tx.Rollback()
+            repo.Logger.Panicf("Cannot exec SaveCartLine command: %v", err.Error())
+            return
+        }
+    }
+    if err = tx.Commit(); err != nil {
+        repo.Logger.Panicf("Transaction cannot be committed: %v", err.Error())
+    }
+}
+
+func (repo *SqlRepository) DeleteAbandonedCartLines(ctx context.Context, 
+        before time.Time) (count int, err error) {
+    err = repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.DeleteAbandonedCartLines.ExecContext(ctx, 
+            before.UTC())
+        if err != nil {
+            return err
+        }
+        affected, err := result.RowsAffected()
+        count = int(affected)
+        return err
+    })
+    return
+}
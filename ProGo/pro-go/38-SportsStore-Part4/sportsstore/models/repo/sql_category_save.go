@@ -1,34 +1,25 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "sportsstore/models"
+)
 
-func (repo *SqlRepository) SaveCategory(c *models.Category) {
-    if (c.ID == 0) {
-        result, err := repo.Commands.SaveCategory.ExecContext(repo.Context, 
-            c.CategoryName)
-        if err == nil {
-            id, err := result.LastInsertId()
+func (repo *SqlRepository) SaveCategory(ctx context.Context, c *models.Category) error {
+    return repo.withRetry(ctx, func() error {
+        if (c.ID == 0) {
+            id, err := repo.execInsert(ctx, repo.Commands.SaveCategory, 
+                c.CategoryName)
             if err == nil {
                 c.ID = int(id)
-                return
-            } else {
-                repo.Logger.Panicf("Cannot get inserted ID: %v", err.Error())       
             }
-        } else {
-            repo.Logger.Panicf("Cannot exec SaveCategory command: %v", err.Error())            
-        }        
-    } else {
-        result, err := repo.Commands.UpdateCategory.ExecContext(repo.Context, 
+            return err
+        }
+        result, err := repo.Commands.UpdateCategory.ExecContext(ctx, 
             c.CategoryName, c.ID)
-        if err == nil {
-            affected, err := result.RowsAffected()
-            if err == nil && affected != 1 {
-                repo.Logger.Panicf("Got unexpected rows affected: %v", affected)       
-            } else if err != nil {
-                repo.Logger.Panicf("Cannot get rows affected: %v", err)       
-            }
-        } else {
-            repo.Logger.Panicf("Cannot exec UpdateCategory command: %v", err.Error())                   
-        }       
-    }
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
 }
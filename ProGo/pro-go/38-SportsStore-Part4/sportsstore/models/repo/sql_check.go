@@ -0,0 +1,42 @@
+package repo
+
+import (
+    "fmt"
+    "os"
+    "platform/config"
+    "reflect"
+)
+
+func CheckConfig(cfg config.Configuration) (problems []string) {
+    dialect, err := GetDialect(cfg.GetStringDefault("sql:dialect", "sqlite"))
+    if err != nil {
+        return []string { err.Error() }
+    }
+    if _, found := cfg.GetString("sql:connection_str"); !found {
+        problems = append(problems, "Missing SQL connection string: sql:connection_str")
+    }
+    migrations := dialectPath(dialect, 
+        cfg.GetStringDefault("sql:migrations:path", "sql/migrations"))
+    if info, err := os.Stat(migrations); err != nil || !info.IsDir() {
+        problems = append(problems, 
+            fmt.Sprintf("Cannot read migrations directory: %v", migrations))
+    }
+    files := []string { cfg.GetStringDefault("sql:seed", "sql/seed_db.sql") }
+    commandType := reflect.TypeOf(SqlCommands{})
+    for i := 0; i < commandType.NumField(); i++ {
+        name := commandType.Field(i).Name
+        if filename, found := cfg.GetString("sql:commands:" + name); found {
+            files = append(files, filename)
+        } else {
+            problems = append(problems, 
+                fmt.Sprintf("Missing location for SQL command: %v", name))
+        }
+    }
+    for _, filename := range files {
+        if _, err := os.Stat(dialectPath(dialect, filename)); err != nil {
+            problems = append(problems, 
+                fmt.Sprintf("Cannot read SQL file: %v", filename))
+        }
+    }
+    return
+}
@@ -0,0 +1,690 @@
+package repo
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "platform/config"
+    "platform/features"
+    "platform/jobs"
+    "platform/logging"
+    "sportsstore/models"
+    "testing"
+    "time"
+)
+
+type testConfig struct {
+    config.Configuration
+    values map[string]string
+}
+
+func (c testConfig) GetString(name string) (string, bool) {
+    if val, found := c.values[name]; found {
+        return val, true
+    }
+    return c.Configuration.GetString(name)
+}
+
+func (c testConfig) GetStringDefault(name, defVal string) string {
+    if val, found := c.GetString(name); found {
+        return val
+    }
+    return defVal
+}
+
+type contractBackend struct {
+    dialect, dsn, envVar string
+}
+
+func TestMain(m *testing.M) {
+    if err := os.Chdir(filepath.Join("..", "..")); err != nil {
+        panic(err)
+    }
+    os.Exit(m.Run())
+}
+
+func TestRepositoryContract(t *testing.T) {
+    backends := []contractBackend {
+        { dialect: "sqlite", dsn: filepath.Join(t.TempDir(), "contract.db") },
+        { dialect: "postgres", envVar: "SPORTSSTORE_TEST_POSTGRES_DSN" },
+        { dialect: "mysql", envVar: "SPORTSSTORE_TEST_MYSQL_DSN" },
+    }
+    for _, backend := range backends {
+        if backend.envVar != "" {
+            backend.dsn = os.Getenv(backend.envVar)
+        }
+        t.Run(backend.dialect, func(subT *testing.T) {
+            if backend.dsn == "" {
+                subT.Skipf("Set %v to run against %v", backend.envVar,
+                    backend.dialect)
+            }
+            runContract(subT, newContractRepo(subT, backend))
+        })
+    }
+}
+
+func TestPostgresRebind(t *testing.T) {
+    tests := map[string]string {
+        "SELECT * FROM Products WHERE Name = '?' AND Id = ? LIMIT ? OFFSET ?":
+            "SELECT * FROM Products WHERE Name = '?' AND Id = $1 LIMIT $2 OFFSET $3",
+        "SELECT * FROM Products WHERE Name = 'It''s ?' AND \"Id?\" = ?":
+            "SELECT * FROM Products WHERE Name = 'It''s ?' AND \"Id?\" = $1",
+        "-- Which product?\nSELECT * FROM Products WHERE Id = ? /* Or ? */ AND Price > ?":
+            "-- Which product?\nSELECT * FROM Products WHERE Id = $1 /* Or ? */ AND Price > $2",
+        "SELECT Name FROM Products -- trailing ?": 
+            "SELECT Name FROM Products -- trailing ?",
+    }
+    for query, expected := range tests {
+        if result := (postgresDialect{}).Rebind(query); result != expected {
+            t.Fatalf("Expected %v, Got %v", expected, result)
+        }
+    }
+}
+
+func newContractRepo(t *testing.T, backend contractBackend) *SqlRepository {
+    base, err := config.Load("config.json")
+    if err != nil {
+        t.Fatal(err)
+    }
+    cfg := testConfig{ Configuration: base, values: map[string]string {
+        "sql:dialect": backend.dialect,
+        "sql:connection_str": backend.dsn,
+        "logging:level": "warning",
+    }}
+    logger := logging.NewDefaultLogger(cfg)
+    db, dialect, commands, migrator, _ := openDB(cfg, logger)
+    t.Cleanup(func() { db.Close() })
+    repo := &SqlRepository{ Configuration: cfg, Logger: logger,
+        Commands: *commands, DB: db, Context: context.Background(),
+        Dialect: dialect, Migrator: migrator }
+    if err := repo.Init(repo.Context); err != nil {
+        t.Fatal(err)
+    }
+    if err := repo.Seed(repo.Context); err != nil {
+        t.Fatal(err)
+    }
+    return repo
+}
+
+func check(t *testing.T, err error) {
+    t.Helper()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+func runContract(t *testing.T, repo *SqlRepository) {
+    ctx := context.Background()
+    t.Run("Products", func(subT *testing.T) {
+        products, err := repo.GetProducts(ctx)
+        check(subT, err)
+        if len(products) != 9 {
+            subT.Fatalf("Expected 9 seeded products, got %v", len(products))
+        }
+        p, err := repo.GetProduct(ctx, 1)
+        check(subT, err)
+        if p.Name != "Kayak" || p.CategoryName != "Watersports" {
+            subT.Fatalf("Unexpected product: %v", p)
+        }
+        page, total, err := repo.GetProductPage(ctx, 2, 4)
+        check(subT, err)
+        if total != 9 || len(page) != 4 || page[0].ID != 5 {
+            subT.Fatalf("Unexpected page: %v products, %v total", len(page), total)
+        }
+        page, total, err = repo.GetProductPageCategory(ctx, 3, 1, 10)
+        check(subT, err)
+        if total != 4 || len(page) != 4 {
+            subT.Fatalf("Unexpected category page: %v products, %v total",
+                len(page), total)
+        }
+    })
+    t.Run("Keyset", func(subT *testing.T) {
+        query := models.ProductQuery{ SortBy: models.SortByPrice, Limit: 4,
+            IncludeTotal: true }
+        first, err := repo.GetProductsKeyset(ctx, query)
+        check(subT, err)
+        if len(first.Products) != 4 || !first.HasNext || first.HasPrev ||
+                first.Total != 9 || first.Products[0].Name != "Thinking Cap" {
+            subT.Fatalf("Unexpected first page: %+v", first)
+        }
+        query.After = &models.ProductKey{ ID: first.Products[3].ID,
+            Price: first.Products[3].Price }
+        second, err := repo.GetProductsKeyset(ctx, query)
+        check(subT, err)
+        if len(second.Products) != 4 || !second.HasNext || !second.HasPrev ||
+                second.Products[0].Price < first.Products[3].Price {
+            subT.Fatalf("Unexpected second page: %+v", second)
+        }
+        query.After, query.Before = nil, &models.ProductKey{
+            ID: second.Products[0].ID, Price: second.Products[0].Price }
+        previous, err := repo.GetProductsKeyset(ctx, query)
+        check(subT, err)
+        if len(previous.Products) != 4 || previous.HasPrev ||
+                previous.Products[0].ID != first.Products[0].ID {
+            subT.Fatalf("Unexpected previous page: %+v", previous)
+        }
+        byName, err := repo.GetProductsKeyset(ctx, models.ProductQuery{
+            CategoryID: 1, SortBy: models.SortByName, Limit: 10 })
+        check(subT, err)
+        if len(byName.Products) != 2 || byName.HasNext ||
+                byName.Products[0].Name != "Kayak" {
+            subT.Fatalf("Unexpected category page: %+v", byName)
+        }
+        _, err = repo.GetProductsKeyset(ctx, models.ProductQuery{ SortBy: "Description",
+            Limit: 1 })
+        if !errors.Is(err, models.ErrInvalidQuery) {
+            subT.Fatalf("Expected ErrInvalidQuery, got %v", err)
+        }
+    })
+    t.Run("NotFound", func(subT *testing.T) {
+        if _, err := repo.GetProduct(ctx, 1000); !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected ErrNotFound for product, got %v", err)
+        }
+        if _, err := repo.GetOrder(ctx, 1000); !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected ErrNotFound for order, got %v", err)
+        }
+        err := repo.SetOrderShipped(ctx, &models.Order{ ID: 1000, Shipped: true })
+        if !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected ErrNotFound for shipped update, got %v", err)
+        }
+    })
+    t.Run("SalesLines", func(subT *testing.T) {
+        lines, err := repo.GetSalesLines(ctx, time.Now().Add(-time.Hour), 
+            time.Now().Add(time.Hour))
+        check(subT, err)
+        if len(lines) != 4 || lines[3].OrderID != 2 || lines[3].ProductName != "Stadium" ||
+                lines[3].Revenue() != 2 * 79500 || lines[3].Country != "UK" {
+            subT.Fatalf("Unexpected sales lines: %v", lines)
+        }
+        lines, err = repo.GetSalesLines(ctx, time.Now().Add(time.Hour), 
+            time.Now().Add(2 * time.Hour))
+        check(subT, err)
+        if len(lines) != 0 {
+            subT.Fatalf("Expected no sales lines outside the date range: %v", lines)
+        }
+    })
+    t.Run("Cancelled", func(subT *testing.T) {
+        cancelled, cancel := context.WithCancel(ctx)
+        cancel()
+        if _, err := repo.GetProducts(cancelled); !errors.Is(err, context.Canceled) {
+            subT.Fatalf("Expected context.Canceled, got %v", err)
+        }
+    })
+    t.Run("SaveProduct", func(subT *testing.T) {
+        p := models.Product{ Name: "Goal Net", Description: "Regulation size",
+            Price: 120.5, Category: &models.Category{ ID: 2 }}
+        check(subT, repo.SaveProduct(ctx, &p))
+        if p.ID <= 9 {
+            subT.Fatalf("Expected a new product ID, got %v", p.ID)
+        }
+        p.Price = 99.95
+        check(subT, repo.SaveProduct(ctx, &p))
+        check(subT, repo.SetProductImage(ctx, p.ID, "products/net.png"))
+        stored, err := repo.GetProduct(ctx, p.ID)
+        check(subT, err)
+        if stored.Price != 99.95 || stored.Image != "products/net.png" {
+            subT.Fatalf("Unexpected product: %v", stored)
+        }
+    })
+    t.Run("SaveCategory", func(subT *testing.T) {
+        c := models.Category{ CategoryName: "Running" }
+        check(subT, repo.SaveCategory(ctx, &c))
+        if c.ID <= 3 {
+            subT.Fatalf("Expected a new category ID, got %v", c.ID)
+        }
+        c.CategoryName = "Athletics"
+        check(subT, repo.SaveCategory(ctx, &c))
+        categories, err := repo.GetCategories(ctx)
+        check(subT, err)
+        found := false
+        for _, stored := range categories {
+            found = found || (stored.ID == c.ID && stored.CategoryName == "Athletics")
+        }
+        if !found {
+            subT.Fatal("Updated category not found")
+        }
+    })
+    t.Run("ShippingMethods", func(subT *testing.T) {
+        methods, err := repo.GetShippingMethods(ctx)
+        check(subT, err)
+        if len(methods) != 2 || methods[1].Name != "Express" || !methods[1].Active {
+            subT.Fatalf("Unexpected shipping methods: %v", methods)
+        }
+        m := models.ShippingMethod{ Name: "Freight", Price: 49.5, DeliveryDays: 10 }
+        check(subT, repo.SaveShippingMethod(ctx, &m))
+        m.Active = true
+        check(subT, repo.SaveShippingMethod(ctx, &m))
+        stored, err := repo.GetShippingMethod(ctx, m.ID)
+        check(subT, err)
+        if stored != m {
+            subT.Fatalf("Unexpected shipping method: %v", stored)
+        }
+        check(subT, repo.DeleteShippingMethod(ctx, m.ID))
+        if err := repo.DeleteShippingMethod(ctx, m.ID); !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected ErrNotFound for deleted method, got %v", err)
+        }
+    })
+    t.Run("Promotions", func(subT *testing.T) {
+        starts := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+        p := models.Promotion{ Code: "SUMMER", DiscountPercent: 10, Starts: starts,
+            Active: true }
+        check(subT, repo.SavePromotion(ctx, &p))
+        stored, err := repo.GetPromotion(ctx, p.ID)
+        check(subT, err)
+        if !stored.Starts.Equal(starts) || !stored.Ends.IsZero() || 
+                !stored.IsCurrent(starts.AddDate(1, 0, 0)) {
+            subT.Fatalf("Unexpected promotion: %v", stored)
+        }
+        check(subT, repo.DeletePromotion(ctx, p.ID))
+        promotions, err := repo.GetPromotions(ctx)
+        check(subT, err)
+        if len(promotions) != 0 {
+            subT.Fatalf("Expected no promotions, got %v", promotions)
+        }
+    })
+    t.Run("PricingRates", func(subT *testing.T) {
+        rates, err := repo.GetShippingRates(ctx)
+        check(subT, err)
+        if len(rates) != 6 || rates[3].Country != "USA,US" || rates[3].MaxWeight != 5 {
+            subT.Fatalf("Unexpected shipping rates: %v", rates)
+        }
+        rate := models.ShippingRate{ MethodID: 2, Country: "Japan,JP", MaxWeight: 10,
+            Price: 24.5 }
+        check(subT, repo.SaveShippingRate(ctx, &rate))
+        rate.Price = 26
+        check(subT, repo.SaveShippingRate(ctx, &rate))
+        if stored, err := repo.GetShippingRate(ctx, rate.ID); err != nil || stored != rate {
+            subT.Fatalf("Unexpected shipping rate: %v %v", stored, err)
+        }
+        check(subT, repo.DeleteShippingRate(ctx, rate.ID))
+        taxes, err := repo.GetTaxRates(ctx)
+        check(subT, err)
+        if len(taxes) != 3 || !taxes[0].Inclusive || taxes[2].Inclusive ||
+                taxes[2].Rate != 8.875 {
+            subT.Fatalf("Unexpected tax rates: %v", taxes)
+        }
+        tax := models.TaxRate{ Name: "GST", Country: "Australia,AU", Rate: 10,
+            Inclusive: true }
+        check(subT, repo.SaveTaxRate(ctx, &tax))
+        tax.TaxShipping = true
+        check(subT, repo.SaveTaxRate(ctx, &tax))
+        if stored, err := repo.GetTaxRate(ctx, tax.ID); err != nil || stored != tax {
+            subT.Fatalf("Unexpected tax rate: %v %v", stored, err)
+        }
+        check(subT, repo.DeleteTaxRate(ctx, tax.ID))
+        if err := repo.DeleteTaxRate(ctx, tax.ID); !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected ErrNotFound for deleted tax rate, got %v", err)
+        }
+    })
+    t.Run("SaveOrder", func(subT *testing.T) {
+        p3, err := repo.GetProduct(ctx, 3)
+        check(subT, err)
+        p4, err := repo.GetProduct(ctx, 4)
+        check(subT, err)
+        order := models.Order{
+            ShippingDetails: models.ShippingDetails{ Name: "Carol",
+                Email: "carol@example.com", StreetAddr: "1 High St", City: "Bath", State: "Somerset",
+                Zip: "BA1 1AA", Country: "UK" },
+            Products: []models.ProductSelection {
+                { Quantity: 2, Product: p3 },
+                { Quantity: 1, Product: p4 },
+            },
+            ShippingMethodID: 1, ShippingMethod: "Standard",
+            OrderTotals: models.OrderTotals{ Subtotal: 73.95, Shipping: 4.95,
+                Tax: 13.15, Total: 78.9, TaxName: "VAT", TaxRate: 20, TaxIncluded: true },
+        }
+        check(subT, repo.SaveOrder(ctx, &order))
+        if order.ID <= 2 {
+            subT.Fatalf("Expected a new order ID, got %v", order.ID)
+        }
+        for _, shipped := range []bool { true, false, true, true } {
+            check(subT, repo.SetOrderShipped(ctx,
+                &models.Order{ ID: order.ID, Shipped: shipped }))
+        }
+        stored, err := repo.GetOrder(ctx, order.ID)
+        check(subT, err)
+        if !stored.Shipped || stored.State != "Somerset" || len(stored.Products) != 2 ||
+                stored.GetSubtotal() != 2 * 19.5 + 34.95 ||
+                stored.OrderTotals != order.OrderTotals ||
+                stored.ShippingMethod != "Standard" {
+            subT.Fatalf("Unexpected order: %v", stored)
+        }
+        orders, err := repo.GetOrders(ctx)
+        check(subT, err)
+        if len(orders) != 3 {
+            subT.Fatalf("Expected 3 orders, got %v", len(orders))
+        }
+        emails, err := repo.GetPendingEmails(ctx, time.Now().Add(time.Minute), 10)
+        check(subT, err)
+        if len(emails) != 2 || emails[0].Kind != models.OrderConfirmationEmail ||
+                emails[1].Kind != models.OrderShippedEmail ||
+                emails[0].Recipient != "carol@example.com" ||
+                emails[0].OrderID != order.ID {
+            subT.Fatalf("Unexpected outbox emails: %v", emails)
+        }
+        now := time.Now().Add(time.Minute)
+        for i, expected := range []bool { true, false } {
+            claim := emails[0]
+            claimed, err := repo.ClaimEmail(ctx, &claim, now, time.Minute)
+            check(subT, err)
+            if claimed != expected || (claimed && claim.Status != models.EmailSending) {
+                subT.Fatalf("Claim %v: expected %v, got %v", i, expected, claimed)
+            }
+        }
+        claimed, err := repo.ClaimEmail(ctx, &emails[0], now.Add(time.Hour), 
+            time.Minute)
+        check(subT, err)
+        if !claimed {
+            subT.Fatal("Expected an expired claim to be reclaimed")
+        }
+        emails[0].Status, emails[0].Attempts = models.EmailSent, 1
+        check(subT, repo.UpdateEmail(ctx, &emails[0]))
+        emails[1].Attempts, emails[1].NextAttempt = 1, time.Now().Add(time.Hour)
+        check(subT, repo.UpdateEmail(ctx, &emails[1]))
+        emails, err = repo.GetPendingEmails(ctx, time.Now().Add(time.Minute), 10)
+        check(subT, err)
+        if len(emails) != 0 {
+            subT.Fatalf("Expected no due emails, got %v", emails)
+        }
+    })
+    t.Run("Reviews", func(subT *testing.T) {
+        reviews, err := repo.GetProductReviews(ctx, 1)
+        check(subT, err)
+        if len(reviews) != 2 {
+            subT.Fatalf("Expected 2 seeded reviews, got %v", reviews)
+        }
+        p3, err := repo.GetProduct(ctx, 3)
+        check(subT, err)
+        check(subT, repo.SaveOrder(ctx, &models.Order{ AccountID: 2,
+            ShippingDetails: models.ShippingDetails{ Name: "Fred" },
+            Products: []models.ProductSelection { { Quantity: 1, Product: p3 } } }))
+        if purchased, err := repo.HasPurchased(ctx, 2, 3); err != nil || !purchased {
+            subT.Fatalf("Expected purchase of product 3: %v", err)
+        }
+        if purchased, err := repo.HasPurchased(ctx, 2, 4); err != nil || purchased {
+            subT.Fatalf("Unexpected purchase of product 4: %v", err)
+        }
+        since := time.Now().Add(-time.Hour)
+        before, err := repo.CountRecentReviews(ctx, 2, since)
+        check(subT, err)
+        review := models.Review{ ProductID: 3, AccountID: 2, Author: "Fred", 
+            Rating: 2, Body: "Lost its shape after a week" }
+        check(subT, repo.SaveReview(ctx, &review))
+        pending, err := repo.GetReviews(ctx, models.ReviewPending)
+        check(subT, err)
+        if len(pending) != 1 || pending[0].ID != review.ID || 
+                pending[0].Status != models.ReviewPending {
+            subT.Fatalf("Unexpected moderation queue: %v", pending)
+        }
+        if after, err := repo.CountRecentReviews(ctx, 2, since); err != nil || 
+                after != before + 1 {
+            subT.Fatalf("Expected %v recent reviews, got %v", before + 1, after)
+        }
+        if reviewed, err := repo.HasReviewed(ctx, 2, 3); err != nil || !reviewed {
+            subT.Fatalf("Expected review of product 3: %v", err)
+        }
+        if p3, err = repo.GetProduct(ctx, 3); err != nil || p3.ReviewCount != 0 {
+            subT.Fatalf("Pending review should not be counted: %+v", p3)
+        }
+        check(subT, repo.SetReviewStatus(ctx, review.ID, models.ReviewApproved))
+        if p3, err = repo.GetProduct(ctx, 3); err != nil || p3.ReviewCount != 1 ||
+                p3.Rating != 2 {
+            subT.Fatalf("Unexpected product rating: %+v", p3)
+        }
+        sorted, _, err := repo.GetProductPageSorted(ctx, 0, models.SortByRating, 1, 3)
+        check(subT, err)
+        if len(sorted) != 3 || sorted[0].ID != 1 || sorted[1].ID != 2 || 
+                sorted[2].ID != 3 {
+            subT.Fatalf("Unexpected rating order: %+v", sorted)
+        }
+        key := sorted[1].Key()
+        next, err := repo.GetProductsKeyset(ctx, models.ProductQuery{ 
+            SortBy: models.SortByRating, After: &key, Limit: 1 })
+        check(subT, err)
+        if len(next.Products) != 1 || next.Products[0].ID != 3 || !next.HasNext {
+            subT.Fatalf("Unexpected rating keyset page: %+v", next)
+        }
+        check(subT, repo.SetReviewStatus(ctx, review.ID, models.ReviewRejected))
+        if p3, err = repo.GetProduct(ctx, 3); err != nil || p3.ReviewCount != 0 ||
+                p3.Rating != 0 {
+            subT.Fatalf("Rejected review should not be counted: %+v", p3)
+        }
+        if err := repo.SetReviewStatus(ctx, 1000, 
+                models.ReviewApproved); !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected ErrNotFound for review, got %v", err)
+        }
+    })
+    t.Run("FeatureFlags", func(subT *testing.T) {
+        flag := features.Flag{ Name: "beta", Enabled: true, Percentage: 150 }
+        check(subT, repo.SaveFlag(ctx, flag))
+        flag.Enabled, flag.Percentage, flag.KeyBy = false, 25, features.KeyByUser
+        check(subT, repo.SaveFlag(ctx, flag))
+        flags, err := repo.GetFlags(ctx)
+        check(subT, err)
+        if len(flags) != 1 || flags[0].Enabled || flags[0].Percentage != 25 ||
+                flags[0].KeyBy != features.KeyByUser {
+            subT.Fatalf("Unexpected feature flags: %+v", flags)
+        }
+    })
+    t.Run("Accounts", func(subT *testing.T) {
+        account := models.Account{ Name: "Dave", Email: "dave@example.com",
+            PasswordHash: "hash", Roles: []string { "Customer" }}
+        check(subT, repo.SaveAccount(&account))
+        if stored, found := repo.GetAccountByName("DAVE"); !found ||
+                stored.ID != account.ID {
+            subT.Fatalf("Account not found by name: %v", stored)
+        }
+        duplicate := models.Account{ Name: "Dave", Email: "dave@example.org",
+            PasswordHash: "hash" }
+        if err := repo.SaveAccount(&duplicate); err == nil || duplicate.ID != 0 {
+            subT.Fatalf("Expected error for duplicate account, got %v", duplicate.ID)
+        }
+        missing := models.Account{ ID: 9999, Name: "Nobody", PasswordHash: "hash" }
+        if err := repo.SaveAccount(&missing); !errors.Is(err, models.ErrNotFound) {
+            subT.Fatalf("Expected missing account error, got %v", err)
+        }
+        details := models.ShippingDetails{ Name: "Dave", StreetAddr: "2 Low St",
+            City: "Leeds", State: "Yorkshire", Zip: "LS1 1AA", Country: "UK" }
+        repo.SaveShippingDetails(account.ID, details)
+        details.City = "York"
+        repo.SaveShippingDetails(account.ID, details)
+        if stored, found := repo.GetShippingDetails(account.ID); !found ||
+                stored.City != "York" {
+            subT.Fatalf("Unexpected shipping details: %v", stored)
+        }
+        p6, err := repo.GetProduct(ctx, 6)
+        check(subT, err)
+        repo.SaveCartLines(account.ID, []models.ProductSelection {
+            { Quantity: 3, Product: p6 },
+        })
+        if lines := repo.GetCartLines(account.ID); len(lines) != 1 ||
+                lines[0].Quantity != 3 || lines[0].ID != 6 {
+            subT.Fatalf("Unexpected cart lines: %v", lines)
+        }
+        count, err := repo.DeleteAbandonedCartLines(ctx, time.Now().Add(-time.Hour))
+        check(subT, err)
+        if count != 0 {
+            subT.Fatalf("Expected recent cart lines to be kept, removed %v", count)
+        }
+        count, err = repo.DeleteAbandonedCartLines(ctx, time.Now().Add(time.Hour))
+        check(subT, err)
+        if count != 1 || len(repo.GetCartLines(account.ID)) != 0 {
+            subT.Fatalf("Expected abandoned cart lines to be removed, removed %v", count)
+        }
+    })
+    t.Run("Jobs", func(subT *testing.T) {
+        first, err := jobs.NewJob("report", map[string]int { "days": 7 })
+        check(subT, err)
+        first.MaxAttempts = 3
+        check(subT, repo.Enqueue(ctx, first))
+        second := &jobs.Job{ Name: "cleanup", MaxAttempts: 1, 
+            RunAt: time.Now().Add(time.Hour) }
+        check(subT, repo.Enqueue(ctx, second))
+        job, found, err := repo.Claim(ctx, time.Now(), time.Minute)
+        check(subT, err)
+        var payload map[string]int
+        if !found || job.ID != first.ID || job.Status != jobs.StatusRunning || 
+                job.Attempts != 1 || job.Decode(&payload) != nil || payload["days"] != 7 {
+            subT.Fatalf("Unexpected claimed job: %v", job)
+        }
+        if _, found, err = repo.Claim(ctx, time.Now(), time.Minute); err != nil || found {
+            subT.Fatalf("Expected no due jobs, got %v %v", found, err)
+        }
+        job, found, err = repo.Claim(ctx, time.Now().Add(2 * time.Minute), time.Minute)
+        check(subT, err)
+        if !found || job.ID != first.ID || job.Attempts != 2 {
+            subT.Fatalf("Expected expired lease to be reclaimed: %v", job)
+        }
+        job.Status, job.LastError = jobs.StatusFailed, "boom"
+        check(subT, repo.Update(ctx, &job))
+        stored, err := repo.GetJob(ctx, job.ID)
+        check(subT, err)
+        if stored.Status != jobs.StatusFailed || stored.LastError != "boom" {
+            subT.Fatalf("Unexpected stored job: %v", stored)
+        }
+        if _, err = repo.GetJob(ctx, 1000); !errors.Is(err, jobs.ErrJobNotFound) {
+            subT.Fatalf("Expected ErrJobNotFound, got %v", err)
+        }
+        counts, err := repo.GetJobCounts(ctx)
+        check(subT, err)
+        if counts[jobs.StatusFailed] != 1 || counts[jobs.StatusPending] != 1 {
+            subT.Fatalf("Unexpected job counts: %v", counts)
+        }
+        pending, err := repo.GetJobs(ctx, jobs.JobFilter{ Status: jobs.StatusPending })
+        check(subT, err)
+        if len(pending) != 1 || pending[0].ID != second.ID {
+            subT.Fatalf("Unexpected pending jobs: %v", pending)
+        }
+        count, err := repo.Prune(ctx, time.Now().Add(time.Minute))
+        check(subT, err)
+        if count != 1 {
+            subT.Fatalf("Expected one finished job to be pruned, got %v", count)
+        }
+    })
+    t.Run("Import", func(subT *testing.T) {
+        rows := []models.ImportCategory {
+            { Row: 2, Category: models.Category{ CategoryName: "Cycling" }},
+        }
+        if summary := repo.ImportCategories(rows, true); summary.Created != 1 ||
+                summary.Committed {
+            subT.Fatalf("Unexpected dry run summary: %v", summary)
+        }
+        before, err := repo.GetCategories(ctx)
+        check(subT, err)
+        summary := repo.ImportCategories(rows, false)
+        after, err := repo.GetCategories(ctx)
+        check(subT, err)
+        if !summary.Committed || len(after) != len(before) + 1 {
+            subT.Fatalf("Unexpected import summary: %v", summary)
+        }
+    })
+
+    t.Run("AuditEntries", func(subT *testing.T) {
+        start := time.Now().UTC().Add(-time.Minute)
+        entries := []models.AuditEntry {
+            { Time: start.Add(time.Second), ActorName: "Alice", Action: "update",
+                Entity: "product", EntityID: 1, 
+                Changes: []models.AuditChange {{ Field: "Price", Before: "275", 
+                    After: "300" }}},
+            { Time: start.Add(2 * time.Second), ActorName: "Bob", Action: "create",
+                Entity: "category", EntityID: 2 },
+            { Time: start.Add(3 * time.Second), ActorName: "Alice", Action: "create",
+                Entity: "product", EntityID: 3 },
+        }
+        for i := range entries {
+            check(subT, repo.SaveAuditEntry(ctx, &entries[i]))
+        }
+        filters := []struct {
+            filter models.AuditFilter
+            expected []int
+        }{
+            { models.AuditFilter{ From: start }, []int { 3, 2, 1 } },
+            { models.AuditFilter{ From: start, Actor: "Alice" }, []int { 3, 1 } },
+            { models.AuditFilter{ From: start, Action: "create" }, []int { 3, 2 } },
+            { models.AuditFilter{ From: start, Entity: "product", EntityID: 1 }, 
+                []int { 1 } },
+            { models.AuditFilter{ From: start, To: start.Add(2 * time.Second) }, 
+                []int { 1 } },
+            { models.AuditFilter{ From: start, Limit: 1 }, []int { 3 } },
+        }
+        for _, test := range filters {
+            results, err := repo.GetAuditEntries(ctx, test.filter)
+            check(subT, err)
+            ids := []int {}
+            for _, entry := range results {
+                for i, saved := range entries {
+                    if entry.ID == saved.ID {
+                        ids = append(ids, i + 1)
+                    }
+                }
+            }
+            if fmt.Sprint(ids) != fmt.Sprint(test.expected) {
+                subT.Fatalf("Filter %+v: expected %v, got %v", test.filter, 
+                    test.expected, ids)
+            }
+        }
+        results, err := repo.GetAuditEntries(ctx, models.AuditFilter{ From: start,
+            EntityID: 1 })
+        check(subT, err)
+        if len(results) != 1 || len(results[0].Changes) != 1 || 
+                results[0].Changes[0] != entries[0].Changes[0] {
+            subT.Fatalf("Expected changes to be stored, got %+v", results)
+        }
+        check(subT, repo.Init(ctx))
+        check(subT, repo.Seed(ctx))
+        results, err = repo.GetAuditEntries(ctx, models.AuditFilter{ From: start })
+        check(subT, err)
+        if len(results) != len(entries) {
+            subT.Fatalf("Expected audit log to survive initialization, got %v", 
+                len(results))
+        }
+    })
+    t.Run("LinePrices", func(subT *testing.T) {
+        p, err := repo.GetProduct(ctx, 6)
+        check(subT, err)
+        p.Price = p.Price - 1
+        order := models.Order{
+            ShippingDetails: models.ShippingDetails{ Name: "Dave",
+                Email: "dave@example.com", StreetAddr: "2 Low St", City: "York", 
+                State: "Yorkshire", Zip: "YO1 1AA", Country: "UK" },
+            Products: []models.ProductSelection {{ Quantity: 3, Product: p }},
+            OrderTotals: models.OrderTotals{ Subtotal: 3 * p.Price, Discount: 5, 
+                Tax: 6.67, Total: 3 * p.Price - 5, TaxName: "VAT", TaxRate: 20, 
+                TaxIncluded: true },
+        }
+        check(subT, repo.SaveOrder(ctx, &order))
+        lines, err := repo.GetSalesLines(ctx, time.Now().Add(-time.Hour), 
+            time.Now().Add(time.Hour))
+        check(subT, err)
+        found := false
+        for _, line := range lines {
+            if line.OrderID == order.ID {
+                found = true
+                if line.Price != p.Price || line.Revenue() != 3 * p.Price || 
+                        line.CreatedEstimated || line.PricesEstimated ||
+                        line.OrderTotal != order.Total || line.OrderTax != order.Tax {
+                    subT.Fatalf("Expected the price at order time, got %+v", line)
+                }
+            }
+        }
+        if !found {
+            subT.Fatalf("Expected a sales line for order %v", order.ID)
+        }
+        stored, err := repo.GetOrder(ctx, order.ID)
+        check(subT, err)
+        if len(stored.Products) != 1 || stored.Products[0].Price != p.Price {
+            subT.Fatalf("Expected the order to use the price at order time: %+v", 
+                stored.Products)
+        }
+        orders, err := repo.GetOrders(ctx)
+        check(subT, err)
+        for _, o := range orders {
+            if o.ID == order.ID && o.Products[0].Price != p.Price {
+                subT.Fatalf("Expected orders to use the price at order time: %+v", 
+                    o.Products)
+            }
+        }
+    })
+}
@@ -0,0 +1,164 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "strconv"
+    "strings"
+    "errors"
+    "github.com/go-sql-driver/mysql"
+    "github.com/lib/pq"
+    "modernc.org/sqlite"
+)
+
+type Dialect interface {
+    Name() string
+    DriverName() string
+    PrepareDSN(dsn string) (string, error)
+    Rebind(query string) string
+    InsertReturnsID() bool
+    IsRetryable(err error) bool
+    IsConflict(err error) bool
+}
+
+func GetDialect(name string) (Dialect, error) {
+    switch strings.ToLower(name) {
+        case "", "sqlite", "sqlite3":
+            return sqliteDialect{}, nil
+        case "postgres", "postgresql":
+            return postgresDialect{}, nil
+        case "mysql":
+            return mysqlDialect{}, nil
+        default:
+            return nil, fmt.Errorf("Unknown SQL dialect: %v", name)
+    }
+}
+
+type sqliteDialect struct {}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+func (sqliteDialect) DriverName() string { return "sqlite" }
+func (sqliteDialect) PrepareDSN(dsn string) (string, error) {
+    if strings.Contains(dsn, "busy_timeout") {
+        return dsn, nil
+    }
+    separator := "?"
+    if strings.Contains(dsn, "?") {
+        separator = "&"
+    }
+    return dsn + separator + "_pragma=busy_timeout(5000)", nil
+}
+func (sqliteDialect) Rebind(query string) string { return query }
+func (sqliteDialect) InsertReturnsID() bool { return false }
+
+func (sqliteDialect) IsRetryable(err error) bool {
+    var sqliteErr *sqlite.Error
+    if errors.As(err, &sqliteErr) {
+        code := sqliteErr.Code() & 0xff
+        return code == 5 || code == 6
+    }
+    return false
+}
+
+func (sqliteDialect) IsConflict(err error) bool {
+    var sqliteErr *sqlite.Error
+    return errors.As(err, &sqliteErr) && sqliteErr.Code() & 0xff == 19
+}
+
+type postgresDialect struct {}
+
+func (postgresDialect) Name() string { return "postgres" }
+func (postgresDialect) DriverName() string { return "postgres" }
+func (postgresDialect) PrepareDSN(dsn string) (string, error) { return dsn, nil }
+func (postgresDialect) InsertReturnsID() bool { return true }
+
+func (postgresDialect) IsRetryable(err error) bool {
+    var pqErr *pq.Error
+    return errors.As(err, &pqErr) &&
+        (pqErr.Code == "40001" || pqErr.Code == "40P01" || pqErr.Code == "55P03")
+}
+
+func (postgresDialect) IsConflict(err error) bool {
+    var pqErr *pq.Error
+    return errors.As(err, &pqErr) && pqErr.Code.Class() == "23"
+}
+
+func (postgresDialect) Rebind(query string) string {
+    var sb strings.Builder
+    param := 0
+    for i := 0; i < len(query); i++ {
+        if end := skipQuoted(query, i); end > i {
+            sb.WriteString(query[i:end])
+            i = end - 1
+        } else if query[i] == '?' {
+            param++
+            sb.WriteString("$" + strconv.Itoa(param))
+        } else {
+            sb.WriteByte(query[i])
+        }
+    }
+    return sb.String()
+}
+
+func skipQuoted(query string, start int) int {
+    var terminator string
+    switch {
+        case query[start] == '\'' || query[start] == '"':
+            terminator = query[start:start + 1]
+        case strings.HasPrefix(query[start:], "--"):
+            terminator = "\n"
+        case strings.HasPrefix(query[start:], "/*"):
+            terminator = "*/"
+        default:
+            return start
+    }
+    end := strings.Index(query[start + 1:], terminator)
+    if end == -1 {
+        return len(query)
+    }
+    return start + 1 + end + len(terminator)
+}
+
+type mysqlDialect struct {}
+
+func (mysqlDialect) Name() string { return "mysql" }
+func (mysqlDialect) DriverName() string { return "mysql" }
+func (mysqlDialect) Rebind(query string) string { return query }
+func (mysqlDialect) InsertReturnsID() bool { return false }
+
+func (mysqlDialect) IsRetryable(err error) bool {
+    var mysqlErr *mysql.MySQLError
+    return errors.As(err, &mysqlErr) &&
+        (mysqlErr.Number == 1205 || mysqlErr.Number == 1213)
+}
+
+func (mysqlDialect) IsConflict(err error) bool {
+    var mysqlErr *mysql.MySQLError
+    return errors.As(err, &mysqlErr) && (mysqlErr.Number == 1062 ||
+        mysqlErr.Number == 1451 || mysqlErr.Number == 1452)
+}
+
+func (mysqlDialect) PrepareDSN(dsn string) (string, error) {
+    cfg, err := mysql.ParseDSN(dsn)
+    if err != nil {
+        return "", err
+    }
+    cfg.MultiStatements = true
+    cfg.ParseTime = true
+    cfg.ClientFoundRows = true
+    return cfg.FormatDSN(), nil
+}
+
+func (repo *SqlRepository) execInsert(ctx context.Context, stmt *sql.Stmt,
+        args ...interface{}) (id int64, err error) {
+    if repo.Dialect.InsertReturnsID() {
+        err = stmt.QueryRowContext(ctx, args...).Scan(&id)
+        return
+    }
+    result, err := stmt.ExecContext(ctx, args...)
+    if err == nil {
+        id, err = result.LastInsertId()
+    }
+    return
+}
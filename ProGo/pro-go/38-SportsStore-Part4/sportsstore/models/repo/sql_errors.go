@@ -0,0 +1,57 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "fmt"
+    "sportsstore/models"
+    "time"
+)
+
+func (repo *SqlRepository) withRetry(ctx context.Context, 
+        operation func() error) (err error) {
+    attempts := repo.Configuration.GetIntDefault("sql:retry:attempts", 3)
+    delay, parseErr := time.ParseDuration(
+        repo.Configuration.GetStringDefault("sql:retry:delay", "25ms"))
+    if parseErr != nil {
+        return parseErr
+    }
+    for attempt := 1; ; attempt++ {
+        err = operation()
+        if err == nil || attempt >= attempts || !repo.Dialect.IsRetryable(err) {
+            break
+        }
+        repo.Logger.Debugf("Retrying after transient database error: %v", err)
+        select {
+            case <-ctx.Done():
+                return ctx.Err()
+            case <-time.After(delay):
+                delay *= 2
+        }
+    }
+    return repo.translateError(err)
+}
+
+func (repo *SqlRepository) translateError(err error) error {
+    switch {
+        case err == nil:
+            return nil
+        case errors.Is(err, sql.ErrNoRows):
+            return models.ErrNotFound
+        case repo.Dialect.IsConflict(err):
+            return fmt.Errorf("%w: %v", models.ErrConflict, err)
+        default:
+            return err
+    }
+}
+
+func checkAffected(result sql.Result) error {
+    affected, err := result.RowsAffected()
+    if err == nil && affected == 0 {
+        return models.ErrNotFound
+    } else if err == nil && affected != 1 {
+        return fmt.Errorf("Got unexpected rows affected: %v", affected)
+    }
+    return err
+}
@@ -0,0 +1,48 @@
+package repo
+
+import (
+    "context"
+    "platform/features"
+    "time"
+)
+
+func (repo *SqlRepository) GetFlags(ctx context.Context) (
+        flags []features.Flag, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetFeatureFlags.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        flags = []features.Flag {}
+        for rows.Next() {
+            var f features.Flag
+            if err = rows.Scan(&f.Name, &f.Description, &f.Enabled, 
+                    &f.Percentage, &f.KeyBy); err != nil {
+                return err
+            }
+            flags = append(flags, f.Normalize())
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) SaveFlag(ctx context.Context, flag features.Flag) error {
+    flag = flag.Normalize()
+    return repo.withRetry(ctx, func() error {
+        now := time.Now().UTC()
+        result, err := repo.Commands.UpdateFeatureFlag.ExecContext(ctx, 
+            flag.Description, flag.Enabled, flag.Percentage, flag.KeyBy, now, 
+            flag.Name)
+        if err != nil {
+            return err
+        }
+        if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+            return err
+        }
+        _, err = repo.Commands.SaveFeatureFlag.ExecContext(ctx, flag.Name, 
+            flag.Description, flag.Enabled, flag.Percentage, flag.KeyBy, now)
+        return err
+    })
+}
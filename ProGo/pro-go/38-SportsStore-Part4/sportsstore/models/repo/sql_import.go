@@ -0,0 +1,150 @@
+package repo
+
+import (
+    "database/sql"
+    "fmt"
+    "sportsstore/models"
+    "strings"
+)
+
+func (repo *SqlRepository) ImportCategories(rows []models.ImportCategory, 
+        dryRun bool) (summary models.ImportSummary) {
+    summary.DryRun = dryRun
+    tx, err := repo.DB.BeginTx(repo.Context, nil)
+    if err != nil {
+        repo.Logger.Panicf("Cannot create transaction: %v", err.Error())
+        return
+    }
+    byId, byName := repo.loadCategoryMaps(tx)
+    for _, row := range rows {
+        result := models.ImportRow { Row: row.Row, ID: row.ID, Name: row.CategoryName }
+        if row.ID == 0 {
+            result.ID = byName[strings.ToLower(row.CategoryName)]
+        }
+        if _, found := byId[result.ID]; found {
+            result.Action = models.ImportUpdate
+            _, err = tx.StmtContext(repo.Context, repo.Commands.UpdateCategory).
+                Exec(row.CategoryName, result.ID)
+        } else if row.ID > 0 {
+            result.Action = models.ImportInvalid
+            err = fmt.Errorf("There is no category with ID %v", row.ID)
+        } else {
+            result.Action = models.ImportCreate
+            var id int64
+            id, err = repo.execInsert(repo.Context, tx.StmtContext(repo.Context, 
+                repo.Commands.SaveCategory), row.CategoryName)
+            result.ID = int(id)
+        }
+        if err == nil {
+            delete(byName, strings.ToLower(byId[result.ID]))
+            byId[result.ID] = row.CategoryName
+            byName[strings.ToLower(row.CategoryName)] = result.ID
+        } else {
+            result.Action, result.Error = models.ImportInvalid, err.Error()
+        }
+        summary.Add(result)
+    }
+    repo.completeImport(tx, &summary)
+    return
+}
+
+func (repo *SqlRepository) ImportProducts(rows []models.ImportProduct, 
+        dryRun bool) (summary models.ImportSummary) {
+    summary.DryRun = dryRun
+    tx, err := repo.DB.BeginTx(repo.Context, nil)
+    if err != nil {
+        repo.Logger.Panicf("Cannot create transaction: %v", err.Error())
+        return
+    }
+    categoriesById, categoriesByName := repo.loadCategoryMaps(tx)
+    productsById, productsByName := map[int]string {}, map[string]int {}
+    existing, err := tx.StmtContext(repo.Context, repo.Commands.GetProducts).Query()
+    if err == nil {
+        var products []models.Product
+        if products, err = scanProducts(existing); err == nil {
+            for _, p := range products {
+                productsById[p.ID] = p.Name
+                productsByName[strings.ToLower(p.Name)] = p.ID
+            }
+        }
+    }
+    if err != nil {
+        tx.Rollback()
+        repo.Logger.Panicf("Cannot load products for import: %v", err.Error())
+        return
+    }
+    for _, row := range rows {
+        result := models.ImportRow { Row: row.Row, ID: row.ID, Name: row.Name }
+        categoryId := 0
+        if row.Category != nil {
+            categoryId = row.Category.ID
+            if categoryId == 0 {
+                categoryId = categoriesByName[strings.ToLower(row.Category.CategoryName)]
+            }
+        }
+        if row.ID == 0 {
+            result.ID = productsByName[strings.ToLower(row.Name)]
+        }
+        err = nil
+        if _, found := categoriesById[categoryId]; !found {
+            err = fmt.Errorf("Unknown category")
+        } else if _, found := productsById[result.ID]; found {
+            result.Action = models.ImportUpdate
+            _, err = tx.StmtContext(repo.Context, repo.Commands.UpdateProduct).
+                Exec(row.Name, row.Description, categoryId, row.Price, result.ID)
+        } else if row.ID > 0 {
+            err = fmt.Errorf("There is no product with ID %v", row.ID)
+        } else {
+            result.Action = models.ImportCreate
+            var id int64
+            id, err = repo.execInsert(repo.Context, tx.StmtContext(repo.Context, 
+                repo.Commands.SaveProduct), row.Name, row.Description, categoryId, row.Price)
+            result.ID = int(id)
+        }
+        if err == nil {
+            delete(productsByName, strings.ToLower(productsById[result.ID]))
+            productsById[result.ID] = row.Name
+            productsByName[strings.ToLower(row.Name)] = result.ID
+        } else {
+            result.Action, result.Error = models.ImportInvalid, err.Error()
+        }
+        summary.Add(result)
+    }
+    repo.completeImport(tx, &summary)
+    return
+}
+
+func (repo *SqlRepository) loadCategoryMaps(tx *sql.Tx) (byId map[int]string, 
+        byName map[string]int) {
+    byId, byName = map[int]string {}, map[string]int {}
+    rows, err := tx.StmtContext(repo.Context, repo.Commands.GetCategories).Query()
+    if err != nil {
+        tx.Rollback()
+        repo.Logger.Panicf("Cannot exec GetCategories command: %v", err)
+        return
+    }
+    for rows.Next() {
+        c := models.Category{}
+        if err := rows.Scan(&c.ID, &c.CategoryName); err != nil {
+            tx.Rollback()
+            repo.Logger.Panicf("Cannot scan data: %v", err.Error())
+            return
+        }
+        byId[c.ID] = c.CategoryName
+        byName[strings.ToLower(c.CategoryName)] = c.ID
+    }
+    return
+}
+
+func (repo *SqlRepository) completeImport(tx *sql.Tx, 
+        summary *models.ImportSummary) {
+    if summary.DryRun || summary.Failed > 0 {
+        if err := tx.Rollback(); err != nil {
+            repo.Logger.Panicf("Transaction cannot be rolled back: %v", err.Error())
+        }
+    } else if err := tx.Commit(); err != nil {
+        repo.Logger.Panicf("Transaction cannot be committed: %v", err.Error())
+    } else {
+        summary.Committed = true
+    }
+}
@@ -1,13 +1,25 @@
 package repo
 
-func (repo *SqlRepository) Init() {
-    if _, err := repo.Commands.Init.ExecContext(repo.Context); err != nil {
-        repo.Logger.Panic("Cannot exec init command")
-    }
+import (
+    "context"
+    "fmt"
+)
+
+// The audit log is append-only and must survive reinitializing the store
+const auditLogMigration = "add_audit_log"
+
+func (repo *SqlRepository) Init(ctx context.Context) error {
+    return repo.Migrator.Reset(ctx, auditLogMigration)
 }
 
-func (repo *SqlRepository) Seed() {
-    if _, err := repo.Commands.Seed.ExecContext(repo.Context); err != nil {
-        repo.Logger.Panic("Cannot exec seed command")
+func (repo *SqlRepository) Seed(ctx context.Context) error {
+    filename := repo.Configuration.GetStringDefault("sql:seed", "sql/seed_db.sql")
+    script, err := readScript(repo.Dialect, filename)
+    if err != nil {
+        return fmt.Errorf("Cannot read SQL seed file: %v", filename)
     }
+    return repo.withRetry(ctx, func() error {
+        _, err := repo.DB.ExecContext(ctx, script)
+        return err
+    })
 }
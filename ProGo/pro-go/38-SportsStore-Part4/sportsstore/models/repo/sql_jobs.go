@@ -0,0 +1,159 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "platform/jobs"
+    "time"
+)
+
+const maxClaimAttempts = 5
+
+func scanJob(scanner interface{ Scan(...interface{}) error }) (job jobs.Job, 
+        err error) {
+    err = scanner.Scan(&job.ID, &job.Name, &job.Payload, &job.Status, 
+        &job.Attempts, &job.MaxAttempts, &job.RunAt, &job.Created, &job.Updated,
+        &job.LastError)
+    return
+}
+
+func (repo *SqlRepository) Enqueue(ctx context.Context, job *jobs.Job) error {
+    now := time.Now().UTC()
+    if job.RunAt.IsZero() {
+        job.RunAt = now
+    }
+    return repo.withRetry(ctx, func() error {
+        id, err := repo.execInsert(ctx, repo.Commands.SaveJob, job.Name, 
+            job.Payload, jobs.StatusPending, job.Attempts, job.MaxAttempts, 
+            job.RunAt.UTC(), now, now, job.LastError)
+        if err == nil {
+            job.ID, job.Status, job.Created, job.Updated = int(id), 
+                jobs.StatusPending, now, now
+        }
+        return err
+    })
+}
+
+func (repo *SqlRepository) Claim(ctx context.Context, now time.Time, 
+        lease time.Duration) (job jobs.Job, found bool, err error) {
+    err = repo.withRetry(ctx, func() error {
+        for i := 0; i < maxClaimAttempts; i++ {
+            candidate, err := scanJob(repo.Commands.GetNextJob.QueryRowContext(ctx, 
+                now.UTC()))
+            if errors.Is(err, sql.ErrNoRows) {
+                return nil
+            } else if err != nil {
+                return err
+            }
+            runAt, updated := now.Add(lease).UTC(), now.UTC()
+            result, err := repo.Commands.ClaimJob.ExecContext(ctx, runAt, updated, 
+                candidate.ID, candidate.Status, candidate.Attempts)
+            if err != nil {
+                return err
+            }
+            if affected, err := result.RowsAffected(); err != nil {
+                return err
+            } else if affected == 1 {
+                candidate.Status, candidate.Attempts = jobs.StatusRunning, 
+                    candidate.Attempts + 1
+                candidate.RunAt, candidate.Updated = runAt, updated
+                job, found = candidate, true
+                return nil
+            }
+        }
+        return nil
+    })
+    return
+}
+
+func (repo *SqlRepository) Update(ctx context.Context, job *jobs.Job) error {
+    now := time.Now().UTC()
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.UpdateJob.ExecContext(ctx, job.Status, 
+            job.Attempts, job.RunAt.UTC(), now, job.LastError, job.ID)
+        if err != nil {
+            return err
+        }
+        if affected, err := result.RowsAffected(); err != nil {
+            return err
+        } else if affected == 0 {
+            return jobs.ErrJobNotFound
+        }
+        job.Updated = now
+        return nil
+    })
+}
+
+func (repo *SqlRepository) GetJob(ctx context.Context, id int) (job jobs.Job, 
+        err error) {
+    err = repo.withRetry(ctx, func() error {
+        job, err = scanJob(repo.Commands.GetJob.QueryRowContext(ctx, id))
+        if errors.Is(err, sql.ErrNoRows) {
+            return jobs.ErrJobNotFound
+        }
+        return err
+    })
+    return
+}
+
+func (repo *SqlRepository) GetJobs(ctx context.Context, 
+        filter jobs.JobFilter) (result []jobs.Job, err error) {
+    if filter.Limit <= 0 {
+        filter.Limit = 100
+    }
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetJobs.QueryContext(ctx, filter.Status, 
+            filter.Status, filter.Name, filter.Name, filter.Limit)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        result = []jobs.Job {}
+        for rows.Next() {
+            job, err := scanJob(rows)
+            if err != nil {
+                return err
+            }
+            result = append(result, job)
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) GetJobCounts(ctx context.Context) (counts map[string]int, 
+        err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetJobCounts.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        counts = map[string]int {}
+        for rows.Next() {
+            var status string
+            var count int
+            if err = rows.Scan(&status, &count); err != nil {
+                return err
+            }
+            counts[status] = count
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) Prune(ctx context.Context, before time.Time) (count int,
+        err error) {
+    err = repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.PruneJobs.ExecContext(ctx, before.UTC())
+        if err != nil {
+            return err
+        }
+        affected, err := result.RowsAffected()
+        count = int(affected)
+        return err
+    })
+    return
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+    "context"
+    "errors"
+    "path/filepath"
+    "platform/jobs"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestSchedulerRetries(t *testing.T) {
+    sqlRepo := newContractRepo(t, contractBackend{ dialect: "sqlite",
+        dsn: filepath.Join(t.TempDir(), "jobs.db") })
+    cfg := testConfig{ Configuration: sqlRepo.Configuration, values: map[string]string {
+        "jobs:backoff": "10ms",
+        "jobs:pollInterval": "10ms",
+    }}
+    scheduler := jobs.NewScheduler(sqlRepo, sqlRepo.Logger, cfg)
+    var calls int32
+    check(t, scheduler.Handle("flaky", func(job jobs.Job, ctx context.Context) error {
+        if atomic.AddInt32(&calls, 1) < 3 {
+            return errors.New("transient failure")
+        }
+        return nil
+    }))
+    ctx, cancel := context.WithCancel(context.Background())
+    wg := scheduler.Start(ctx)
+    defer func() {
+        cancel()
+        wg.Wait()
+    }()
+    job, err := scheduler.Enqueue(ctx, "flaky", nil)
+    check(t, err)
+    missing, err := scheduler.Enqueue(ctx, "missing", nil)
+    check(t, err)
+    deadline := time.Now().Add(5 * time.Second)
+    for time.Now().Before(deadline) {
+        stored, err := sqlRepo.GetJob(ctx, job.ID)
+        check(t, err)
+        storedMissing, err := sqlRepo.GetJob(ctx, missing.ID)
+        check(t, err)
+        if stored.Status == jobs.StatusSucceeded && 
+                storedMissing.Status != jobs.StatusRunning &&
+                storedMissing.Status != jobs.StatusPending {
+            if stored.Attempts != 3 || atomic.LoadInt32(&calls) != 3 {
+                t.Fatalf("Expected success on the third attempt: %v", stored)
+            }
+            if storedMissing.Status != jobs.StatusFailed || 
+                    storedMissing.Attempts != 1 {
+                t.Fatalf("Expected job without handler to fail: %v", storedMissing)
+            }
+            return
+        }
+        time.Sleep(20 * time.Millisecond)
+    }
+    t.Fatal("Job did not complete")
+}
@@ -0,0 +1,168 @@
+package repo
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sportsstore/models"
+)
+
+const keysetSelect = `SELECT Products.Id, Products.Name, Products.Description, 
+    Products.Price, Products.Image, Products.Weight, Products.Rating, 
+    Products.ReviewCount, Categories.Id, Categories.Name 
+FROM Products, Categories 
+WHERE Products.Category = Categories.Id`
+
+var keysetColumns = map[string]string {
+    models.SortByID: "Products.Id",
+    models.SortByName: "Products.Name",
+    models.SortByPrice: "Products.Price",
+    models.SortByRating: "Products.Rating",
+}
+
+var keysetDescending = map[string]bool {
+    models.SortByRating: true,
+}
+
+func (repo *SqlRepository) GetProductsKeyset(ctx context.Context, 
+        query models.ProductQuery) (page models.ProductKeysetPage, err error) {
+    if err = query.Validate(); err != nil {
+        return
+    }
+    sql, args, reverse := buildKeysetQuery(query)
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.DB.QueryContext(ctx, repo.Dialect.Rebind(sql), args...)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        page.Products, err = scanProducts(rows)
+        return err
+    })
+    if err != nil {
+        return
+    }
+    more := len(page.Products) > query.Limit
+    if more {
+        page.Products = page.Products[:query.Limit]
+    }
+    if reverse {
+        for i, j := 0, len(page.Products) - 1; i < j; i, j = i + 1, j - 1 {
+            page.Products[i], page.Products[j] = page.Products[j], page.Products[i]
+        }
+        page.HasPrev, page.HasNext = more, true
+    } else {
+        page.HasNext, page.HasPrev = more, query.After != nil
+    }
+    if query.IncludeTotal {
+        page.Total, err = repo.countProducts(ctx, query.CategoryID)
+        page.TotalIncluded = err == nil
+    }
+    return
+}
+
+func buildKeysetQuery(query models.ProductQuery) (sql string, 
+        args []interface{}, reverse bool) {
+    var sb strings.Builder
+    sb.WriteString(keysetSelect)
+    if query.CategoryID > 0 {
+        sb.WriteString(" AND Products.Category = ?")
+        args = append(args, query.CategoryID)
+    }
+    column := keysetColumns[query.SortBy]
+    op, direction, key := ">", "ASC", query.After
+    if query.Before != nil {
+        op, direction, key, reverse = "<", "DESC", query.Before, true
+    }
+    if keysetDescending[query.SortBy] {
+        op, direction = flipKeyset(op, direction)
+    }
+    if key != nil {
+        var value interface{} = key.ID
+        switch query.SortBy {
+            case models.SortByName:
+                value = key.Name
+            case models.SortByPrice:
+                value = key.Price
+            case models.SortByRating:
+                value = key.Rating
+        }
+        if query.SortBy == models.SortByID {
+            sb.WriteString(" AND Products.Id " + op + " ?")
+            args = append(args, key.ID)
+        } else {
+            sb.WriteString(" AND (" + column + " " + op + " ? OR (" + column + 
+                " = ? AND Products.Id " + op + " ?))")
+            args = append(args, value, value, key.ID)
+        }
+    }
+    if query.SortBy == models.SortByID {
+        sb.WriteString(" ORDER BY Products.Id " + direction)
+    } else {
+        sb.WriteString(" ORDER BY " + column + " " + direction + 
+            ", Products.Id " + direction)
+    }
+    sb.WriteString(" LIMIT ?")
+    args = append(args, query.Limit + 1)
+    return sb.String(), args, reverse
+}
+
+func flipKeyset(op, direction string) (string, string) {
+    if op == ">" {
+        return "<", "DESC"
+    }
+    return ">", "ASC"
+}
+
+func (repo *SqlRepository) GetProductPageSorted(ctx context.Context, categoryId int, 
+        sortBy string, page, pageSize int) (products []models.Product, 
+            totalAvailable int, err error) {
+    if sortBy == "" || sortBy == models.SortByID {
+        return repo.GetProductPageCategory(ctx, categoryId, page, pageSize)
+    }
+    column, found := keysetColumns[sortBy]
+    if !found {
+        err = fmt.Errorf("%w: cannot sort by %v", models.ErrInvalidQuery, sortBy)
+        return
+    }
+    direction := "ASC"
+    if keysetDescending[sortBy] {
+        direction = "DESC"
+    }
+    var sb strings.Builder
+    args := []interface{} {}
+    sb.WriteString(keysetSelect)
+    if categoryId > 0 {
+        sb.WriteString(" AND Products.Category = ?")
+        args = append(args, categoryId)
+    }
+    sb.WriteString(" ORDER BY " + column + " " + direction + ", Products.Id " + 
+        direction + " LIMIT ? OFFSET ?")
+    args = append(args, pageSize, (pageSize * page) - pageSize)
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.DB.QueryContext(ctx, repo.Dialect.Rebind(sb.String()), 
+            args...)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        products, err = scanProducts(rows)
+        return err
+    })
+    if err == nil {
+        totalAvailable, err = repo.countProducts(ctx, categoryId)
+    }
+    return
+}
+
+func (repo *SqlRepository) countProducts(ctx context.Context, 
+        categoryId int) (count int, err error) {
+    err = repo.withRetry(ctx, func() error {
+        if categoryId > 0 {
+            return repo.Commands.GetCategoryPageCount.QueryRowContext(ctx, 
+                categoryId).Scan(&count)
+        }
+        return repo.Commands.GetPageCount.QueryRowContext(ctx).Scan(&count)
+    })
+    return
+}
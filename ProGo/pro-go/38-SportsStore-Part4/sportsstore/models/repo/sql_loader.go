@@ -1,35 +1,128 @@
 package repo
 
 import (
+    "context"
+    "errors"
     "os"
     "database/sql"
+    "path/filepath"
     "reflect"
+    "time"
     "platform/config"
     "platform/logging"
-    _ "modernc.org/sqlite"
 )
 
-func openDB(config config.Configuration, logger logging.Logger) (db *sql.DB, 
-        commands *SqlCommands, needInit bool) {
-    driver := config.GetStringDefault("sql:driver_name", "sqlite")
+func openDB(config config.Configuration, logger logging.Logger) (db *sql.DB,
+        dialect Dialect, commands *SqlCommands, migrator *Migrator,
+        needInit bool) {
+    db, dialect, err := connect(config)
+    if err != nil {
+        logger.Panic(err.Error())
+        return
+    }
+    configurePool(db, config, logger)
+    migrator, needInit = migrateDB(db, dialect, config, logger)
+    commands = loadCommands(db, dialect, config, logger)
+    return
+}
+
+func connect(config config.Configuration) (db *sql.DB, dialect Dialect,
+        err error) {
+    dialect, err = GetDialect(config.GetStringDefault("sql:dialect", "sqlite"))
+    if err != nil {
+        return
+    }
     connectionStr, found := config.GetString("sql:connection_str")
     if !found {
-        logger.Panic("Cannot read SQL connection string from config")
+        err = errors.New("Cannot read SQL connection string from config")
         return
-    } 
-    if _, err := os.Stat(connectionStr); os.IsNotExist(err) {
-        needInit = true
-    }
-    var err error
-    if db, err = sql.Open(driver, connectionStr); err == nil {
-        commands = loadCommands(db, config, logger)
-    } else {
+    }
+    if connectionStr, err = dialect.PrepareDSN(connectionStr); err == nil {
+        db, err = sql.Open(config.GetStringDefault("sql:driver_name",
+            dialect.DriverName()), connectionStr)
+    }
+    return
+}
+
+func configurePool(db *sql.DB, config config.Configuration,
+        logger logging.Logger) {
+    if val, found := config.GetInt("sql:pool:max_open_conns"); found {
+        db.SetMaxOpenConns(val)
+    }
+    if val, found := config.GetInt("sql:pool:max_idle_conns"); found {
+        db.SetMaxIdleConns(val)
+    }
+    if val, found := config.GetString("sql:pool:conn_max_lifetime"); found {
+        if duration, err := time.ParseDuration(val); err == nil {
+            db.SetConnMaxLifetime(duration)
+        } else {
+            logger.Panicf("Invalid SQL connection lifetime: %v", val)
+        }
+    }
+    if val, found := config.GetString("sql:pool:conn_max_idle_time"); found {
+        if duration, err := time.ParseDuration(val); err == nil {
+            db.SetConnMaxIdleTime(duration)
+        } else {
+            logger.Panicf("Invalid SQL connection idle time: %v", val)
+        }
+    }
+}
+
+func OpenMigrator(config config.Configuration,
+        logger logging.Logger) (*Migrator, error) {
+    db, dialect, err := connect(config)
+    if err != nil {
+        return nil, err
+    }
+    return newConfiguredMigrator(db, dialect, config, logger)
+}
+
+func newConfiguredMigrator(db *sql.DB, dialect Dialect,
+        config config.Configuration, logger logging.Logger) (*Migrator, error) {
+    return NewMigrator(db, dialect, dialectPath(dialect,
+        config.GetStringDefault("sql:migrations:path", "sql/migrations")), logger)
+}
+
+func migrateDB(db *sql.DB, dialect Dialect, config config.Configuration,
+        logger logging.Logger) (migrator *Migrator, fresh bool) {
+    migrator, err := newConfiguredMigrator(db, dialect, config, logger)
+    if err != nil {
+        logger.Panicf("Cannot load migrations: %v", err.Error())
+    }
+    ctx := context.Background()
+    status, err := migrator.Status(ctx)
+    if err != nil {
         logger.Panic(err.Error())
     }
-    return   
+    pending := 0
+    for _, s := range status {
+        if !s.Applied {
+            pending++
+        }
+    }
+    if config.GetBoolDefault("sql:migrations:auto", true) {
+        if count, err := migrator.Up(ctx); err != nil {
+            logger.Panic(err.Error())
+        } else if count > 0 {
+            logger.Infof("Applied %v migration(s)", count)
+            fresh = count == len(status)
+        }
+    } else if pending > 0 {
+        logger.Warnf("Database has %v pending migration(s)", pending)
+    }
+    return
+}
+
+func dialectPath(dialect Dialect, path string) string {
+    dialectPath := filepath.Join(filepath.Dir(path), dialect.Name(),
+        filepath.Base(path))
+    if _, err := os.Stat(dialectPath); err == nil {
+        return dialectPath
+    }
+    return path
 }
 
-func loadCommands(db *sql.DB, config config.Configuration, 
+func loadCommands(db *sql.DB, dialect Dialect, config config.Configuration,
         logger logging.Logger) (commands *SqlCommands)  {
     commands = &SqlCommands {}
     commandVal := reflect.ValueOf(commands).Elem()
@@ -37,26 +130,31 @@ func loadCommands(db *sql.DB, config config.Configuration,
     for i := 0; i < commandType.NumField(); i++ {
         commandName := commandType.Field(i).Name
         logger.Debugf("Loading SQL command: %v", commandName)
-        stmt := prepareCommand(db, commandName, config, logger)
+        stmt := prepareCommand(db, dialect, commandName, config, logger)
         commandVal.Field(i).Set(reflect.ValueOf(stmt))
     }
     return commands
 }
 
-func prepareCommand(db *sql.DB, command string, config config.Configuration, 
-        logger logging.Logger) *sql.Stmt {
+func prepareCommand(db *sql.DB, dialect Dialect, command string,
+        config config.Configuration, logger logging.Logger) *sql.Stmt {
     filename, found := config.GetString("sql:commands:" + command)
     if !found {
-        logger.Panicf("Config does not contain location for SQL command: %v", 
+        logger.Panicf("Config does not contain location for SQL command: %v",
             command)
     }
-    data, err := os.ReadFile(filename)
+    data, err := readScript(dialect, filename)
     if err != nil {
         logger.Panicf("Cannot read SQL command file: %v", filename)
     }
-    statement, err := db.Prepare(string(data))
+    statement, err := db.Prepare(dialect.Rebind(data))
     if (err != nil) {
         logger.Panicf(err.Error())
     }
     return statement
 }
+
+func readScript(dialect Dialect, filename string) (string, error) {
+    data, err := os.ReadFile(dialectPath(dialect, filename))
+    return string(data), err
+}
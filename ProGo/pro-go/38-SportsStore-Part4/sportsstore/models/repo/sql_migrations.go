@@ -0,0 +1,320 @@
+package repo
+
+import (
+    "context"
+    "crypto/sha256"
+    "database/sql"
+    "encoding/hex"
+    "fmt"
+    "os"
+    "path/filepath"
+    "platform/logging"
+    "regexp"
+    "sort"
+    "strconv"
+    "time"
+)
+
+type Migration struct {
+    Version int
+    Name string
+    Up, Down string
+    Baseline string
+    Checksum string
+}
+
+type MigrationStatus struct {
+    Migration
+    Applied bool
+    AppliedAt time.Time
+    Modified bool
+    Missing bool
+}
+
+type Migrator struct {
+    db *sql.DB
+    dialect Dialect
+    logger logging.Logger
+    migrations []Migration
+}
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down|baseline)\.sql$`)
+
+const recordMigration = 
+    "INSERT INTO schema_migrations (Version, Name, Checksum, AppliedAt) VALUES (?, ?, ?, ?)"
+
+func LoadMigrations(dir string) (migrations []Migration, err error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, err
+    }
+    byVersion := map[int]*Migration {}
+    for _, entry := range entries {
+        match := migrationFile.FindStringSubmatch(entry.Name())
+        if entry.IsDir() || match == nil {
+            continue
+        }
+        version, _ := strconv.Atoi(match[1])
+        data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+        if err != nil {
+            return nil, err
+        }
+        m, found := byVersion[version]
+        if !found {
+            m = &Migration{ Version: version, Name: match[2] }
+            byVersion[version] = m
+        } else if m.Name != match[2] {
+            return nil, fmt.Errorf("Migration %v has conflicting names: %v and %v",
+                version, m.Name, match[2])
+        }
+        switch match[3] {
+            case "up":
+                m.Up = string(data)
+                sum := sha256.Sum256(data)
+                m.Checksum = hex.EncodeToString(sum[:])
+            case "down":
+                m.Down = string(data)
+            default:
+                m.Baseline = string(data)
+        }
+    }
+    for _, m := range byVersion {
+        if m.Up == "" {
+            return nil, fmt.Errorf("Migration %v (%v) has no up script",
+                m.Version, m.Name)
+        }
+        migrations = append(migrations, *m)
+    }
+    sort.Slice(migrations, func(i, j int) bool {
+        return migrations[i].Version < migrations[j].Version
+    })
+    return
+}
+
+func NewMigrator(db *sql.DB, dialect Dialect, dir string, 
+        logger logging.Logger) (*Migrator, error) {
+    migrations, err := LoadMigrations(dir)
+    if err != nil {
+        return nil, err
+    }
+    return &Migrator{ db: db, dialect: dialect, logger: logger, 
+        migrations: migrations }, nil
+}
+
+type appliedMigration struct {
+    version int
+    name, checksum string
+    appliedAt time.Time
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+    _, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+        Version INTEGER NOT NULL PRIMARY KEY,
+        Name TEXT NOT NULL,
+        Checksum TEXT NOT NULL,
+        AppliedAt TIMESTAMP NOT NULL)`)
+    return err
+}
+
+func (m *Migrator) applied(ctx context.Context) (applied []appliedMigration,
+        err error) {
+    if err = m.ensureTable(ctx); err != nil {
+        return
+    }
+    rows, err := m.db.QueryContext(ctx,
+        "SELECT Version, Name, Checksum, AppliedAt FROM schema_migrations ORDER BY Version")
+    if err != nil {
+        return
+    }
+    defer rows.Close()
+    for rows.Next() {
+        a := appliedMigration{}
+        if err = rows.Scan(&a.version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+            return
+        }
+        applied = append(applied, a)
+    }
+    return applied, rows.Err()
+}
+
+func (m *Migrator) Status(ctx context.Context) (status []MigrationStatus, err error) {
+    applied, err := m.applied(ctx)
+    if err != nil {
+        return
+    }
+    appliedMap := map[int]appliedMigration {}
+    for _, a := range applied {
+        appliedMap[a.version] = a
+    }
+    for _, migration := range m.migrations {
+        s := MigrationStatus{ Migration: migration }
+        if a, found := appliedMap[migration.Version]; found {
+            s.Applied, s.AppliedAt = true, a.appliedAt
+            s.Modified = a.checksum != migration.Checksum
+            delete(appliedMap, migration.Version)
+        }
+        status = append(status, s)
+    }
+    for _, a := range applied {
+        if _, found := appliedMap[a.version]; found {
+            status = append(status, MigrationStatus{
+                Migration: Migration{ Version: a.version, Name: a.name,
+                    Checksum: a.checksum },
+                Applied: true, AppliedAt: a.appliedAt, Missing: true,
+            })
+        }
+    }
+    sort.SliceStable(status, func(i, j int) bool {
+        return status[i].Version < status[j].Version
+    })
+    return
+}
+
+func (m *Migrator) Verify(ctx context.Context) error {
+    status, err := m.Status(ctx)
+    if err != nil {
+        return err
+    }
+    for _, s := range status {
+        if s.Modified {
+            return fmt.Errorf("Migration %v (%v) has been edited since it was applied",
+                s.Version, s.Name)
+        } else if s.Missing {
+            return fmt.Errorf("Applied migration %v (%v) cannot be found",
+                s.Version, s.Name)
+        }
+    }
+    return nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) (pending []Migration, err error) {
+    status, err := m.Status(ctx)
+    for _, s := range status {
+        if !s.Applied {
+            pending = append(pending, s.Migration)
+        }
+    }
+    return
+}
+
+func (m *Migrator) Baseline(ctx context.Context) (count int, err error) {
+    applied, err := m.applied(ctx)
+    if err != nil || len(applied) > 0 {
+        return
+    }
+    for _, migration := range m.migrations {
+        if migration.Baseline == "" || !m.probe(ctx, migration.Baseline) {
+            break
+        }
+        m.logger.Infof("Marking migration %v (%v) as applied to the existing schema",
+            migration.Version, migration.Name)
+        if _, err = m.db.ExecContext(ctx, m.dialect.Rebind(recordMigration), 
+                migration.Version, migration.Name, migration.Checksum, 
+                time.Now().UTC()); err != nil {
+            return
+        }
+        count++
+    }
+    return
+}
+
+func (m *Migrator) probe(ctx context.Context, script string) bool {
+    rows, err := m.db.QueryContext(ctx, script)
+    if err == nil {
+        rows.Close()
+    }
+    return err == nil
+}
+
+func (m *Migrator) Up(ctx context.Context) (count int, err error) {
+    if _, err = m.Baseline(ctx); err != nil {
+        return
+    }
+    if err = m.Verify(ctx); err != nil {
+        return
+    }
+    pending, err := m.Pending(ctx)
+    if err != nil {
+        return
+    }
+    for _, migration := range pending {
+        m.logger.Infof("Applying migration %v (%v)", migration.Version,
+            migration.Name)
+        err = m.run(ctx, migration.Up, func(tx *sql.Tx) (err error) {
+            _, err = tx.ExecContext(ctx, m.dialect.Rebind(recordMigration), 
+                migration.Version, migration.Name, migration.Checksum,
+                time.Now().UTC())
+            return
+        })
+        if err != nil {
+            return count, fmt.Errorf("Migration %v (%v) failed: %v",
+                migration.Version, migration.Name, err)
+        }
+        count++
+    }
+    return
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) (count int, err error) {
+    return m.rollback(ctx, steps, map[string]bool {})
+}
+
+func (m *Migrator) rollback(ctx context.Context, steps int, 
+        preserve map[string]bool) (count int, err error) {
+    if _, err = m.Baseline(ctx); err != nil {
+        return
+    }
+    status, err := m.Status(ctx)
+    if err != nil {
+        return
+    }
+    for i := len(status) - 1; i >= 0 && count < steps; i-- {
+        migration := status[i]
+        if !migration.Applied || preserve[migration.Name] {
+            continue
+        } else if migration.Missing || migration.Down == "" {
+            return count, fmt.Errorf("Migration %v (%v) cannot be rolled back",
+                migration.Version, migration.Name)
+        }
+        m.logger.Infof("Rolling back migration %v (%v)", migration.Version,
+            migration.Name)
+        err = m.run(ctx, migration.Down, func(tx *sql.Tx) (err error) {
+            _, err = tx.ExecContext(ctx, m.dialect.Rebind(
+                "DELETE FROM schema_migrations WHERE Version = ?"), migration.Version)
+            return
+        })
+        if err != nil {
+            return count, fmt.Errorf("Rollback of migration %v (%v) failed: %v",
+                migration.Version, migration.Name, err)
+        }
+        count++
+    }
+    return
+}
+
+func (m *Migrator) Reset(ctx context.Context, preserve ...string) (err error) {
+    names := map[string]bool {}
+    for _, name := range preserve {
+        names[name] = true
+    }
+    if _, err = m.rollback(ctx, len(m.migrations), names); err == nil {
+        _, err = m.Up(ctx)
+    }
+    return
+}
+
+func (m *Migrator) run(ctx context.Context, script string,
+        record func(*sql.Tx) error) error {
+    tx, err := m.db.BeginTx(ctx, nil)
+    if err != nil {
+        return err
+    }
+    if _, err = tx.ExecContext(ctx, script); err == nil {
+        err = record(tx)
+    }
+    if err != nil {
+        tx.Rollback()
+        return err
+    }
+    return tx.Commit()
+}
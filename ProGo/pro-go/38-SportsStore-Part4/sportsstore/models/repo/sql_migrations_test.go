@@ -0,0 +1,153 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "os"
+    "path/filepath"
+    "platform/config"
+    "platform/logging"
+    "strings"
+    "testing"
+    "time"
+)
+
+func newTestMigrator(t *testing.T, dir string) (*sql.DB, *Migrator) {
+    db, err := sql.Open(sqliteDialect{}.DriverName(), 
+        filepath.Join(t.TempDir(), "migrations.db"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { db.Close() })
+    return db, openTestMigrator(t, db, dir)
+}
+
+func openTestMigrator(t *testing.T, db *sql.DB, dir string) *Migrator {
+    logger := logging.NewDefaultLogger(config.NewMapConfig(map[string]interface{} {
+        "logging": map[string]interface{} { "level": "none" } }))
+    migrator, err := NewMigrator(db, sqliteDialect{}, dir, logger)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return migrator
+}
+
+func TestMigrationsUpDown(t *testing.T) {
+    ctx := context.Background()
+    _, migrator := newTestMigrator(t, "sql/migrations")
+    total := len(migrator.migrations)
+    if count, err := migrator.Up(ctx); err != nil || count != total {
+        t.Fatalf("Expected %v migrations to be applied: %v %v", total, count, err)
+    }
+    if count, err := migrator.Up(ctx); err != nil || count != 0 {
+        t.Fatalf("Expected no pending migrations: %v %v", count, err)
+    }
+    if count, err := migrator.Down(ctx, 2); err != nil || count != 2 {
+        t.Fatalf("Expected two rollbacks: %v %v", count, err)
+    }
+    pending, err := migrator.Pending(ctx)
+    if err != nil || len(pending) != 2 || 
+            pending[1].Version != migrator.migrations[total - 1].Version {
+        t.Fatalf("Unexpected pending migrations: %v %v", pending, err)
+    }
+    if err := migrator.Reset(ctx); err != nil {
+        t.Fatal(err)
+    }
+    status, err := migrator.Status(ctx)
+    check(t, err)
+    for _, s := range status {
+        if !s.Applied || s.Modified || s.Missing {
+            t.Fatalf("Unexpected status after reset: %+v", s)
+        }
+    }
+    before := map[int]time.Time {}
+    for _, s := range status {
+        before[s.Version] = s.AppliedAt
+    }
+    time.Sleep(10 * time.Millisecond)
+    check(t, migrator.Reset(ctx, "add_audit_log"))
+    status, err = migrator.Status(ctx)
+    check(t, err)
+    for _, s := range status {
+        if !s.Applied || (s.Name == "add_audit_log") != 
+                s.AppliedAt.Equal(before[s.Version]) {
+            t.Fatalf("Expected only the preserved migration to be kept: %+v", s)
+        }
+    }
+}
+
+func TestMigrationChecksumMismatch(t *testing.T) {
+    ctx := context.Background()
+    dir := t.TempDir()
+    for _, name := range []string { "0001_create_catalog.up.sql", 
+            "0001_create_catalog.down.sql" } {
+        data, err := os.ReadFile(filepath.Join("sql", "migrations", name))
+        check(t, err)
+        check(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+    }
+    db, migrator := newTestMigrator(t, dir)
+    if _, err := migrator.Up(ctx); err != nil {
+        t.Fatal(err)
+    }
+    check(t, os.WriteFile(filepath.Join(dir, "0001_create_catalog.up.sql"), 
+        []byte("CREATE TABLE Edited (Id INTEGER);"), 0644))
+    edited := openTestMigrator(t, db, dir)
+    if status, err := edited.Status(ctx); err != nil || !status[0].Modified {
+        t.Fatalf("Expected modified migration: %v %v", status, err)
+    }
+    if _, err := edited.Up(ctx); err == nil || 
+            !strings.Contains(err.Error(), "has been edited") {
+        t.Fatalf("Expected checksum error, got %v", err)
+    }
+    check(t, os.Remove(filepath.Join(dir, "0001_create_catalog.up.sql")))
+    check(t, os.Remove(filepath.Join(dir, "0001_create_catalog.down.sql")))
+    if err := openTestMigrator(t, db, dir).Verify(ctx); err == nil ||
+            !strings.Contains(err.Error(), "cannot be found") {
+        t.Fatalf("Expected missing migration error, got %v", err)
+    }
+}
+
+func TestMigrationBaseline(t *testing.T) {
+    ctx := context.Background()
+    db, migrator := newTestMigrator(t, "sql/migrations")
+    for _, migration := range migrator.migrations[:2] {
+        if _, err := db.ExecContext(ctx, migration.Up); err != nil {
+            t.Fatal(err)
+        }
+    }
+    for _, statement := range []string {
+        "INSERT INTO Categories (Id, Name) VALUES (1, 'Legacy')",
+        "INSERT INTO Products (Id, Name, Description, Category, Price) " +
+            "VALUES (1, 'Kayak', '', 1, 275)",
+        "INSERT INTO Orders (Id, Name, StreetAddr, City, State, Zip, Country, " + 
+            "Shipped) VALUES (1, 'Alice', '', '', '', '', '', false)",
+        "INSERT INTO OrderLines (Id, OrderId, ProductId, Quantity) VALUES (1, 1, 1, 2)",
+    } {
+        _, err := db.ExecContext(ctx, statement)
+        check(t, err)
+    }
+
+    if count, err := migrator.Up(ctx); err != nil || 
+            count != len(migrator.migrations) - 2 {
+        t.Fatalf("Expected legacy schema to be baselined: %v %v", count, err)
+    }
+    var name string
+    check(t, db.QueryRowContext(ctx, 
+        "SELECT Name FROM Categories WHERE Id = 1").Scan(&name))
+    if name != "Legacy" {
+        t.Fatalf("Expected existing data to be kept, got %v", name)
+    }
+    var price float64
+    var estimated, pricesEstimated bool
+    check(t, db.QueryRowContext(ctx, "SELECT OrderLines.Price, " + 
+        "Orders.CreatedEstimated, Orders.PricesEstimated FROM OrderLines, Orders " + 
+        "WHERE OrderLines.OrderId = Orders.Id").Scan(&price, &estimated, 
+        &pricesEstimated))
+    if price != 275 || !estimated || !pricesEstimated {
+        t.Fatalf("Expected legacy order to be backfilled and flagged: %v %v %v", 
+            price, estimated, pricesEstimated)
+    }
+    if count, err := migrator.Baseline(ctx); err != nil || count != 0 {
+        t.Fatalf("Expected baseline to run only once: %v %v", count, err)
+    }
+}
@@ -1,18 +1,32 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "sportsstore/models"
+)
 
-func (repo *SqlRepository) SetOrderShipped(o *models.Order) {
-    result, err := repo.Commands.UpdateOrder.ExecContext(repo.Context, 
-        o.Shipped, o.ID)
-    if err == nil {
-        rows, err :=result.RowsAffected()
+func (repo *SqlRepository) SetOrderShipped(ctx context.Context, 
+        o *models.Order) error {
+    return repo.withRetry(ctx, func() error {
+        tx, err := repo.DB.BeginTx(ctx, nil)
         if err != nil {
-            repo.Logger.Panicf("Cannot get updated ID: %v", err.Error())     
-        } else if rows != 1 {
-            repo.Logger.Panicf("Got unexpected rows affected: %v", rows)       
+            return err
         }
-    } else {
-        repo.Logger.Panicf("Cannot exec UpdateOrder command: %v", err.Error())               
-    }
+        result, err := tx.StmtContext(ctx, repo.Commands.UpdateOrder).ExecContext(ctx, 
+            o.Shipped, o.ID)
+        if err == nil {
+            err = checkAffected(result)
+        }
+        if err == nil && o.Shipped {
+            err = repo.enqueueOrderEmail(ctx, tx, models.OrderShippedEmail, 
+                int64(o.ID))
+        }
+        if err == nil {
+            err = tx.Commit()
+        }
+        if err != nil {
+            tx.Rollback()
+        }
+        return err
+    })
 }
@@ -1,28 +1,52 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "database/sql"
+    "sportsstore/models"
+)
 
-func (repo *SqlRepository) GetOrders() []models.Order {
+func (repo *SqlRepository) GetOrders(ctx context.Context) ([]models.Order, error) {
+    return repo.queryOrders(ctx, repo.Commands.GetOrders, 
+        repo.Commands.GetOrdersLines)
+}
+
+func (repo *SqlRepository) queryOrders(ctx context.Context, orderStmt, 
+        lineStmt *sql.Stmt, args ...interface{}) (orders []models.Order, err error) {
+    err = repo.withRetry(ctx, func() error {
+        orders, err = readOrders(ctx, orderStmt, lineStmt, args...)
+        return err
+    })
+    return
+}
+
+func readOrders(ctx context.Context, orderStmt, lineStmt *sql.Stmt, 
+        args ...interface{}) ([]models.Order, error) {
     orderMap := make(map[int]*models.Order, 10)
-    orderRows, err := repo.Commands.GetOrders.QueryContext(repo.Context)
+    orderIds := make([]int, 0, 10)
+    orderRows, err := orderStmt.QueryContext(ctx, args...)
     if err != nil {
-        repo.Logger.Panicf("Cannot exec GetOrders command: %v", err.Error())
+        return nil, err
     }
+    defer orderRows.Close()
     for orderRows.Next() {
         order := models.Order { Products: []models.ProductSelection {}}
-        err := orderRows.Scan(&order.ID, &order.Name, &order.StreetAddr, &order.City, 
-            &order.Zip, &order.Country, &order.Shipped)
+        err := orderRows.Scan(orderFields(&order)...)
         if (err != nil) {
-            repo.Logger.Panicf("Cannot scan order data: %v", err.Error())
-            return  []models.Order {}
+            return nil, err
         }   
         orderMap[order.ID] = &order
+        orderIds = append(orderIds, order.ID)
+    }
+    if err = orderRows.Err(); err != nil {
+        return nil, err
     }
 
-    lineRows, err := repo.Commands.GetOrdersLines.QueryContext(repo.Context)
+    lineRows, err := lineStmt.QueryContext(ctx, args...)
     if (err != nil) {
-        repo.Logger.Panicf("Cannot exec GetOrdersLines command: %v", err.Error())
+        return nil, err
     }
+    defer lineRows.Close()
     for lineRows.Next() {
         var order_id int
         ps := models.ProductSelection { 
@@ -31,15 +55,19 @@ func (repo *SqlRepository) GetOrders() []models.Order {
         err = lineRows.Scan(&order_id, &ps.Quantity, &ps.Product.ID,          
             &ps.Product.Name, &ps.Product.Description, &ps.Product.Price, 
             &ps.Product.Category.ID, &ps.Product.Category.CategoryName)
-        if err == nil {
-            orderMap[order_id].Products = append(orderMap[order_id].Products, ps)
-        } else {
-            repo.Logger.Panicf("Cannot scan order line data: %v", err.Error())
-        }        
+        if err != nil {
+            return nil, err
+        }
+        if order, ok := orderMap[order_id]; ok {
+            order.Products = append(order.Products, ps)
+        }
+    }
+    if err = lineRows.Err(); err != nil {
+        return nil, err
     }
     orders := make([]models.Order, 0, len(orderMap))
-    for _, o := range orderMap {
-        orders = append(orders, *o)
+    for _, id := range orderIds {
+        orders = append(orders, *orderMap[id])
     }
-    return orders
+    return orders, nil
 }
@@ -1,44 +1,48 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "sportsstore/models"
+    "time"
+)
 
-
-func (repo *SqlRepository) SaveOrder(order *models.Order) {
-    tx, err := repo.DB.Begin()
-    if err != nil {
-        repo.Logger.Panicf("Cannot create transaction: %v", err.Error())
-        return
-    }
-    result, err :=  tx.StmtContext(repo.Context, 
-        repo.Commands.SaveOrder).Exec(order.Name, order.StreetAddr, order.City, 
-            order.Zip, order.Country, order.Shipped)       
-    if err != nil {
-        repo.Logger.Panicf("Cannot exec SaveOrder command: %v", err.Error())
-        tx.Rollback()
-        return
-    } 
-    id, err := result.LastInsertId()
-    if err != nil {
-        repo.Logger.Panicf("Cannot get inserted ID: %v", err.Error())
-        tx.Rollback()
-        return
+func (repo *SqlRepository) SaveOrder(ctx context.Context, order *models.Order) error {
+    if order.Created.IsZero() {
+        order.Created = time.Now().UTC()
     }
-    statement := tx.StmtContext(repo.Context, repo.Commands.SaveOrderLine)
-    for _, sel := range order.Products {
-        _, err := statement.Exec(id, sel.Product.ID, sel.Quantity)
+    return repo.withRetry(ctx, func() error {
+        tx, err := repo.DB.BeginTx(ctx, nil)
+        if err != nil {
+            return err
+        }
+        id, err := repo.execInsert(ctx, tx.StmtContext(ctx, repo.Commands.SaveOrder), 
+            order.Name, order.Email, order.StreetAddr, order.City, order.State, order.Zip, 
+            order.Country, order.Shipped, order.AccountID, order.Created.UTC(),
+            order.ShippingMethodID, order.ShippingMethod, order.PromotionCode, 
+            order.Subtotal, order.Shipping, order.Discount, order.Tax, order.Total, 
+            order.TaxName, order.TaxRate, order.TaxIncluded)
         if err != nil {
-            repo.Logger.Panicf("Cannot exec SaveOrderLine command: %v", err.Error())
             tx.Rollback()
-            return
+            return err
         }
-    }
-    err = tx.Commit()
-    if err != nil {
-        repo.Logger.Panicf("Transaction cannot be committed: %v", err.Error())
-        err = tx.Rollback()
+        statement := tx.StmtContext(ctx, repo.Commands.SaveOrderLine)
+        for _, sel := range order.Products {
+            if _, err := statement.ExecContext(ctx, id, sel.Product.ID, 
+                    sel.Quantity, sel.Product.Price); err != nil {
+                tx.Rollback()
+                return err
+            }
+        }
+        err = repo.enqueueOrderEmail(ctx, tx, models.OrderConfirmationEmail, id)
         if err != nil {
-            repo.Logger.Panicf("Transaction cannot be rolled back: %v", err.Error())
+            tx.Rollback()
+            return err
         }
-    }
-    order.ID = int(id)
+        if err = tx.Commit(); err != nil {
+            tx.Rollback()
+            return err
+        }
+        order.ID = int(id)
+        return nil
+    })
 }
@@ -0,0 +1,70 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "sportsstore/models"
+    "time"
+)
+
+func (repo *SqlRepository) enqueueOrderEmail(ctx context.Context, tx *sql.Tx, 
+        kind string, orderId int64) error {
+    now := time.Now().UTC()
+    _, err := tx.StmtContext(ctx, repo.Commands.EnqueueOrderEmail).ExecContext(ctx, 
+        kind, now, now, orderId, kind)
+    return err
+}
+
+func (repo *SqlRepository) GetPendingEmails(ctx context.Context, due time.Time, 
+        limit int) (emails []models.OutboxEmail, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetPendingEmails.QueryContext(ctx, due.UTC(), limit)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        emails = []models.OutboxEmail {}
+        for rows.Next() {
+            e := models.OutboxEmail{}
+            err = rows.Scan(&e.ID, &e.Kind, &e.OrderID, &e.Recipient, &e.Created, 
+                &e.Status, &e.Attempts, &e.NextAttempt, &e.LastError)
+            if err != nil {
+                return err
+            }
+            emails = append(emails, e)
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) ClaimEmail(ctx context.Context, e *models.OutboxEmail,
+        now time.Time, lease time.Duration) (claimed bool, err error) {
+    leaseExpiry := now.Add(lease).UTC()
+    err = repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.ClaimEmail.ExecContext(ctx, leaseExpiry, 
+            e.ID, now.UTC())
+        if err != nil {
+            return err
+        }
+        affected, err := result.RowsAffected()
+        claimed = err == nil && affected == 1
+        return err
+    })
+    if claimed {
+        e.Status, e.NextAttempt = models.EmailSending, leaseExpiry
+    }
+    return
+}
+
+func (repo *SqlRepository) UpdateEmail(ctx context.Context, 
+        e *models.OutboxEmail) error {
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.UpdateEmail.ExecContext(ctx, e.Status, 
+            e.Attempts, e.NextAttempt.UTC(), e.LastError, e.ID)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
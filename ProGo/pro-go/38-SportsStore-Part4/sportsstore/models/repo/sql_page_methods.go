@@ -1,56 +1,42 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "database/sql"
+    "sportsstore/models"
+)
 
-func (repo *SqlRepository) GetProductPage(page, 
-        pageSize int) (products []models.Product, totalAvailable int) {
-    rows, err := repo.Commands.GetPage.QueryContext(repo.Context, 
-        pageSize, (pageSize * page) - pageSize)
-    if err == nil {
-        if products, err = scanProducts(rows); err != nil {
-            repo.Logger.Panicf("Cannot scan data: %v", err.Error())    
-            return
-        }
-    } else {
-        repo.Logger.Panicf("Cannot exec GetProductPage command: %v", err)
-        return 
-    }
-    row := repo.Commands.GetPageCount.QueryRowContext(repo.Context)
-    if row.Err() == nil {
-        if err := row.Scan(&totalAvailable); err != nil {
-            repo.Logger.Panicf("Cannot scan data: %v", err.Error())    
-        }
-    } else {
-        repo.Logger.Panicf("Cannot exec GetPageCount command: %v", row.Err().Error())
-    }
-    return
+func (repo *SqlRepository) GetProductPage(ctx context.Context, page, 
+        pageSize int) (products []models.Product, totalAvailable int, err error) {
+    return repo.queryProductPage(ctx, repo.Commands.GetPage, 
+        repo.Commands.GetPageCount, []interface{} {}, page, pageSize)
 }
 
-func (repo *SqlRepository) GetProductPageCategory(categoryId int, page, 
-        pageSize int) (products []models.Product, totalAvailable int) {
+func (repo *SqlRepository) GetProductPageCategory(ctx context.Context, 
+        categoryId int, page, pageSize int) (products []models.Product, 
+            totalAvailable int, err error) {
     if (categoryId == 0) {
-        return repo.GetProductPage(page, pageSize)
+        return repo.GetProductPage(ctx, page, pageSize)
     }
-    rows, err := repo.Commands.GetCategoryPage.QueryContext(repo.Context, categoryId, 
-        pageSize, (pageSize * page) - pageSize)
-    if err == nil {
+    return repo.queryProductPage(ctx, repo.Commands.GetCategoryPage, 
+        repo.Commands.GetCategoryPageCount, []interface{} { categoryId }, 
+        page, pageSize)
+}
+
+func (repo *SqlRepository) queryProductPage(ctx context.Context, pageStmt, 
+        countStmt *sql.Stmt, args []interface{}, page, 
+        pageSize int) (products []models.Product, totalAvailable int, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := pageStmt.QueryContext(ctx, 
+            append(args, pageSize, (pageSize * page) - pageSize)...)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
         if products, err = scanProducts(rows); err != nil {
-            repo.Logger.Panicf("Cannot scan data: %v", err.Error())    
-            return
+            return err
         }
-    } else {
-        repo.Logger.Panicf("Cannot exec GetProductPage command: %v", err)
-        return         
-    }
-    row := repo.Commands.GetCategoryPageCount.QueryRowContext(repo.Context, 
-        categoryId)
-    if row.Err() == nil {
-        if err := row.Scan(&totalAvailable); err != nil {
-            repo.Logger.Panicf("Cannot scan data: %v", err.Error())    
-        }        
-    } else {
-        repo.Logger.Panicf("Cannot exec GetCategoryPageCount command: %v", 
-            row.Err().Error())
-    }
+        return countStmt.QueryRowContext(ctx, args...).Scan(&totalAvailable)
+    })
     return
 }
@@ -0,0 +1,129 @@
+package repo
+
+import (
+    "context"
+    "sportsstore/models"
+)
+
+func (repo *SqlRepository) GetShippingRates(ctx context.Context) (
+        rates []models.ShippingRate, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetShippingRates.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        rates = []models.ShippingRate {}
+        for rows.Next() {
+            r := models.ShippingRate{}
+            if err := rows.Scan(&r.ID, &r.MethodID, &r.Country, &r.State, 
+                    &r.MaxWeight, &r.Price); err != nil {
+                return err
+            }
+            rates = append(rates, r)
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) GetShippingRate(ctx context.Context, 
+        id int) (r models.ShippingRate, err error) {
+    err = repo.withRetry(ctx, func() error {
+        return repo.Commands.GetShippingRate.QueryRowContext(ctx, id).Scan(
+            &r.ID, &r.MethodID, &r.Country, &r.State, &r.MaxWeight, &r.Price)
+    })
+    return
+}
+
+func (repo *SqlRepository) SaveShippingRate(ctx context.Context, 
+        r *models.ShippingRate) error {
+    return repo.withRetry(ctx, func() error {
+        if r.ID == 0 {
+            id, err := repo.execInsert(ctx, repo.Commands.SaveShippingRate, 
+                r.MethodID, r.Country, r.State, r.MaxWeight, r.Price)
+            if err == nil {
+                r.ID = int(id)
+            }
+            return err
+        }
+        result, err := repo.Commands.UpdateShippingRate.ExecContext(ctx, 
+            r.MethodID, r.Country, r.State, r.MaxWeight, r.Price, r.ID)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) DeleteShippingRate(ctx context.Context, id int) error {
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.DeleteShippingRate.ExecContext(ctx, id)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) GetTaxRates(ctx context.Context) (
+        rates []models.TaxRate, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetTaxRates.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        rates = []models.TaxRate {}
+        for rows.Next() {
+            r := models.TaxRate{}
+            if err := rows.Scan(&r.ID, &r.Name, &r.Country, &r.State, &r.Rate, 
+                    &r.Inclusive, &r.TaxShipping); err != nil {
+                return err
+            }
+            rates = append(rates, r)
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) GetTaxRate(ctx context.Context, 
+        id int) (r models.TaxRate, err error) {
+    err = repo.withRetry(ctx, func() error {
+        return repo.Commands.GetTaxRate.QueryRowContext(ctx, id).Scan(
+            &r.ID, &r.Name, &r.Country, &r.State, &r.Rate, &r.Inclusive, 
+            &r.TaxShipping)
+    })
+    return
+}
+
+func (repo *SqlRepository) SaveTaxRate(ctx context.Context, 
+        r *models.TaxRate) error {
+    return repo.withRetry(ctx, func() error {
+        if r.ID == 0 {
+            id, err := repo.execInsert(ctx, repo.Commands.SaveTaxRate, 
+                r.Name, r.Country, r.State, r.Rate, r.Inclusive, r.TaxShipping)
+            if err == nil {
+                r.ID = int(id)
+            }
+            return err
+        }
+        result, err := repo.Commands.UpdateTaxRate.ExecContext(ctx, 
+            r.Name, r.Country, r.State, r.Rate, r.Inclusive, r.TaxShipping, r.ID)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) DeleteTaxRate(ctx context.Context, id int) error {
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.DeleteTaxRate.ExecContext(ctx, id)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
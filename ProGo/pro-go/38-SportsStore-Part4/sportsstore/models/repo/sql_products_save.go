@@ -1,35 +1,41 @@
 package repo
 
-import "sportsstore/models"
+import (
+    "context"
+    "sportsstore/models"
+)
 
-func (repo *SqlRepository) SaveProduct(p *models.Product) {
-
-    if (p.ID == 0) {
-        result, err := repo.Commands.SaveProduct.ExecContext(repo.Context, p.Name, 
-            p.Description, p.Category.ID, p.Price)
-        if err == nil {
-            id, err := result.LastInsertId()
-            if err == nil {
-                p.ID = int(id)
-                return
-            } else {
-                repo.Logger.Panicf("Cannot get inserted ID: %v", err.Error())       
+func (repo *SqlRepository) SaveProduct(ctx context.Context, p *models.Product) error {
+    return repo.withRetry(ctx, func() error {
+        if (p.ID == 0) {
+            id, err := repo.execInsert(ctx, repo.Commands.SaveProduct, p.Name, 
+                p.Description, p.Category.ID, p.Price)
+            if err != nil {
+                return err
             }
+            p.ID = int(id)
         } else {
-            repo.Logger.Panicf("Cannot exec SaveProduct command: %v", err.Error())            
-        }
-    } else {
-        result, err := repo.Commands.UpdateProduct.ExecContext(repo.Context, p.Name, 
-            p.Description, p.Category.ID, p.Price, p.ID)
-        if err == nil {
-            affected, err := result.RowsAffected()
-            if err == nil && affected != 1 {
-                repo.Logger.Panicf("Got unexpected rows affected: %v", affected)       
-            } else if err != nil {
-                repo.Logger.Panicf("Cannot get rows affected: %v", err)       
+            result, err := repo.Commands.UpdateProduct.ExecContext(ctx, p.Name, 
+                p.Description, p.Category.ID, p.Price, p.ID)
+            if err != nil {
+                return err
             }
-        } else {
-            repo.Logger.Panicf("Cannot exec Update command: %v", err.Error())                   
+            if err = checkAffected(result); err != nil {
+                return err
+            }
+        }
+        _, err := repo.Commands.UpdateProductWeight.ExecContext(ctx, p.Weight, p.ID)
+        return err
+    })
+}
+
+func (repo *SqlRepository) SetProductImage(ctx context.Context, id int, 
+        image string) error {
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.UpdateProductImage.ExecContext(ctx, image, id)
+        if err != nil {
+            return err
         }
-    }
+        return checkAffected(result)
+    })
 }
@@ -13,11 +13,11 @@ type SqlRepository struct {
     Commands SqlCommands
     *sql.DB
     context.Context
+    Dialect Dialect
+    Migrator *Migrator
 }
 
 type SqlCommands struct {
-    Init,
-    Seed,
     GetProduct,
     GetProducts,
     GetCategories,
@@ -34,7 +34,68 @@ type SqlCommands struct {
     UpdateOrder,    
     SaveProduct,
     UpdateProduct,
+    UpdateProductImage,
+    UpdateProductWeight,
     SaveCategory,
-    UpdateCategory *sql.Stmt
+    UpdateCategory,
+    GetAccount,
+    GetAccountByName,
+    SaveAccount,
+    UpdateAccount,
+    GetShippingDetails,
+    SaveShippingDetails,
+    GetAccountOrders,
+    GetAccountOrdersLines,
+    GetCartLines,
+    ClearCartLines,
+    SaveCartLine,
+    SaveAuditEntry,
+    GetAuditEntries,
+    EnqueueOrderEmail,
+    GetPendingEmails,
+    UpdateEmail,
+    ClaimEmail,
+    SaveJob,
+    GetNextJob,
+    ClaimJob,
+    UpdateJob,
+    GetJob,
+    GetJobs,
+    GetJobCounts,
+    PruneJobs,
+    DeleteAbandonedCartLines,
+    GetSalesLines,
+    GetShippingMethods,
+    GetShippingMethod,
+    SaveShippingMethod,
+    UpdateShippingMethod,
+    DeleteShippingMethod,
+    GetPromotions,
+    GetPromotion,
+    SavePromotion,
+    UpdatePromotion,
+    DeletePromotion,
+    GetShippingRates,
+    GetShippingRate,
+    SaveShippingRate,
+    UpdateShippingRate,
+    DeleteShippingRate,
+    GetTaxRates,
+    GetTaxRate,
+    SaveTaxRate,
+    UpdateTaxRate,
+    DeleteTaxRate,
+    GetProductReviews,
+    GetReviews,
+    GetReview,
+    SaveReview,
+    UpdateReviewStatus,
+    UpdateProductRating,
+    GetPurchaseCount,
+    GetProductReviewCount,
+    GetRecentReviewCount,
+    GetFeatureFlags,
+    SaveFeatureFlag,
+    UpdateFeatureFlag *sql.Stmt
 
 }
@@ -0,0 +1,33 @@
+package repo
+
+import (
+    "context"
+    "sportsstore/models"
+    "time"
+)
+
+func (repo *SqlRepository) GetSalesLines(ctx context.Context, 
+        from, to time.Time) (lines []models.SalesLine, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetSalesLines.QueryContext(ctx, from.UTC(), 
+            to.UTC())
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        lines = []models.SalesLine {}
+        for rows.Next() {
+            line := models.SalesLine{}
+            err = rows.Scan(&line.OrderID, &line.Created, &line.CreatedEstimated, 
+                &line.PricesEstimated, &line.State, &line.Country, &line.Quantity, 
+                &line.ProductID, &line.ProductName, &line.Price, &line.CategoryID, 
+                &line.CategoryName, &line.OrderTotal, &line.OrderTax)
+            if err != nil {
+                return err
+            }
+            lines = append(lines, line)
+        }
+        return rows.Err()
+    })
+    return
+}
@@ -0,0 +1,161 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "sportsstore/models"
+    "time"
+)
+
+func scanReviews(rows *sql.Rows) (reviews []models.Review, err error) {
+    reviews = []models.Review {}
+    for rows.Next() {
+        r := models.Review{}
+        if err = rows.Scan(&r.ID, &r.ProductID, &r.AccountID, &r.Author, &r.Rating, 
+                &r.Body, &r.Status, &r.Created); err != nil {
+            return
+        }
+        reviews = append(reviews, r)
+    }
+    err = rows.Err()
+    return
+}
+
+func (repo *SqlRepository) GetProductReviews(ctx context.Context, 
+        productId int) (reviews []models.Review, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetProductReviews.QueryContext(ctx, productId, 
+            models.ReviewApproved)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        reviews, err = scanReviews(rows)
+        return err
+    })
+    return
+}
+
+func (repo *SqlRepository) GetReviews(ctx context.Context, 
+        status string) (reviews []models.Review, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetReviews.QueryContext(ctx, status)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        reviews, err = scanReviews(rows)
+        return err
+    })
+    return
+}
+
+func (repo *SqlRepository) GetReview(ctx context.Context, 
+        id int) (r models.Review, err error) {
+    err = repo.withRetry(ctx, func() error {
+        return repo.Commands.GetReview.QueryRowContext(ctx, id).Scan(&r.ID, 
+            &r.ProductID, &r.AccountID, &r.Author, &r.Rating, &r.Body, &r.Status, 
+            &r.Created)
+    })
+    return
+}
+
+func (repo *SqlRepository) SaveReview(ctx context.Context, r *models.Review) error {
+    if r.Created.IsZero() {
+        r.Created = time.Now().UTC()
+    }
+    if r.Status == "" {
+        r.Status = models.ReviewPending
+    }
+    return repo.withRetry(ctx, func() error {
+        tx, err := repo.DB.BeginTx(ctx, nil)
+        if err != nil {
+            return err
+        }
+        id, err := repo.execInsert(ctx, tx.StmtContext(ctx, repo.Commands.SaveReview), 
+            r.ProductID, r.AccountID, r.Author, r.Rating, r.Body, r.Status, 
+            r.Created.UTC())
+        if err == nil {
+            err = repo.updateProductRating(ctx, tx, r.ProductID)
+        }
+        if err == nil {
+            err = tx.Commit()
+        }
+        if err != nil {
+            tx.Rollback()
+            return err
+        }
+        r.ID = int(id)
+        return nil
+    })
+}
+
+func (repo *SqlRepository) SetReviewStatus(ctx context.Context, id int, 
+        status string) error {
+    return repo.withRetry(ctx, func() error {
+        tx, err := repo.DB.BeginTx(ctx, nil)
+        if err != nil {
+            return err
+        }
+        var productId int
+        err = tx.StmtContext(ctx, repo.Commands.GetReview).QueryRowContext(ctx, 
+            id).Scan(new(int), &productId, new(int), new(string), new(int), 
+                new(string), new(string), new(time.Time))
+        if err == nil {
+            var result sql.Result
+            result, err = tx.StmtContext(ctx, 
+                repo.Commands.UpdateReviewStatus).ExecContext(ctx, status, id)
+            if err == nil {
+                err = checkAffected(result)
+            }
+        }
+        if err == nil {
+            err = repo.updateProductRating(ctx, tx, productId)
+        }
+        if err == nil {
+            err = tx.Commit()
+        }
+        if err != nil {
+            tx.Rollback()
+        }
+        return err
+    })
+}
+
+func (repo *SqlRepository) updateProductRating(ctx context.Context, tx *sql.Tx, 
+        productId int) error {
+    _, err := tx.StmtContext(ctx, repo.Commands.UpdateProductRating).ExecContext(ctx,
+        productId, models.ReviewApproved, productId, models.ReviewApproved, productId)
+    return err
+}
+
+func (repo *SqlRepository) HasPurchased(ctx context.Context, accountId, 
+        productId int) (bool, error) {
+    return repo.countExists(ctx, repo.Commands.GetPurchaseCount, accountId, productId)
+}
+
+func (repo *SqlRepository) HasReviewed(ctx context.Context, accountId, 
+        productId int) (bool, error) {
+    return repo.countExists(ctx, repo.Commands.GetProductReviewCount, accountId, 
+        productId)
+}
+
+func (repo *SqlRepository) CountRecentReviews(ctx context.Context, accountId int, 
+        since time.Time) (count int, err error) {
+    err = repo.withRetry(ctx, func() error {
+        return repo.Commands.GetRecentReviewCount.QueryRowContext(ctx, accountId,
+            since.UTC()).Scan(&count)
+    })
+    return
+}
+
+func (repo *SqlRepository) countExists(ctx context.Context, stmt *sql.Stmt, 
+        args ...interface{}) (exists bool, err error) {
+    err = repo.withRetry(ctx, func() error {
+        var count int
+        err := stmt.QueryRowContext(ctx, args...).Scan(&count)
+        exists = count > 0
+        return err
+    })
+    return
+}
@@ -9,8 +9,9 @@ func scanProducts(rows *sql.Rows) (products []models.Product, err error) {
     products = make([]models.Product, 0, 10)
     for rows.Next() {
         p := models.Product{ Category: &models.Category{}}
-        err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, 
-            &p.Category.ID, &p.Category.CategoryName)
+        err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Image, 
+            &p.Weight, &p.Rating, &p.ReviewCount, &p.Category.ID, 
+            &p.Category.CategoryName)
         if (err == nil) {
             products = append(products, p)
         } else {
@@ -22,7 +23,18 @@ func scanProducts(rows *sql.Rows) (products []models.Product, err error) {
 
 func scanProduct(row *sql.Row) (p models.Product, err error) {
     p = models.Product{ Category: &models.Category{}}
-    err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Category.ID, 
+    err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Image, 
+        &p.Weight, &p.Rating, &p.ReviewCount, &p.Category.ID, 
         &p.Category.CategoryName)
     return p, err
 }
+
+func orderFields(order *models.Order) []interface{} {
+    return []interface{} { &order.ID, &order.Name, &order.Email, &order.StreetAddr, 
+        &order.City, &order.State, &order.Zip, &order.Country, &order.Shipped, 
+        &order.AccountID, &order.Created, &order.ShippingMethodID, 
+        &order.ShippingMethod, &order.PromotionCode, &order.Subtotal, 
+        &order.Shipping, &order.Discount, &order.Tax, &order.Total, &order.TaxName, 
+        &order.TaxRate, &order.TaxIncluded,
+    }
+}
@@ -6,34 +6,144 @@ import (
     "database/sql"
     "platform/services"
     "platform/config"
+    "platform/features"
+    "platform/health"
+    "platform/jobs"
     "platform/logging"
     "sportsstore/models"
 )
 
+type sqlDatabase struct {
+    db *sql.DB
+    commands *SqlCommands
+    dialect Dialect
+    migrator *Migrator
+    needInit bool
+    resetOnce sync.Once
+}
+
+func databaseKey(config config.Configuration) string {
+    return config.GetStringDefault("sql:dialect", "sqlite") + ":" + 
+        config.GetStringDefault("sql:connection_str", "")
+}
+
 func RegisterSqlRepositoryService() {
-    var db *sql.DB
-    var commands *SqlCommands
-    var needInit bool
-    loadOnce := sync.Once {}
-    resetOnce := sync.Once {}
+    databases := map[string]*sqlDatabase {}
+    lock := sync.Mutex {}
+    getDatabase := func(config config.Configuration, 
+            logger logging.Logger) *sqlDatabase {
+        lock.Lock()
+        defer lock.Unlock()
+        database, found := databases[databaseKey(config)]
+        if !found {
+            database = &sqlDatabase{}
+            database.db, database.dialect, database.commands, database.migrator,
+                database.needInit = openDB(config, logger)
+            databases[databaseKey(config)] = database
+        }
+        return database
+    }
     services.AddScoped(func (ctx context.Context, config config.Configuration, 
-            logger logging.Logger) models.Repository {
-        loadOnce.Do(func () {
-            db, commands, needInit = openDB(config, logger)
-        })
+            logger logging.Logger) *SqlRepository {
+        database := getDatabase(config, logger)
         repo := &SqlRepository{
             Configuration: config,
             Logger: logger,
-            Commands: *commands,
-            DB: db,
+            Commands: *database.commands,
+            DB: database.db,
+            Dialect: database.dialect,
+            Migrator: database.migrator,
             Context: ctx,
         }
-        resetOnce.Do(func() {
-            if needInit || config.GetBoolDefault("sql:always_reset", true) {
-                repo.Init()
-                repo.Seed()   
+        database.resetOnce.Do(func() {
+            var err error
+            initCtx := context.Background()
+            if config.GetBoolDefault("sql:always_reset", true) {
+                if err = repo.Init(initCtx); err == nil {
+                    err = repo.Seed(initCtx)
+                }
+            } else if database.needInit {
+                err = repo.Seed(initCtx)
+            }
+            if err != nil {
+                logger.Panicf("Cannot initialize database: %v", err.Error())
             }
         })
         return repo
     })
+    services.AddScoped(func (repo *SqlRepository) models.RepositoryV2 {
+        return repo
+    })
+    services.AddScoped(func (ctx context.Context, 
+            repo models.RepositoryV2) models.Repository {
+        return models.NewRepositoryAdapter(ctx, repo)
+    })
+    services.AddScoped(func (repo *SqlRepository) models.AccountRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.BulkRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.AuditRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.OutboxRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.ReportRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.AbandonedCartRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.ShippingRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.PromotionRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.PricingRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) models.ReviewRepository {
+        return repo
+    })
+    services.AddScoped(func (repo *SqlRepository) *Migrator {
+        return repo.Migrator
+    })
+    err := health.AddCheck("sql", health.Readiness, 
+        func (ctx context.Context, repo *SqlRepository) error {
+            return repo.DB.PingContext(ctx)
+        })
+    if (err != nil) {
+        panic(err)
+    }
+}
+
+func RegisterSqlJobQueueService() {
+    services.Decorate(func (queue jobs.JobQueue, 
+            config config.Configuration) jobs.JobQueue {
+        if config.GetStringDefault("jobs:queue", "memory") != "sql" {
+            return queue
+        }
+        var repo *SqlRepository
+        if err := services.GetService(&repo); err != nil {
+            panic(err)
+        }
+        return repo
+    })
+}
+
+func RegisterSqlFeatureStoreService() {
+    services.Decorate(func (store features.FlagStore, ctx context.Context,
+            config config.Configuration) features.FlagStore {
+        if config.GetStringDefault("features:store", "config") != "sql" {
+            return store
+        }
+        var repo *SqlRepository
+        if err := services.GetServiceForContext(ctx, &repo); err != nil {
+            panic(err)
+        }
+        return &features.OverlayFlagStore{ Defaults: store, Overrides: repo }
+    })
 }
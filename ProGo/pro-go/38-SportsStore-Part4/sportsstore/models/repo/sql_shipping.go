@@ -0,0 +1,146 @@
+package repo
+
+import (
+    "context"
+    "database/sql"
+    "sportsstore/models"
+    "time"
+)
+
+func (repo *SqlRepository) GetShippingMethods(ctx context.Context) (
+        methods []models.ShippingMethod, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetShippingMethods.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        methods = []models.ShippingMethod {}
+        for rows.Next() {
+            m := models.ShippingMethod{}
+            if err := rows.Scan(&m.ID, &m.Name, &m.Description, &m.Price, 
+                    &m.DeliveryDays, &m.Active); err != nil {
+                return err
+            }
+            methods = append(methods, m)
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) GetShippingMethod(ctx context.Context, 
+        id int) (m models.ShippingMethod, err error) {
+    err = repo.withRetry(ctx, func() error {
+        return repo.Commands.GetShippingMethod.QueryRowContext(ctx, id).Scan(
+            &m.ID, &m.Name, &m.Description, &m.Price, &m.DeliveryDays, &m.Active)
+    })
+    return
+}
+
+func (repo *SqlRepository) SaveShippingMethod(ctx context.Context, 
+        m *models.ShippingMethod) error {
+    return repo.withRetry(ctx, func() error {
+        if m.ID == 0 {
+            id, err := repo.execInsert(ctx, repo.Commands.SaveShippingMethod, 
+                m.Name, m.Description, m.Price, m.DeliveryDays, m.Active)
+            if err == nil {
+                m.ID = int(id)
+            }
+            return err
+        }
+        result, err := repo.Commands.UpdateShippingMethod.ExecContext(ctx, 
+            m.Name, m.Description, m.Price, m.DeliveryDays, m.Active, m.ID)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) DeleteShippingMethod(ctx context.Context, id int) error {
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.DeleteShippingMethod.ExecContext(ctx, id)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) GetPromotions(ctx context.Context) (
+        promotions []models.Promotion, err error) {
+    err = repo.withRetry(ctx, func() error {
+        rows, err := repo.Commands.GetPromotions.QueryContext(ctx)
+        if err != nil {
+            return err
+        }
+        defer rows.Close()
+        promotions = []models.Promotion {}
+        for rows.Next() {
+            var p models.Promotion
+            if p, err = scanPromotion(rows); err != nil {
+                return err
+            }
+            promotions = append(promotions, p)
+        }
+        return rows.Err()
+    })
+    return
+}
+
+func (repo *SqlRepository) GetPromotion(ctx context.Context, 
+        id int) (p models.Promotion, err error) {
+    err = repo.withRetry(ctx, func() (err error) {
+        p, err = scanPromotion(repo.Commands.GetPromotion.QueryRowContext(ctx, id))
+        return
+    })
+    return
+}
+
+func (repo *SqlRepository) SavePromotion(ctx context.Context, 
+        p *models.Promotion) error {
+    starts, ends := nullTime(p.Starts), nullTime(p.Ends)
+    return repo.withRetry(ctx, func() error {
+        if p.ID == 0 {
+            id, err := repo.execInsert(ctx, repo.Commands.SavePromotion, 
+                p.Code, p.Description, p.DiscountPercent, starts, ends, p.Active)
+            if err == nil {
+                p.ID = int(id)
+            }
+            return err
+        }
+        result, err := repo.Commands.UpdatePromotion.ExecContext(ctx, 
+            p.Code, p.Description, p.DiscountPercent, starts, ends, p.Active, p.ID)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+func (repo *SqlRepository) DeletePromotion(ctx context.Context, id int) error {
+    return repo.withRetry(ctx, func() error {
+        result, err := repo.Commands.DeletePromotion.ExecContext(ctx, id)
+        if err != nil {
+            return err
+        }
+        return checkAffected(result)
+    })
+}
+
+type rowScanner interface {
+    Scan(dest ...interface{}) error
+}
+
+func scanPromotion(row rowScanner) (p models.Promotion, err error) {
+    var starts, ends sql.NullTime
+    err = row.Scan(&p.ID, &p.Code, &p.Description, &p.DiscountPercent, 
+        &starts, &ends, &p.Active)
+    p.Starts, p.Ends = starts.Time, ends.Time
+    return
+}
+
+func nullTime(t time.Time) sql.NullTime {
+    return sql.NullTime{ Time: t.UTC(), Valid: !t.IsZero() }
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+    "context"
+    "time"
+)
+
+type SalesLine struct {
+    OrderID int
+    Created time.Time
+    CreatedEstimated bool
+    PricesEstimated bool
+    State, Country string
+    Quantity int
+    ProductID int
+    ProductName string
+    Price float64
+    CategoryID int
+    CategoryName string
+    OrderTotal float64
+    OrderTax float64
+}
+
+func (line SalesLine) Revenue() float64 {
+    return line.Price * float64(line.Quantity)
+}
+
+// NetRevenue is the stored order total with discounts applied and tax removed
+func (line SalesLine) NetRevenue() float64 {
+    return line.OrderTotal - line.OrderTax
+}
+
+type ReportRepository interface {
+    GetSalesLines(ctx context.Context, from, to time.Time) ([]SalesLine, error)
+}
@@ -5,6 +5,7 @@ type Repository interface {
     GetProduct(id int) Product
     GetProducts() []Product
     SaveProduct(*Product)
+    SetProductImage(id int, image string)
 
     GetProductPage(page, pageSize int) (products []Product, totalAvailable int)
 
@@ -0,0 +1,90 @@
+package models
+
+import "context"
+
+func NewRepositoryAdapter(ctx context.Context, repo RepositoryV2) Repository {
+    return &RepositoryAdapter{ Context: ctx, RepositoryV2: repo }
+}
+
+type RepositoryAdapter struct {
+    Context context.Context
+    RepositoryV2 RepositoryV2
+}
+
+func (a *RepositoryAdapter) check(err error) {
+    if err != nil {
+        panic(err)
+    }
+}
+
+func (a *RepositoryAdapter) GetProduct(id int) Product {
+    p, err := a.RepositoryV2.GetProduct(a.Context, id)
+    a.check(err)
+    return p
+}
+
+func (a *RepositoryAdapter) GetProducts() []Product {
+    products, err := a.RepositoryV2.GetProducts(a.Context)
+    a.check(err)
+    return products
+}
+
+func (a *RepositoryAdapter) SaveProduct(p *Product) {
+    a.check(a.RepositoryV2.SaveProduct(a.Context, p))
+}
+
+func (a *RepositoryAdapter) SetProductImage(id int, image string) {
+    a.check(a.RepositoryV2.SetProductImage(a.Context, id, image))
+}
+
+func (a *RepositoryAdapter) GetProductPage(page, pageSize int) ([]Product, int) {
+    products, total, err := a.RepositoryV2.GetProductPage(a.Context, page, pageSize)
+    a.check(err)
+    return products, total
+}
+
+func (a *RepositoryAdapter) GetProductPageCategory(categoryId int, page, 
+        pageSize int) ([]Product, int) {
+    products, total, err := a.RepositoryV2.GetProductPageCategory(a.Context, 
+        categoryId, page, pageSize)
+    a.check(err)
+    return products, total
+}
+
+func (a *RepositoryAdapter) GetCategories() []Category {
+    categories, err := a.RepositoryV2.GetCategories(a.Context)
+    a.check(err)
+    return categories
+}
+
+func (a *RepositoryAdapter) SaveCategory(c *Category) {
+    a.check(a.RepositoryV2.SaveCategory(a.Context, c))
+}
+
+func (a *RepositoryAdapter) GetOrder(id int) Order {
+    order, err := a.RepositoryV2.GetOrder(a.Context, id)
+    a.check(err)
+    return order
+}
+
+func (a *RepositoryAdapter) GetOrders() []Order {
+    orders, err := a.RepositoryV2.GetOrders(a.Context)
+    a.check(err)
+    return orders
+}
+
+func (a *RepositoryAdapter) SaveOrder(o *Order) {
+    a.check(a.RepositoryV2.SaveOrder(a.Context, o))
+}
+
+func (a *RepositoryAdapter) SetOrderShipped(o *Order) {
+    a.check(a.RepositoryV2.SetOrderShipped(a.Context, o))
+}
+
+func (a *RepositoryAdapter) Seed() {
+    a.check(a.RepositoryV2.Seed(a.Context))
+}
+
+func (a *RepositoryAdapter) Init() {
+    a.check(a.RepositoryV2.Init(a.Context))
+}
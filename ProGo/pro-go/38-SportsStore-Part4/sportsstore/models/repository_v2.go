@@ -0,0 +1,40 @@
+package models
+
+import (
+    "context"
+    "errors"
+)
+
+var ErrNotFound = errors.New("The requested item does not exist")
+var ErrConflict = errors.New("The change conflicts with existing data")
+
+type RepositoryV2 interface {
+
+    GetProduct(ctx context.Context, id int) (Product, error)
+    GetProducts(ctx context.Context) ([]Product, error)
+    SaveProduct(ctx context.Context, p *Product) error
+    SetProductImage(ctx context.Context, id int, image string) error
+
+    GetProductPage(ctx context.Context, page, pageSize int) (products []Product, 
+        totalAvailable int, err error)
+
+    GetProductPageCategory(ctx context.Context, categoryId int, page, 
+        pageSize int) (products []Product, totalAvailable int, err error)
+
+    GetProductPageSorted(ctx context.Context, categoryId int, sortBy string, 
+        page, pageSize int) (products []Product, totalAvailable int, err error)
+
+    GetProductsKeyset(ctx context.Context, 
+        query ProductQuery) (ProductKeysetPage, error)
+
+    GetCategories(ctx context.Context) ([]Category, error)
+    SaveCategory(ctx context.Context, c *Category) error
+
+    GetOrder(ctx context.Context, id int) (Order, error)
+    GetOrders(ctx context.Context) ([]Order, error)
+    SaveOrder(ctx context.Context, o *Order) error
+    SetOrderShipped(ctx context.Context, o *Order) error
+
+    Seed(ctx context.Context) error
+    Init(ctx context.Context) error
+}
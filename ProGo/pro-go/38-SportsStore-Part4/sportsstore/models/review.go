@@ -0,0 +1,37 @@
+package models
+
+import (
+    "context"
+    "time"
+)
+
+const (
+    ReviewPending = "pending"
+    ReviewApproved = "approved"
+    ReviewRejected = "rejected"
+)
+
+type Review struct {
+    ID int
+    ProductID int
+    AccountID int
+    Author string
+    Rating int
+    Body string
+    Status string
+    Created time.Time
+}
+
+type ReviewRepository interface {
+
+    GetProductReviews(ctx context.Context, productId int) ([]Review, error)
+    GetReviews(ctx context.Context, status string) ([]Review, error)
+    GetReview(ctx context.Context, id int) (Review, error)
+    SaveReview(ctx context.Context, review *Review) error
+    SetReviewStatus(ctx context.Context, id int, status string) error
+
+    HasPurchased(ctx context.Context, accountId, productId int) (bool, error)
+    HasReviewed(ctx context.Context, accountId, productId int) (bool, error)
+    CountRecentReviews(ctx context.Context, accountId int, 
+        since time.Time) (int, error)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "context"
+
+type ShippingMethod struct {
+    ID int
+    Name string `validation:"required"`
+    Description string `scaffold:"textarea,nolist"`
+    Price float64 `validation:"min:0"`
+    DeliveryDays int `validation:"min:0"`
+    Active bool
+}
+
+type ShippingRepository interface {
+
+    GetShippingMethods(ctx context.Context) ([]ShippingMethod, error)
+    GetShippingMethod(ctx context.Context, id int) (ShippingMethod, error)
+    SaveShippingMethod(ctx context.Context, method *ShippingMethod) error
+    DeleteShippingMethod(ctx context.Context, id int) error
+}
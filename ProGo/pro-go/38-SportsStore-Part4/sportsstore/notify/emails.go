@@ -0,0 +1,50 @@
+package notify
+
+import (
+    "fmt"
+    "platform/mail"
+    "platform/templates"
+    "sportsstore/models"
+    "strings"
+)
+
+type emailTemplate struct {
+    name string
+    subject string
+}
+
+var emailTemplates = map[string]emailTemplate {
+    models.OrderConfirmationEmail: { 
+        name: "email_order_confirmation.html", 
+        subject: "Your SportsStore order #%v",
+    },
+    models.OrderShippedEmail: { 
+        name: "email_order_shipped.html",
+        subject: "Your SportsStore order #%v has shipped",
+    },
+}
+
+type EmailContext struct {
+    Order models.Order
+    Email models.OutboxEmail
+}
+
+func RenderEmail(executor templates.TemplateExecutor, email models.OutboxEmail,
+        order models.Order) (msg mail.Message, err error) {
+    tmpl, found := emailTemplates[email.Kind]
+    if !found {
+        return msg, fmt.Errorf("Unknown email kind: %v", email.Kind)
+    }
+    var sb strings.Builder
+    err = executor.ExecTemplate(&sb, tmpl.name, EmailContext{ 
+        Order: order, Email: email })
+    if err == nil {
+        msg = mail.Message{
+            To: []string { email.Recipient },
+            Subject: fmt.Sprintf(tmpl.subject, order.ID),
+            Body: sb.String(),
+            HTML: true,
+        }
+    }
+    return
+}
@@ -0,0 +1,119 @@
+package notify
+
+import (
+    "context"
+    "errors"
+    "platform/config"
+    "platform/jobs"
+    "platform/logging"
+    "platform/mail"
+    "platform/templates"
+    "sportsstore/models"
+    "time"
+)
+
+type OutboxWorker struct {
+    Outbox models.OutboxRepository
+    Mailer mail.Mailer
+    templates.TemplateExecutor
+    logging.Logger
+    config.Configuration
+}
+
+func SendPendingEmails(job jobs.Job, ctx context.Context, 
+        outbox models.OutboxRepository, mailer mail.Mailer, 
+        executor templates.TemplateExecutor, logger logging.Logger, 
+        cfg config.Configuration) error {
+    worker := &OutboxWorker{ Outbox: outbox, Mailer: mailer, 
+        TemplateExecutor: executor, Logger: logger, Configuration: cfg }
+    _, err := worker.ProcessPending(ctx)
+    return err
+}
+
+func (w *OutboxWorker) ProcessPending(ctx context.Context) (sent int, err error) {
+    now := time.Now()
+    emails, err := w.Outbox.GetPendingEmails(ctx, now, 
+        w.Configuration.GetIntDefault("outbox:batchSize", 20))
+    if err != nil {
+        return
+    }
+    lease := w.duration("outbox:lease", 5 * time.Minute)
+    for _, email := range emails {
+        if ctx.Err() != nil {
+            return sent, ctx.Err()
+        }
+        claimed, err := w.Outbox.ClaimEmail(ctx, &email, time.Now(), lease)
+        if err != nil {
+            return sent, err
+        } else if !claimed {
+            continue
+        }
+        if w.process(ctx, &email) {
+            sent++
+        }
+    }
+    return
+}
+
+func (w *OutboxWorker) process(ctx context.Context, email *models.OutboxEmail) bool {
+    sendErr := w.deliver(ctx, email)
+    email.Attempts++
+    if sendErr == nil {
+        email.Status, email.LastError = models.EmailSent, ""
+        email.NextAttempt = time.Now()
+        w.Logger.Debugf("Sent %v email for order %v to %v", email.Kind, 
+            email.OrderID, email.Recipient)
+    } else {
+        email.LastError = sendErr.Error()
+        if email.Attempts >= w.Configuration.GetIntDefault("outbox:maxAttempts", 5) ||
+                errors.Is(sendErr, models.ErrNotFound) {
+            email.Status = models.EmailFailed
+            w.Logger.Warnf("Giving up on %v email for order %v after %v attempts: %v",
+                email.Kind, email.OrderID, email.Attempts, email.LastError)
+        } else {
+            email.Status = models.EmailPending
+            email.NextAttempt = time.Now().Add(w.backoff(email.Attempts))
+            w.Logger.Infof("Cannot send %v email for order %v, retrying at %v: %v",
+                email.Kind, email.OrderID, email.NextAttempt.Format(time.RFC3339),
+                email.LastError)
+        }
+    }
+    if err := w.Outbox.UpdateEmail(ctx, email); err != nil {
+        w.Logger.Warnf("Cannot update outbox email %v: %v", email.ID, err.Error())
+    }
+    return sendErr == nil
+}
+
+func (w *OutboxWorker) deliver(ctx context.Context, email *models.OutboxEmail) error {
+    order, err := w.Outbox.GetOrder(ctx, email.OrderID)
+    if err != nil {
+        return err
+    }
+    msg, err := RenderEmail(w.TemplateExecutor, *email, order)
+    if err != nil {
+        return err
+    }
+    return w.Mailer.Send(ctx, msg)
+}
+
+func (w *OutboxWorker) backoff(attempts int) time.Duration {
+    delay := w.duration("outbox:backoff", 30 * time.Second)
+    limit := w.duration("outbox:maxBackoff", time.Hour)
+    for i := 1; i < attempts && delay < limit; i++ {
+        delay *= 2
+    }
+    if delay > limit {
+        delay = limit
+    }
+    return delay
+}
+
+func (w *OutboxWorker) duration(name string, defVal time.Duration) time.Duration {
+    if val, found := w.Configuration.GetString(name); found {
+        if d, err := time.ParseDuration(val); err == nil && d > 0 {
+            return d
+        }
+        w.Logger.Warnf("Invalid duration for %v: %v", name, val)
+    }
+    return defVal
+}
@@ -0,0 +1,162 @@
+package pricing
+
+import (
+    "context"
+    "errors"
+    "sportsstore/models"
+    "strings"
+    "time"
+)
+
+var ErrShippingMethod = errors.New("The selected shipping method is not available")
+var ErrPromotionCode = errors.New("The promotion code is not valid")
+
+type Line struct {
+    Price float64
+    Weight float64
+    Quantity int
+}
+
+func SelectionLines(selections []models.ProductSelection) []Line {
+    lines := make([]Line, len(selections))
+    for i, sel := range selections {
+        lines[i] = Line{ Price: sel.Price, Weight: sel.Weight, Quantity: sel.Quantity }
+    }
+    return lines
+}
+
+type Request struct {
+    Lines []Line
+    Country, State string
+    ShippingMethodID int
+    PromotionCode string
+}
+
+type Quote struct {
+    models.OrderTotals
+    Method models.ShippingMethod
+    PromotionCode string
+    Weight float64
+}
+
+type Calculator struct {
+    Shipping models.ShippingRepository
+    Rates models.PricingRepository
+    Promotions models.PromotionRepository
+}
+
+func (c *Calculator) Methods(ctx context.Context) (active []models.ShippingMethod,
+        err error) {
+    methods, err := c.Shipping.GetShippingMethods(ctx)
+    for _, method := range methods {
+        if method.Active {
+            active = append(active, method)
+        }
+    }
+    return
+}
+
+func (c *Calculator) Calculate(ctx context.Context, req Request) (quote Quote, 
+        err error) {
+    subtotal := 0.0
+    for _, line := range req.Lines {
+        subtotal += line.Price * float64(line.Quantity)
+        quote.Weight += line.Weight * float64(line.Quantity)
+    }
+    discount := 0.0
+    if code := strings.TrimSpace(req.PromotionCode); code != "" {
+        var promotion models.Promotion
+        if promotion, err = c.findPromotion(ctx, code); err != nil {
+            return
+        }
+        quote.PromotionCode = promotion.Code
+        discount = subtotal * float64(promotion.DiscountPercent) / 100
+    }
+    if quote.Method, err = c.findMethod(ctx, req.ShippingMethodID); err != nil {
+        return
+    }
+    shipping := 0.0
+    if len(req.Lines) > 0 && quote.Method.ID > 0 {
+        var rates []models.ShippingRate
+        if rates, err = c.Rates.GetShippingRates(ctx); err != nil {
+            return
+        }
+        shipping = quote.Method.Price
+        if rate, found := SelectShippingRate(rates, quote.Method.ID, req.Country, 
+                req.State, quote.Weight); found {
+            shipping = rate.Price
+        }
+    }
+    taxRates, err := c.Rates.GetTaxRates(ctx)
+    if err != nil {
+        return
+    }
+    var tax *models.TaxRate
+    if rate, found := SelectTaxRate(taxRates, req.Country, req.State); found {
+        tax = &rate
+    }
+    quote.OrderTotals = ComputeTotals(subtotal, discount, shipping, tax)
+    return
+}
+
+func ComputeTotals(subtotal, discount, shipping float64, 
+        tax *models.TaxRate) (totals models.OrderTotals) {
+    totals.Subtotal = round(subtotal)
+    totals.Discount = round(discount)
+    totals.Shipping = round(shipping)
+    totals.Total = totals.Subtotal - totals.Discount + totals.Shipping
+    if tax != nil {
+        taxable := totals.Subtotal - totals.Discount
+        if tax.TaxShipping {
+            taxable += totals.Shipping
+        }
+        totals.TaxName, totals.TaxRate, totals.TaxIncluded = tax.Name, tax.Rate, 
+            tax.Inclusive
+        if tax.Inclusive {
+            totals.Tax = round(taxable * tax.Rate / (100 + tax.Rate))
+        } else {
+            totals.Tax = round(taxable * tax.Rate / 100)
+            totals.Total += totals.Tax
+        }
+    }
+    totals.Total = round(totals.Total)
+    return
+}
+
+func (c *Calculator) findPromotion(ctx context.Context, 
+        code string) (models.Promotion, error) {
+    promotions, err := c.Promotions.GetPromotions(ctx)
+    if err == nil {
+        now := time.Now()
+        for _, promotion := range promotions {
+            if strings.EqualFold(promotion.Code, code) && promotion.IsCurrent(now) {
+                return promotion, nil
+            }
+        }
+        err = ErrPromotionCode
+    }
+    return models.Promotion{}, err
+}
+
+func (c *Calculator) findMethod(ctx context.Context, 
+        id int) (models.ShippingMethod, error) {
+    methods, err := c.Methods(ctx)
+    if err == nil {
+        for _, method := range methods {
+            if method.ID == id || id == 0 {
+                return method, nil
+            }
+        }
+        if id != 0 {
+            err = ErrShippingMethod
+        }
+    }
+    return models.ShippingMethod{}, err
+}
+
+func (quote Quote) Apply(order *models.Order) {
+    order.OrderTotals = quote.OrderTotals
+    order.ShippingMethodID = quote.Method.ID
+    order.ShippingMethod = quote.Method.Name
+    order.PromotionCode = quote.PromotionCode
+}
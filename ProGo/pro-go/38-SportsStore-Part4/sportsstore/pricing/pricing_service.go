@@ -0,0 +1,17 @@
+package pricing
+
+import (
+    "platform/services"
+    "sportsstore/models"
+)
+
+func RegisterPricingService() {
+    err := services.AddScoped(func(shipping models.ShippingRepository, 
+            rates models.PricingRepository, 
+            promotions models.PromotionRepository) *Calculator {
+        return &Calculator{ Shipping: shipping, Rates: rates, Promotions: promotions }
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
@@ -0,0 +1,69 @@
+package pricing
+
+import (
+    "math"
+    "sportsstore/models"
+    "strings"
+)
+
+func matchesList(list, value string) bool {
+    for _, item := range strings.Split(list, ",") {
+        if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(value)) {
+            return true
+        }
+    }
+    return false
+}
+
+func specificity(country, state, destCountry, destState string) int {
+    if country == "" {
+        return 0
+    } else if !matchesList(country, destCountry) {
+        return -1
+    } else if state == "" {
+        return 1
+    } else if !matchesList(state, destState) {
+        return -1
+    }
+    return 2
+}
+
+func SelectShippingRate(rates []models.ShippingRate, methodID int, 
+        country, state string, weight float64) (selected models.ShippingRate, 
+        found bool) {
+    best := -1
+    for _, rate := range rates {
+        if rate.MethodID != methodID || 
+                (rate.MaxWeight > 0 && weight > rate.MaxWeight) {
+            continue
+        }
+        score := specificity(rate.Country, rate.State, country, state)
+        if score < 0 || score < best {
+            continue
+        }
+        if score > best || isTighter(rate.MaxWeight, selected.MaxWeight) {
+            selected, best, found = rate, score, true
+        }
+    }
+    return
+}
+
+func isTighter(maxWeight, current float64) bool {
+    return maxWeight > 0 && (current == 0 || maxWeight < current)
+}
+
+func SelectTaxRate(rates []models.TaxRate, country, 
+        state string) (selected models.TaxRate, found bool) {
+    best := 0
+    for _, rate := range rates {
+        if score := specificity(rate.Country, rate.State, country, 
+                state); score > best {
+            selected, best, found = rate, score, true
+        }
+    }
+    return
+}
+
+func round(value float64) float64 {
+    return math.Round(value * 100) / 100
+}
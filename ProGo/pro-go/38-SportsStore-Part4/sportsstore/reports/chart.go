@@ -0,0 +1,105 @@
+package reports
+
+import (
+    "fmt"
+    "math"
+)
+
+type Bar struct {
+    X, Y, Width, Height float64
+    LabelX float64
+    Label string
+    ShowLabel bool
+    Value float64
+}
+
+type GridLine struct {
+    Y float64
+    Label string
+}
+
+type BarChart struct {
+    Width, Height float64
+    PlotLeft, PlotBottom float64
+    Bars []Bar
+    GridLines []GridLine
+}
+
+const (
+    chartLeftMargin = 60.0
+    chartBottomMargin = 24.0
+    chartTopMargin = 8.0
+    chartMaxLabels = 12
+)
+
+func NewBarChart(labels []string, values []float64, width, height float64) BarChart {
+    chart := BarChart{ Width: width, Height: height, PlotLeft: chartLeftMargin,
+        PlotBottom: height - chartBottomMargin }
+    maxValue := niceCeiling(maxOf(values))
+    plotHeight := chart.PlotBottom - chartTopMargin
+    for i := 0; i <= 4; i++ {
+        value := maxValue * float64(i) / 4
+        chart.GridLines = append(chart.GridLines, GridLine{ 
+            Y: round(chart.PlotBottom - plotHeight * float64(i) / 4),
+            Label: fmt.Sprintf("$%.0f", value),
+        })
+    }
+    if len(values) == 0 {
+        return chart
+    }
+    slot := (width - chartLeftMargin) / float64(len(values))
+    labelEvery := int(math.Ceil(float64(len(values)) / chartMaxLabels))
+    for i, value := range values {
+        barHeight := 0.0
+        if maxValue > 0 {
+            barHeight = plotHeight * value / maxValue
+        }
+        x := chartLeftMargin + slot * float64(i)
+        chart.Bars = append(chart.Bars, Bar{
+            X: round(x + slot * 0.1), 
+            Y: round(chart.PlotBottom - barHeight),
+            Width: round(slot * 0.8), 
+            Height: round(barHeight),
+            LabelX: round(x + slot / 2),
+            Label: labels[i],
+            ShowLabel: i % labelEvery == 0,
+            Value: value,
+        })
+    }
+    return chart
+}
+
+func round(value float64) float64 {
+    return math.Round(value * 10) / 10
+}
+
+func maxOf(values []float64) (max float64) {
+    for _, v := range values {
+        if v > max {
+            max = v
+        }
+    }
+    return
+}
+
+func niceCeiling(value float64) float64 {
+    if value <= 0 {
+        return 1
+    }
+    magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+    for _, step := range []float64 { 1, 2, 2.5, 5, 10 } {
+        if step * magnitude >= value {
+            return step * magnitude
+        }
+    }
+    return 10 * magnitude
+}
+
+func (report SalesReport) RevenueChart() BarChart {
+    labels := make([]string, len(report.Buckets))
+    values := make([]float64, len(report.Buckets))
+    for i, bucket := range report.Buckets {
+        labels[i], values[i] = bucket.Label, bucket.Revenue
+    }
+    return NewBarChart(labels, values, 800, 240)
+}
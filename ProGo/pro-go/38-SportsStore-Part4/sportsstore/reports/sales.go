@@ -0,0 +1,226 @@
+package reports
+
+import (
+    "fmt"
+    "sort"
+    "sportsstore/models"
+    "time"
+)
+
+const (
+    ByDay = "day"
+    ByWeek = "week"
+    ByMonth = "month"
+)
+
+var Periods = []string { ByDay, ByWeek, ByMonth }
+
+type RevenueBucket struct {
+    Start time.Time
+    Label string
+    Orders int
+    Units int
+    Revenue float64
+}
+
+type RankedItem struct {
+    ID int
+    Name string
+    Orders int
+    Units int
+    Revenue float64
+    Share float64
+}
+
+type RegionTotal struct {
+    Country string
+    State string
+    Orders int
+    Units int
+    Revenue float64
+    Share float64
+}
+
+type SalesReport struct {
+    From, To time.Time
+    Period string
+    Orders int
+    EstimatedDates int
+    EstimatedPrices int
+    Units int
+    Revenue float64
+    AverageOrderValue float64
+    Buckets []RevenueBucket
+    TopProducts []RankedItem
+    TopCategories []RankedItem
+    Regions []RegionTotal
+}
+
+func ValidPeriod(period string) bool {
+    for _, p := range Periods {
+        if p == period {
+            return true
+        }
+    }
+    return false
+}
+
+func BucketStart(t time.Time, period string) time.Time {
+    day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+    switch period {
+        case ByWeek:
+            return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+        case ByMonth:
+            return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+        default:
+            return day
+    }
+}
+
+func nextBucket(start time.Time, period string) time.Time {
+    switch period {
+        case ByWeek:
+            return start.AddDate(0, 0, 7)
+        case ByMonth:
+            return start.AddDate(0, 1, 0)
+        default:
+            return start.AddDate(0, 0, 1)
+    }
+}
+
+func bucketLabel(start time.Time, period string) string {
+    switch period {
+        case ByWeek:
+            year, week := start.ISOWeek()
+            return fmt.Sprintf("%d-W%02d", year, week)
+        case ByMonth:
+            return start.Format("2006-01")
+        default:
+            return start.Format("2006-01-02")
+    }
+}
+
+type accumulator struct {
+    orders map[int]bool
+    units int
+    revenue float64
+}
+
+func (acc *accumulator) add(line models.SalesLine, revenue float64) {
+    if acc.orders == nil {
+        acc.orders = map[int]bool {}
+    }
+    acc.orders[line.OrderID] = true
+    acc.units += line.Quantity
+    acc.revenue += revenue
+}
+
+type accumulators map[interface{}]*accumulator
+
+func (values accumulators) get(key interface{}) *accumulator {
+    acc, found := values[key]
+    if !found {
+        acc = &accumulator{}
+        values[key] = acc
+    }
+    return acc
+}
+
+func BuildSalesReport(lines []models.SalesLine, from, to time.Time, 
+        period string, top int) SalesReport {
+    report := SalesReport{ From: from, To: to, Period: period }
+    // Each order's net revenue is shared between its lines by their line prices
+    orderLines := map[int]float64 {}
+    for _, line := range lines {
+        orderLines[line.OrderID] += line.Revenue()
+    }
+    total := accumulator{}
+    buckets, products, categories := accumulators{}, accumulators{}, accumulators{}
+    productNames, categoryNames := map[int]string {}, map[int]string {}
+    regions, estimated, estimatedPrices := accumulators{}, accumulator{}, 
+        accumulator{}
+    for _, line := range lines {
+        created := line.Created.In(from.Location())
+        if created.Before(from) || !created.Before(to) {
+            continue
+        }
+        revenue := 0.0
+        if gross := orderLines[line.OrderID]; gross > 0 {
+            revenue = line.NetRevenue() * line.Revenue() / gross
+        }
+        total.add(line, revenue)
+        if line.CreatedEstimated {
+            estimated.add(line, revenue)
+        }
+        if line.PricesEstimated {
+            estimatedPrices.add(line, revenue)
+        }
+        buckets.get(BucketStart(created, period).Unix()).add(line, revenue)
+        products.get(line.ProductID).add(line, revenue)
+        categories.get(line.CategoryID).add(line, revenue)
+        regions.get([2]string { line.Country, line.State }).add(line, revenue)
+        productNames[line.ProductID] = line.ProductName
+        categoryNames[line.CategoryID] = line.CategoryName
+    }
+    report.Orders, report.Units, report.Revenue = len(total.orders), total.units, 
+        total.revenue
+    report.EstimatedDates = len(estimated.orders)
+    report.EstimatedPrices = len(estimatedPrices.orders)
+    if report.Orders > 0 {
+        report.AverageOrderValue = report.Revenue / float64(report.Orders)
+    }
+    for start := BucketStart(from, period); start.Before(to); 
+            start = nextBucket(start, period) {
+        bucket := RevenueBucket{ Start: start, Label: bucketLabel(start, period) }
+        if acc, found := buckets[start.Unix()]; found {
+            bucket.Orders, bucket.Units, bucket.Revenue = len(acc.orders), 
+                acc.units, acc.revenue
+        }
+        report.Buckets = append(report.Buckets, bucket)
+    }
+    report.TopProducts = rank(products, productNames, report.Revenue, top)
+    report.TopCategories = rank(categories, categoryNames, report.Revenue, top)
+    for key, acc := range regions {
+        region := key.([2]string)
+        report.Regions = append(report.Regions, RegionTotal{ Country: region[0], 
+            State: region[1], Orders: len(acc.orders), Units: acc.units, 
+            Revenue: acc.revenue, Share: share(acc.revenue, report.Revenue) })
+    }
+    sort.Slice(report.Regions, func(i, j int) bool {
+        if report.Regions[i].Revenue != report.Regions[j].Revenue {
+            return report.Regions[i].Revenue > report.Regions[j].Revenue
+        }
+        if report.Regions[i].Country != report.Regions[j].Country {
+            return report.Regions[i].Country < report.Regions[j].Country
+        }
+        return report.Regions[i].State < report.Regions[j].State
+    })
+    return report
+}
+
+func rank(values accumulators, names map[int]string, 
+        total float64, top int) []RankedItem {
+    items := make([]RankedItem, 0, len(values))
+    for id, acc := range values {
+        items = append(items, RankedItem{ ID: id.(int), Name: names[id.(int)], 
+            Orders: len(acc.orders), Units: acc.units, Revenue: acc.revenue,
+            Share: share(acc.revenue, total) })
+    }
+    sort.Slice(items, func(i, j int) bool {
+        if items[i].Revenue != items[j].Revenue {
+            return items[i].Revenue > items[j].Revenue
+        }
+        return items[i].Name < items[j].Name
+    })
+    if top > 0 && len(items) > top {
+        items = items[:top]
+    }
+    return items
+}
+
+func share(value, total float64) float64 {
+    if total > 0 {
+        return value * 100 / total
+    }
+    return 0
+}
@@ -0,0 +1,51 @@
+package reports
+
+import (
+    "math"
+    "sportsstore/models"
+    "testing"
+    "time"
+)
+
+func near(value, expected float64) bool {
+    return math.Abs(value - expected) < 0.005
+}
+
+func TestSalesReportUsesOrderTotals(t *testing.T) {
+    from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+    created := from.Add(time.Hour)
+    // UK order: 200 of goods, 20 discount, 10 shipping, 20% VAT included
+    uk := models.SalesLine{ OrderID: 1, Created: created, Country: "UK",
+        OrderTotal: 190, OrderTax: 31.67 }
+    // US order: 100 of goods, 8% sales tax added to the total
+    us := models.SalesLine{ OrderID: 2, Created: created, Country: "USA",
+        OrderTotal: 108, OrderTax: 8 }
+    lines := []models.SalesLine {}
+    for _, line := range []struct { order models.SalesLine; product, quantity int
+            price float64 } {
+        { uk, 1, 1, 100 }, { uk, 2, 2, 50 }, { us, 1, 1, 100 },
+    } {
+        l := line.order
+        l.ProductID, l.ProductName, l.Quantity, l.Price = line.product, 
+            map[int]string { 1: "Kayak", 2: "Lifejacket" }[line.product],
+            line.quantity, line.price
+        lines = append(lines, l)
+    }
+    report := BuildSalesReport(lines, from, from.AddDate(0, 0, 1), ByDay, 5)
+    if report.Orders != 2 || report.Units != 4 || !near(report.Revenue, 258.33) ||
+            !near(report.AverageOrderValue, 129.165) {
+        t.Fatalf("Unexpected totals: %v %v %v %v", report.Orders, report.Units,
+            report.Revenue, report.AverageOrderValue)
+    }
+    if len(report.TopProducts) != 2 || report.TopProducts[0].Name != "Kayak" ||
+            !near(report.TopProducts[0].Revenue, 179.165) ||
+            !near(report.TopProducts[1].Revenue, 79.165) {
+        t.Fatalf("Unexpected products: %+v", report.TopProducts)
+    }
+    if len(report.Regions) != 2 || report.Regions[0].Country != "UK" ||
+            !near(report.Regions[0].Revenue, 158.33) ||
+            !near(report.Regions[1].Revenue, 100) ||
+            !near(report.Buckets[0].Revenue, report.Revenue) {
+        t.Fatalf("Unexpected regions: %+v", report.Regions)
+    }
+}
@@ -0,0 +1,44 @@
+package reviews
+
+import (
+    "errors"
+    "platform/config"
+    "platform/services"
+    "platform/templates"
+    "platform/validation"
+    "strings"
+)
+
+func RegisterReviewServices() {
+    validation.AddValidator("nospam", func(fieldName string, value interface{}, 
+            arg string) (bool, error) {
+        str, ok := value.(string)
+        if !ok {
+            return false, errors.New("The nospam validator is for strings")
+        }
+        var cfg config.Configuration
+        if err := services.GetService(&cfg); err != nil {
+            return false, err
+        }
+        return !NewSpamFilter(cfg).IsSpam(str), ErrSpam
+    })
+    err := templates.AddContextFunc("stars", func() func(interface{}) []string {
+        return func(rating interface{}) []string {
+            if val, ok := rating.(int); ok {
+                return Stars(float64(val))
+            }
+            return Stars(rating.(float64))
+        }
+    })
+    if (err != nil) {
+        panic(err)
+    }
+}
+
+func NewSpamFilter(cfg config.Configuration) SpamFilter {
+    return SpamFilter{
+        Words: strings.Split(cfg.GetStringDefault("reviews:spam:words", ""), ","),
+        MaxRepeat: cfg.GetIntDefault("reviews:spam:maxRepeat", 5),
+        MaxCapitals: float64(cfg.GetIntDefault("reviews:spam:maxCapitals", 70)) / 100,
+    }
+}
@@ -0,0 +1,59 @@
+package reviews
+
+import (
+    "errors"
+    "strings"
+    "unicode"
+)
+
+var ErrSpam = errors.New("The text looks like spam")
+
+type SpamFilter struct {
+    Words []string
+    MaxRepeat int
+    MaxCapitals float64
+}
+
+func (f SpamFilter) IsSpam(text string) bool {
+    lower := strings.ToLower(text)
+    for _, word := range f.Words {
+        if word = strings.TrimSpace(strings.ToLower(word)); word != "" && 
+                strings.Contains(lower, word) {
+            return true
+        }
+    }
+    return f.repeats(text) || f.shouting(text)
+}
+
+func (f SpamFilter) repeats(text string) bool {
+    if f.MaxRepeat < 1 {
+        return false
+    }
+    var last rune
+    count := 0
+    for _, r := range text {
+        if r == last && !unicode.IsSpace(r) {
+            count++
+        } else {
+            last, count = r, 1
+        }
+        if count > f.MaxRepeat {
+            return true
+        }
+    }
+    return false
+}
+
+func (f SpamFilter) shouting(text string) bool {
+    letters, capitals := 0, 0
+    for _, r := range text {
+        if unicode.IsLetter(r) {
+            letters++
+            if unicode.IsUpper(r) {
+                capitals++
+            }
+        }
+    }
+    return f.MaxCapitals > 0 && letters >= 20 && 
+        float64(capitals) / float64(letters) > f.MaxCapitals
+}
@@ -0,0 +1,22 @@
+package reviews
+
+const (
+    fullStar = "fas fa-star"
+    halfStar = "fas fa-star-half-alt"
+    emptyStar = "far fa-star"
+)
+
+func Stars(rating float64) []string {
+    stars := make([]string, 5)
+    for i := range stars {
+        switch remaining := rating - float64(i); {
+            case remaining >= 0.75:
+                stars[i] = fullStar
+            case remaining >= 0.25:
+                stars[i] = halfStar
+            default:
+                stars[i] = emptyStar
+        }
+    }
+    return stars
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "platform/http/handling"
+    "text/tabwriter"
+)
+
+func runRoutesCommand(args []string) error {
+    if len(args) > 0 {
+        return usageError("routes")
+    }
+    writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+    fmt.Fprintln(writer, "METHOD\tPATTERN\tHANDLER\tACTION\tTARGET")
+    for _, route := range handling.DescribeRoutes(createComponents()...) {
+        fmt.Fprintf(writer, "%v\t%v\t%v\t%v\t%v\n", route.Method, route.Pattern, 
+            route.Handler, route.Action, route.Target)
+    }
+    return writer.Flush()
+}
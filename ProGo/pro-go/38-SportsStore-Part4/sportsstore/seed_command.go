@@ -0,0 +1,32 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "sportsstore/models/repo"
+)
+
+func runSeedCommand(args []string, ctx context.Context, 
+        sqlRepo *repo.SqlRepository) error {
+    flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+    reset := flags.Bool("reset", false, "Remove existing data before seeding")
+    if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+        return usageError("seed")
+    }
+    if *reset {
+        if err := sqlRepo.Init(ctx); err != nil {
+            return err
+        }
+    } else if products, err := sqlRepo.GetProducts(ctx); err != nil {
+        return err
+    } else if len(products) > 0 {
+        fmt.Println("Database already contains data, use --reset to replace it")
+        return nil
+    }
+    if err := sqlRepo.Seed(ctx); err != nil {
+        return err
+    }
+    fmt.Println("Database seeded")
+    return nil
+}
@@ -0,0 +1,2 @@
+UPDATE Outbox SET Status = 'sending', NextAttempt = ?
+WHERE Id = ? AND Status IN ('pending', 'sending') AND NextAttempt <= ?
@@ -0,0 +1,2 @@
+UPDATE Jobs SET Status = 'running', Attempts = Attempts + 1, RunAt = ?, Updated = ?
+WHERE Id = ? AND Status = ? AND Attempts = ?
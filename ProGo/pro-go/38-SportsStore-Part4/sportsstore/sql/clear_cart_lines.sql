@@ -0,0 +1 @@
+DELETE FROM CartLines WHERE AccountId = ?
@@ -0,0 +1 @@
+DELETE FROM CartLines WHERE Updated IS NULL OR Updated < ?
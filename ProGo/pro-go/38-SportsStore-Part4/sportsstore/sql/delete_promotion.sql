@@ -0,0 +1 @@
+DELETE FROM Promotions WHERE Id = ?
@@ -0,0 +1 @@
+DELETE FROM ShippingMethods WHERE Id = ?
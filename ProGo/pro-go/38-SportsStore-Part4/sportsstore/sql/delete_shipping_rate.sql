@@ -0,0 +1 @@
+DELETE FROM ShippingRates WHERE Id = ?
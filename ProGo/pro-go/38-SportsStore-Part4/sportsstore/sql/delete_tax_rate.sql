@@ -0,0 +1 @@
+DELETE FROM TaxRates WHERE Id = ?
@@ -0,0 +1,5 @@
+INSERT INTO Outbox(Kind, OrderId, Recipient, Created, Status, Attempts, NextAttempt, LastError)
+SELECT ?, Orders.Id, Orders.Email, ?, 'pending', 0, ?, ''
+FROM Orders
+WHERE Orders.Id = ? AND Orders.Email <> '' AND NOT EXISTS (
+    SELECT 1 FROM Outbox WHERE Outbox.OrderId = Orders.Id AND Outbox.Kind = ?)
\ No newline at end of file
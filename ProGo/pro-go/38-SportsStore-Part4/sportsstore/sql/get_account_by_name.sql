@@ -0,0 +1,4 @@
+SELECT Accounts.Id, Accounts.Name, Accounts.Email, Accounts.PasswordHash, 
+    Accounts.Roles
+FROM Accounts
+WHERE LOWER(Accounts.Name) = LOWER(?)
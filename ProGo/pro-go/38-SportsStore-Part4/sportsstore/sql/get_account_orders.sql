@@ -0,0 +1,8 @@
+SELECT Orders.Id, Orders.Name, Orders.Email, Orders.StreetAddr, Orders.City, Orders.State, 
+    Orders.Zip, Orders.Country, Orders.Shipped, Orders.AccountId, Orders.Created,
+    Orders.ShippingMethodId, Orders.ShippingMethod, Orders.PromotionCode, Orders.Subtotal,
+    Orders.Shipping, Orders.Discount, Orders.Tax, Orders.Total, Orders.TaxName, 
+    Orders.TaxRate, Orders.TaxIncluded
+FROM Orders
+WHERE Orders.AccountId = ?
+ORDER BY Orders.Id DESC
@@ -0,0 +1,8 @@
+SELECT Orders.Id, OrderLines.Quantity, Products.Id, Products.Name, 
+    Products.Description, OrderLines.Price, Categories.Id, Categories.Name
+FROM Orders, OrderLines, Products, Categories
+WHERE Orders.Id = OrderLines.OrderId 
+    AND OrderLines.ProductId = Products.Id 
+    AND Products.Category = Categories.Id
+    AND Orders.AccountId = ?
+ORDER BY Orders.Id
@@ -0,0 +1,8 @@
+SELECT Id, Time, ActorId, ActorName, Action, Entity, EntityId, Changes, 
+    Method, Path, RemoteAddr, UserAgent
+FROM AuditLog
+WHERE (? = '' OR ActorName = ?) AND (? = '' OR Action = ?) 
+    AND (? = '' OR Entity = ?) AND (? = 0 OR EntityId = ?)
+    AND Time >= ? AND Time < ?
+ORDER BY Id DESC
+LIMIT ?
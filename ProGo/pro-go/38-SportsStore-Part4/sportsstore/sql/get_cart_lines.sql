@@ -0,0 +1,7 @@
+SELECT CartLines.Quantity, Products.Id, Products.Name, Products.Description, 
+    Products.Price, Products.Weight, Categories.Id, Categories.Name
+FROM CartLines, Products, Categories
+WHERE CartLines.ProductId = Products.Id 
+    AND Products.Category = Categories.Id
+    AND CartLines.AccountId = ?
+ORDER BY CartLines.Id
@@ -0,0 +1,2 @@
+SELECT Name, Description, Enabled, Percentage, KeyBy
+FROM FeatureFlags ORDER BY Name
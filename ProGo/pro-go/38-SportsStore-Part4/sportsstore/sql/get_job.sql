@@ -0,0 +1,4 @@
+SELECT Jobs.Id, Jobs.Name, Jobs.Payload, Jobs.Status, Jobs.Attempts, Jobs.MaxAttempts, 
+    Jobs.RunAt, Jobs.Created, Jobs.Updated, Jobs.LastError
+FROM Jobs
+WHERE Jobs.Id = ?
@@ -0,0 +1,3 @@
+SELECT Jobs.Status, COUNT(Jobs.Id)
+FROM Jobs
+GROUP BY Jobs.Status
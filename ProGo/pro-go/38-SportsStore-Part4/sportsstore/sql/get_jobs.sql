@@ -0,0 +1,6 @@
+SELECT Jobs.Id, Jobs.Name, Jobs.Payload, Jobs.Status, Jobs.Attempts, Jobs.MaxAttempts, 
+    Jobs.RunAt, Jobs.Created, Jobs.Updated, Jobs.LastError
+FROM Jobs
+WHERE (? = '' OR Jobs.Status = ?) AND (? = '' OR Jobs.Name = ?)
+ORDER BY Jobs.Id DESC
+LIMIT ?
@@ -0,0 +1,6 @@
+SELECT Jobs.Id, Jobs.Name, Jobs.Payload, Jobs.Status, Jobs.Attempts, Jobs.MaxAttempts, 
+    Jobs.RunAt, Jobs.Created, Jobs.Updated, Jobs.LastError
+FROM Jobs
+WHERE Jobs.Status IN ('pending', 'running') AND Jobs.RunAt <= ?
+ORDER BY Jobs.RunAt, Jobs.Id
+LIMIT 1
@@ -0,0 +1,6 @@
+SELECT Outbox.Id, Outbox.Kind, Outbox.OrderId, Outbox.Recipient, Outbox.Created, 
+    Outbox.Status, Outbox.Attempts, Outbox.NextAttempt, Outbox.LastError
+FROM Outbox
+WHERE Outbox.Status IN ('pending', 'sending') AND Outbox.NextAttempt <= ?
+ORDER BY Outbox.NextAttempt, Outbox.Id
+LIMIT ?
@@ -1,4 +1,5 @@
 SELECT Products.Id, Products.Name, Products.Description, Products.Price, 
+    Products.Image, Products.Weight, Products.Rating, Products.ReviewCount, 
     Categories.Id, Categories.Name 
 FROM Products, Categories 
 WHERE Products.Category = Categories.Id	
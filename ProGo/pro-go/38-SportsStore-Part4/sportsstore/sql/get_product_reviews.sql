@@ -0,0 +1,4 @@
+SELECT Id, ProductId, AccountId, Author, Rating, Body, Status, Created
+FROM Reviews
+WHERE ProductId = ? AND Status = ?
+ORDER BY Created DESC, Id DESC
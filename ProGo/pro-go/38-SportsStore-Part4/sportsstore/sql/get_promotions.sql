@@ -0,0 +1,2 @@
+SELECT Id, Code, Description, DiscountPercent, Starts, Ends, Active
+FROM Promotions ORDER BY Id
@@ -0,0 +1,4 @@
+SELECT COUNT(*) 
+FROM Orders, OrderLines
+WHERE Orders.Id = OrderLines.OrderId 
+    AND Orders.AccountId = ? AND OrderLines.ProductId = ?
@@ -0,0 +1 @@
+SELECT COUNT(*) FROM Reviews WHERE AccountId = ? AND Created > ?
@@ -0,0 +1,3 @@
+SELECT Id, ProductId, AccountId, Author, Rating, Body, Status, Created
+FROM Reviews
+WHERE Id = ?
@@ -0,0 +1,10 @@
+SELECT Orders.Id, Orders.Created, Orders.CreatedEstimated, 
+    Orders.PricesEstimated, Orders.State, Orders.Country, OrderLines.Quantity, 
+    Products.Id, Products.Name, OrderLines.Price, Categories.Id, Categories.Name,
+    Orders.Total, Orders.Tax
+FROM Orders, OrderLines, Products, Categories
+WHERE Orders.Id = OrderLines.OrderId 
+    AND OrderLines.ProductId = Products.Id 
+    AND Products.Category = Categories.Id
+    AND Orders.Created >= ? AND Orders.Created < ?
+ORDER BY Orders.Created, Orders.Id
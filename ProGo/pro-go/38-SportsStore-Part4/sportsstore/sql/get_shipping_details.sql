@@ -0,0 +1,4 @@
+SELECT Addresses.Name, Addresses.StreetAddr, Addresses.City, Addresses.State, 
+    Addresses.Zip, Addresses.Country
+FROM Addresses
+WHERE Addresses.AccountId = ?
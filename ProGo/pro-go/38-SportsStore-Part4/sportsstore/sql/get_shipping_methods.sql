@@ -0,0 +1,2 @@
+SELECT Id, Name, Description, Price, DeliveryDays, Active
+FROM ShippingMethods ORDER BY Id
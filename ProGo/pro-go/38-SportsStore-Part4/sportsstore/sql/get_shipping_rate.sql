@@ -0,0 +1,2 @@
+SELECT Id, MethodId, Country, State, MaxWeight, Price
+FROM ShippingRates WHERE Id = ?
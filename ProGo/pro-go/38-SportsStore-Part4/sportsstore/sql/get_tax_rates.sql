@@ -0,0 +1,2 @@
+SELECT Id, Name, Country, State, Rate, Inclusive, TaxShipping
+FROM TaxRates ORDER BY Country, State, Id
@@ -0,0 +1,2 @@
+SELECT Categories.Id, Products.Id, Orders.Id, OrderLines.Id
+FROM Categories, Products, Orders, OrderLines WHERE 1 = 0
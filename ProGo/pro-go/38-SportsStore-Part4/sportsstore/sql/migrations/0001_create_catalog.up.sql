@@ -1,8 +1,3 @@
-DROP TABLE IF EXISTS OrderLines;
-DROP TABLE IF EXISTS Orders;
-DROP TABLE IF EXISTS Products;
-DROP TABLE IF EXISTS Categories;
-
 CREATE TABLE IF NOT EXISTS Categories (
     Id INTEGER NOT NULL PRIMARY KEY,	Name TEXT
 );
@@ -14,13 +9,6 @@ CREATE TABLE IF NOT EXISTS Products (
     CONSTRAINT CatRef FOREIGN KEY(Category) REFERENCES Categories (Id)
 );
 
-CREATE TABLE IF NOT EXISTS OrderLines (
-    Id INTEGER NOT NULL PRIMARY KEY,
-    OrderId INT, ProductId INT, Quantity INT,
-    CONSTRAINT OrderRef FOREIGN KEY(ProductId) REFERENCES Products (Id)
-    CONSTRAINT OrderRef FOREIGN KEY(OrderId) REFERENCES Orders (Id)
-);
-
 CREATE TABLE IF NOT EXISTS Orders (
     Id INTEGER NOT NULL PRIMARY KEY,
     Name TEXT NOT NULL, 
@@ -28,5 +16,12 @@ CREATE TABLE IF NOT EXISTS Orders (
     City TEXT NOT NULL,
     Zip TEXT NOT NULL,
     Country TEXT NOT NULL,
-    Shipped BOOLEAN 
+    Shipped BOOLEAN
+);
+
+CREATE TABLE IF NOT EXISTS OrderLines (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    OrderId INT, ProductId INT, Quantity INT,
+    CONSTRAINT OrderRef FOREIGN KEY(ProductId) REFERENCES Products (Id)
+    CONSTRAINT OrderRef FOREIGN KEY(OrderId) REFERENCES Orders (Id)
 );
@@ -0,0 +1,2 @@
+SELECT Accounts.Id, Addresses.AccountId, CartLines.Id, Orders.State, Orders.AccountId
+FROM Accounts, Addresses, CartLines, Orders WHERE 1 = 0
@@ -0,0 +1 @@
+SELECT Image FROM Products WHERE 1 = 0
@@ -0,0 +1 @@
+ALTER TABLE Products ADD COLUMN Image TEXT NOT NULL DEFAULT '';
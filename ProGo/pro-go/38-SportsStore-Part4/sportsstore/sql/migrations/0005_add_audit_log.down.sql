@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS AuditLogNoDelete;
+DROP TRIGGER IF EXISTS AuditLogNoUpdate;
+DROP TABLE IF EXISTS AuditLog;
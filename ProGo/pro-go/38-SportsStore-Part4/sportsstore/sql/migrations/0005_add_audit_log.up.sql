@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS AuditLog (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    Time TIMESTAMP NOT NULL,
+    ActorId INTEGER NOT NULL,
+    ActorName TEXT NOT NULL,
+    Action TEXT NOT NULL,
+    Entity TEXT NOT NULL,
+    EntityId INTEGER NOT NULL,
+    Changes TEXT NOT NULL,
+    Method TEXT NOT NULL,
+    Path TEXT NOT NULL,
+    RemoteAddr TEXT NOT NULL,
+    UserAgent TEXT NOT NULL
+);
+
+CREATE INDEX AuditLogByTime ON AuditLog (Time);
+
+CREATE TRIGGER AuditLogNoUpdate BEFORE UPDATE ON AuditLog
+BEGIN
+    SELECT RAISE(ABORT, 'AuditLog is append-only');
+END;
+
+CREATE TRIGGER AuditLogNoDelete BEFORE DELETE ON AuditLog
+BEGIN
+    SELECT RAISE(ABORT, 'AuditLog is append-only');
+END;
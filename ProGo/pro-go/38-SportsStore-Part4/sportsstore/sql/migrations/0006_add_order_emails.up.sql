@@ -0,0 +1,15 @@
+ALTER TABLE Orders ADD COLUMN Email TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS Outbox (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    Kind TEXT NOT NULL,
+    OrderId INTEGER NOT NULL,
+    Recipient TEXT NOT NULL,
+    Created TIMESTAMP NOT NULL,
+    Status TEXT NOT NULL DEFAULT 'pending',
+    Attempts INTEGER NOT NULL DEFAULT 0,
+    NextAttempt TIMESTAMP NOT NULL,
+    LastError TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX OutboxByStatus ON Outbox (Status, NextAttempt);
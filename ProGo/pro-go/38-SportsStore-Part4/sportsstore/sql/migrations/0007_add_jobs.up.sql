@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS Jobs (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    Name TEXT NOT NULL,
+    Payload TEXT NOT NULL,
+    Status TEXT NOT NULL,
+    Attempts INTEGER NOT NULL DEFAULT 0,
+    MaxAttempts INTEGER NOT NULL,
+    RunAt TIMESTAMP NOT NULL,
+    Created TIMESTAMP NOT NULL,
+    Updated TIMESTAMP NOT NULL,
+    LastError TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX JobsByStatus ON Jobs (Status, RunAt);
+
+ALTER TABLE CartLines ADD COLUMN Updated TIMESTAMP NULL;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS ShippingMethods (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    Name TEXT NOT NULL,
+    Description TEXT NOT NULL DEFAULT '',
+    Price decimal(8, 2) NOT NULL,
+    DeliveryDays INTEGER NOT NULL,
+    Active BOOLEAN NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Promotions (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    Code TEXT NOT NULL UNIQUE,
+    Description TEXT NOT NULL DEFAULT '',
+    DiscountPercent INTEGER NOT NULL,
+    Starts TIMESTAMP NULL,
+    Ends TIMESTAMP NULL,
+    Active BOOLEAN NOT NULL
+);
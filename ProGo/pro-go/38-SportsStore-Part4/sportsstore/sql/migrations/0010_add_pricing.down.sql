@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS TaxRates;
+DROP TABLE IF EXISTS ShippingRates;
+
+ALTER TABLE Orders DROP COLUMN TaxIncluded;
+ALTER TABLE Orders DROP COLUMN TaxRate;
+ALTER TABLE Orders DROP COLUMN TaxName;
+ALTER TABLE Orders DROP COLUMN Total;
+ALTER TABLE Orders DROP COLUMN Tax;
+ALTER TABLE Orders DROP COLUMN Discount;
+ALTER TABLE Orders DROP COLUMN Shipping;
+ALTER TABLE Orders DROP COLUMN Subtotal;
+ALTER TABLE Orders DROP COLUMN PromotionCode;
+ALTER TABLE Orders DROP COLUMN ShippingMethod;
+ALTER TABLE Orders DROP COLUMN ShippingMethodId;
+
+ALTER TABLE Products DROP COLUMN Weight;
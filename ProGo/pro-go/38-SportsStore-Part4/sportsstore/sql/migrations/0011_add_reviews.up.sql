@@ -0,0 +1,18 @@
+ALTER TABLE Products ADD COLUMN Rating DECIMAL(3, 2) NOT NULL DEFAULT 0;
+ALTER TABLE Products ADD COLUMN ReviewCount INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX ProductsByRating ON Products (Rating, Id);
+
+CREATE TABLE IF NOT EXISTS Reviews (
+    Id INTEGER NOT NULL PRIMARY KEY,
+    ProductId INTEGER NOT NULL,
+    AccountId INTEGER NOT NULL,
+    Author TEXT NOT NULL,
+    Rating INTEGER NOT NULL,
+    Body TEXT NOT NULL,
+    Status TEXT NOT NULL,
+    Created TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ReviewsByProduct ON Reviews (ProductId, Status);
+CREATE INDEX ReviewsByAccount ON Reviews (AccountId, Created);
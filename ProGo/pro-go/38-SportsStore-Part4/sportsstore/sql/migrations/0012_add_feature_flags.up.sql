@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS FeatureFlags (
+    Name TEXT NOT NULL PRIMARY KEY,
+    Description TEXT NOT NULL DEFAULT '',
+    Enabled BOOLEAN NOT NULL,
+    Percentage INTEGER NOT NULL,
+    KeyBy TEXT NOT NULL,
+    Updated TIMESTAMP NOT NULL
+);
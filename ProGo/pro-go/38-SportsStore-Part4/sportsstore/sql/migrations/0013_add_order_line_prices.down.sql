@@ -0,0 +1,3 @@
+ALTER TABLE Orders DROP COLUMN CreatedEstimated;
+ALTER TABLE Orders DROP COLUMN PricesEstimated;
+ALTER TABLE OrderLines DROP COLUMN Price;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS OrderLines;
+DROP TABLE IF EXISTS Orders;
+DROP TABLE IF EXISTS Products;
+DROP TABLE IF EXISTS Categories;
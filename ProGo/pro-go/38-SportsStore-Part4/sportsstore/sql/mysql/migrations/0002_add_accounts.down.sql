@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS CartLines;
+DROP TABLE IF EXISTS Addresses;
+DROP TABLE IF EXISTS Accounts;
+
+ALTER TABLE Orders DROP COLUMN AccountId;
+ALTER TABLE Orders DROP COLUMN State;
@@ -0,0 +1 @@
+ALTER TABLE Products ADD COLUMN Image VARCHAR(255) NOT NULL DEFAULT '';
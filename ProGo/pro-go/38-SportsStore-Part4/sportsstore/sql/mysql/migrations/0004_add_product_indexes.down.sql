@@ -0,0 +1,3 @@
+DROP INDEX ProductsByName ON Products;
+DROP INDEX ProductsByPrice ON Products;
+DROP INDEX ProductsByCategory ON Products;
@@ -0,0 +1,3 @@
+CREATE INDEX ProductsByName ON Products (Name(191), Id);
+CREATE INDEX ProductsByPrice ON Products (Price, Id);
+CREATE INDEX ProductsByCategory ON Products (Category, Id);
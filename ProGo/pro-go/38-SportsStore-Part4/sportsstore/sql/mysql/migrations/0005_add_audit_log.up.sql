@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS AuditLog (
+    Id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    Time DATETIME(6) NOT NULL,
+    ActorId INTEGER NOT NULL,
+    ActorName VARCHAR(255) NOT NULL,
+    Action VARCHAR(255) NOT NULL,
+    Entity VARCHAR(255) NOT NULL,
+    EntityId INTEGER NOT NULL,
+    Changes TEXT NOT NULL,
+    Method VARCHAR(16) NOT NULL,
+    Path TEXT NOT NULL,
+    RemoteAddr VARCHAR(255) NOT NULL,
+    UserAgent TEXT NOT NULL
+);
+
+CREATE INDEX AuditLogByTime ON AuditLog (Time);
+
+CREATE TRIGGER AuditLogNoUpdate BEFORE UPDATE ON AuditLog FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'AuditLog is append-only';
+
+CREATE TRIGGER AuditLogNoDelete BEFORE DELETE ON AuditLog FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'AuditLog is append-only';
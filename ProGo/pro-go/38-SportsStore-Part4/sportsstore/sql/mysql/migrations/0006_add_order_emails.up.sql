@@ -0,0 +1,15 @@
+ALTER TABLE Orders ADD COLUMN Email VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS Outbox (
+    Id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    Kind VARCHAR(64) NOT NULL,
+    OrderId INTEGER NOT NULL,
+    Recipient VARCHAR(255) NOT NULL,
+    Created DATETIME(6) NOT NULL,
+    Status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    Attempts INTEGER NOT NULL DEFAULT 0,
+    NextAttempt DATETIME(6) NOT NULL,
+    LastError TEXT NOT NULL
+);
+
+CREATE INDEX OutboxByStatus ON Outbox (Status, NextAttempt);
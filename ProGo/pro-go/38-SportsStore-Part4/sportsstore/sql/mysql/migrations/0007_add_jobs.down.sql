@@ -0,0 +1,2 @@
+ALTER TABLE CartLines DROP COLUMN Updated;
+DROP TABLE IF EXISTS Jobs;
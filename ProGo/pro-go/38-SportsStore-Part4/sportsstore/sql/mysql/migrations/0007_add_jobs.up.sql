@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS Jobs (
+    Id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(255) NOT NULL,
+    Payload TEXT NOT NULL,
+    Status VARCHAR(16) NOT NULL,
+    Attempts INTEGER NOT NULL DEFAULT 0,
+    MaxAttempts INTEGER NOT NULL,
+    RunAt DATETIME(6) NOT NULL,
+    Created DATETIME(6) NOT NULL,
+    Updated DATETIME(6) NOT NULL,
+    LastError TEXT NOT NULL
+);
+
+CREATE INDEX JobsByStatus ON Jobs (Status, RunAt);
+
+ALTER TABLE CartLines ADD COLUMN Updated DATETIME(6) NULL;
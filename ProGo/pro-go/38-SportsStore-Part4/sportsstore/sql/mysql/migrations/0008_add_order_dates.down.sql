@@ -0,0 +1,2 @@
+DROP INDEX OrdersByCreated ON Orders;
+ALTER TABLE Orders DROP COLUMN Created;
@@ -0,0 +1,5 @@
+ALTER TABLE Orders ADD COLUMN Created DATETIME(6) NULL;
+
+UPDATE Orders SET Created = CURRENT_TIMESTAMP(6) WHERE Created IS NULL;
+
+CREATE INDEX OrdersByCreated ON Orders (Created);
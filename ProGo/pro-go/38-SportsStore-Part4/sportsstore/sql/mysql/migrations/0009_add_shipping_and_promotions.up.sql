@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS ShippingMethods (
+    Id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    Name VARCHAR(255) NOT NULL,
+    Description TEXT NOT NULL,
+    Price DECIMAL(8, 2) NOT NULL,
+    DeliveryDays INTEGER NOT NULL,
+    Active BOOLEAN NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Promotions (
+    Id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    Code VARCHAR(64) NOT NULL UNIQUE,
+    Description TEXT NOT NULL,
+    DiscountPercent INTEGER NOT NULL,
+    Starts DATETIME(6) NULL,
+    Ends DATETIME(6) NULL,
+    Active BOOLEAN NOT NULL
+);
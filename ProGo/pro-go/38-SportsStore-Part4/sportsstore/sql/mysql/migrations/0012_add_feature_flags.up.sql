@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS FeatureFlags (
+    Name VARCHAR(128) NOT NULL PRIMARY KEY,
+    Description TEXT NOT NULL,
+    Enabled BOOLEAN NOT NULL,
+    Percentage INTEGER NOT NULL,
+    KeyBy VARCHAR(32) NOT NULL,
+    Updated DATETIME(6) NOT NULL
+);
@@ -0,0 +1,5 @@
+INSERT INTO Addresses(AccountId, Name, StreetAddr, City, State, Zip, Country) 
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE Name = VALUES(Name), 
+    StreetAddr = VALUES(StreetAddr), City = VALUES(City), 
+    State = VALUES(State), Zip = VALUES(Zip), Country = VALUES(Country)
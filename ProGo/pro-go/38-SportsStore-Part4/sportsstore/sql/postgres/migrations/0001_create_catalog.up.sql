@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS Categories (
+    Id SERIAL PRIMARY KEY, Name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS Products (
+    Id SERIAL PRIMARY KEY,
+    Name TEXT, Description TEXT,
+    Category INTEGER, Price NUMERIC(8, 2),
+    CONSTRAINT CatRef FOREIGN KEY(Category) REFERENCES Categories (Id)
+);
+
+CREATE TABLE IF NOT EXISTS Orders (
+    Id SERIAL PRIMARY KEY,
+    Name TEXT NOT NULL, 
+    StreetAddr TEXT NOT NULL,
+    City TEXT NOT NULL,
+    Zip TEXT NOT NULL,
+    Country TEXT NOT NULL,
+    Shipped BOOLEAN
+);
+
+CREATE TABLE IF NOT EXISTS OrderLines (
+    Id SERIAL PRIMARY KEY,
+    OrderId INTEGER, ProductId INTEGER, Quantity INTEGER,
+    CONSTRAINT ProductRef FOREIGN KEY(ProductId) REFERENCES Products (Id),
+    CONSTRAINT OrderRef FOREIGN KEY(OrderId) REFERENCES Orders (Id)
+);
@@ -0,0 +1,28 @@
+ALTER TABLE Orders ADD COLUMN State TEXT NOT NULL DEFAULT '';
+ALTER TABLE Orders ADD COLUMN AccountId INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS Accounts (
+    Id SERIAL PRIMARY KEY,
+    Name TEXT NOT NULL UNIQUE,
+    Email TEXT NOT NULL,
+    PasswordHash TEXT NOT NULL,
+    Roles TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Addresses (
+    AccountId INTEGER NOT NULL PRIMARY KEY,
+    Name TEXT NOT NULL, 
+    StreetAddr TEXT NOT NULL,
+    City TEXT NOT NULL,
+    State TEXT NOT NULL,
+    Zip TEXT NOT NULL,
+    Country TEXT NOT NULL,
+    CONSTRAINT AccountRef FOREIGN KEY(AccountId) REFERENCES Accounts (Id)
+);
+
+CREATE TABLE IF NOT EXISTS CartLines (
+    Id SERIAL PRIMARY KEY,
+    AccountId INTEGER, ProductId INTEGER, Quantity INTEGER,
+    CONSTRAINT CartAccountRef FOREIGN KEY(AccountId) REFERENCES Accounts (Id),
+    CONSTRAINT CartProductRef FOREIGN KEY(ProductId) REFERENCES Products (Id)
+);
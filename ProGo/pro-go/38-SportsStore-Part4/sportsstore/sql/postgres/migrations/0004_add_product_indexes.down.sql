@@ -0,0 +1,3 @@
+DROP INDEX ProductsByName;
+DROP INDEX ProductsByPrice;
+DROP INDEX ProductsByCategory;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS AuditLog (
+    Id SERIAL PRIMARY KEY,
+    Time TIMESTAMP NOT NULL,
+    ActorId INTEGER NOT NULL,
+    ActorName TEXT NOT NULL,
+    Action TEXT NOT NULL,
+    Entity TEXT NOT NULL,
+    EntityId INTEGER NOT NULL,
+    Changes TEXT NOT NULL,
+    Method TEXT NOT NULL,
+    Path TEXT NOT NULL,
+    RemoteAddr TEXT NOT NULL,
+    UserAgent TEXT NOT NULL
+);
+
+CREATE INDEX AuditLogByTime ON AuditLog (Time);
+
+CREATE FUNCTION AuditLogAppendOnly() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'AuditLog is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER AuditLogNoChange BEFORE UPDATE OR DELETE ON AuditLog
+    FOR EACH ROW EXECUTE FUNCTION AuditLogAppendOnly();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Outbox;
+ALTER TABLE Orders DROP COLUMN Email;
@@ -0,0 +1,2 @@
+DROP INDEX OrdersByCreated;
+ALTER TABLE Orders DROP COLUMN Created;
@@ -0,0 +1,5 @@
+ALTER TABLE Orders ADD COLUMN Created TIMESTAMP NULL;
+
+UPDATE Orders SET Created = CURRENT_TIMESTAMP WHERE Created IS NULL;
+
+CREATE INDEX OrdersByCreated ON Orders (Created);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Promotions;
+DROP TABLE IF EXISTS ShippingMethods;
@@ -0,0 +1,39 @@
+ALTER TABLE Products ADD COLUMN Weight DECIMAL(8, 3) NOT NULL DEFAULT 0;
+
+ALTER TABLE Orders ADD COLUMN ShippingMethodId INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN ShippingMethod TEXT NOT NULL DEFAULT '';
+ALTER TABLE Orders ADD COLUMN PromotionCode TEXT NOT NULL DEFAULT '';
+ALTER TABLE Orders ADD COLUMN Subtotal DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN Shipping DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN Discount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN Tax DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN Total DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN TaxName TEXT NOT NULL DEFAULT '';
+ALTER TABLE Orders ADD COLUMN TaxRate DECIMAL(6, 3) NOT NULL DEFAULT 0;
+ALTER TABLE Orders ADD COLUMN TaxIncluded BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE Orders SET Subtotal = COALESCE((SELECT SUM(OrderLines.Quantity * Products.Price)
+    FROM OrderLines, Products 
+    WHERE OrderLines.OrderId = Orders.Id AND OrderLines.ProductId = Products.Id), 0);
+UPDATE Orders SET Total = Subtotal;
+
+CREATE TABLE IF NOT EXISTS ShippingRates (
+    Id SERIAL PRIMARY KEY,
+    MethodId INTEGER NOT NULL,
+    Country TEXT NOT NULL DEFAULT '',
+    State TEXT NOT NULL DEFAULT '',
+    MaxWeight DECIMAL(8, 3) NOT NULL DEFAULT 0,
+    Price DECIMAL(8, 2) NOT NULL
+);
+
+CREATE INDEX ShippingRatesByMethod ON ShippingRates (MethodId);
+
+CREATE TABLE IF NOT EXISTS TaxRates (
+    Id SERIAL PRIMARY KEY,
+    Name TEXT NOT NULL,
+    Country TEXT NOT NULL,
+    State TEXT NOT NULL DEFAULT '',
+    Rate DECIMAL(6, 3) NOT NULL,
+    Inclusive BOOLEAN NOT NULL,
+    TaxShipping BOOLEAN NOT NULL
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS Reviews;
+
+DROP INDEX ProductsByRating;
+ALTER TABLE Products DROP COLUMN ReviewCount;
+ALTER TABLE Products DROP COLUMN Rating;
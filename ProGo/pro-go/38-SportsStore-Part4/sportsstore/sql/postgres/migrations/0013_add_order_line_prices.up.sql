@@ -0,0 +1,18 @@
+ALTER TABLE OrderLines ADD COLUMN Price DECIMAL(8, 2) NOT NULL DEFAULT 0;
+
+-- Lines saved before this migration did not record a price, so the current
+-- product price is the best available estimate. The totals of orders placed 
+-- before 0010_add_pricing were estimated from the product prices in the same way.
+UPDATE OrderLines SET Price = COALESCE((SELECT Price FROM Products 
+    WHERE Products.Id = OrderLines.ProductId), 0);
+
+ALTER TABLE Orders ADD COLUMN PricesEstimated BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE Orders SET PricesEstimated = TRUE;
+
+-- Orders placed before 0008_add_order_dates were given the date that 
+-- migration was applied rather than the date they were placed.
+ALTER TABLE Orders ADD COLUMN CreatedEstimated BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE Orders SET CreatedEstimated = TRUE 
+WHERE Created <= (SELECT AppliedAt FROM schema_migrations WHERE Version = 8);
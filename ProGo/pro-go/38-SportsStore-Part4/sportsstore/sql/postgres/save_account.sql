@@ -0,0 +1,3 @@
+INSERT INTO Accounts(Name, Email, PasswordHash, Roles)
+VALUES (?, ?, ?, ?)
+RETURNING Id
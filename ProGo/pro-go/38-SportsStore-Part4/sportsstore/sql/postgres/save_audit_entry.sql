@@ -0,0 +1,4 @@
+INSERT INTO AuditLog(Time, ActorId, ActorName, Action, Entity, EntityId, Changes,
+    Method, Path, RemoteAddr, UserAgent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING Id
@@ -0,0 +1,2 @@
+INSERT INTO Categories(Name) VALUES (?)
+RETURNING Id
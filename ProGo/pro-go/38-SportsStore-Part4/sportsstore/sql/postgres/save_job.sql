@@ -0,0 +1,3 @@
+INSERT INTO Jobs(Name, Payload, Status, Attempts, MaxAttempts, RunAt, Created, Updated, LastError)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING Id
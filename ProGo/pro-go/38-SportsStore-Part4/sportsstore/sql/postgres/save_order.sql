@@ -0,0 +1,5 @@
+INSERT INTO Orders(Name, Email, StreetAddr, City, State, Zip, Country, Shipped, AccountId, Created,
+    ShippingMethodId, ShippingMethod, PromotionCode, Subtotal, Shipping, Discount, Tax,
+    Total, TaxName, TaxRate, TaxIncluded)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING Id
@@ -0,0 +1,3 @@
+INSERT INTO Products(Name, Description, Category, Price)
+VALUES (?, ?, ?, ?)
+RETURNING Id
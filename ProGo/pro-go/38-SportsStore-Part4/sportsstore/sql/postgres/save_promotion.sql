@@ -0,0 +1,3 @@
+INSERT INTO Promotions(Code, Description, DiscountPercent, Starts, Ends, Active)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING Id
@@ -0,0 +1,3 @@
+INSERT INTO Reviews(ProductId, AccountId, Author, Rating, Body, Status, Created)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING Id
@@ -0,0 +1,3 @@
+INSERT INTO ShippingMethods(Name, Description, Price, DeliveryDays, Active)
+VALUES (?, ?, ?, ?, ?)
+RETURNING Id
@@ -0,0 +1,3 @@
+INSERT INTO ShippingRates(MethodId, Country, State, MaxWeight, Price)
+VALUES (?, ?, ?, ?, ?)
+RETURNING Id
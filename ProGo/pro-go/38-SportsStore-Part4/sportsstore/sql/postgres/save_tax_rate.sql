@@ -0,0 +1,3 @@
+INSERT INTO TaxRates(Name, Country, State, Rate, Inclusive, TaxShipping)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING Id
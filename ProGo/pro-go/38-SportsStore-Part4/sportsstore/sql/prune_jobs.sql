@@ -0,0 +1 @@
+DELETE FROM Jobs WHERE Status IN ('succeeded', 'failed') AND Updated < ?
@@ -0,0 +1,2 @@
+INSERT INTO Accounts(Name, Email, PasswordHash, Roles) 
+VALUES (?, ?, ?, ?)
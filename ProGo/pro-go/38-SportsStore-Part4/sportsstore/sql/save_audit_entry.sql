@@ -0,0 +1,3 @@
+INSERT INTO AuditLog(Time, ActorId, ActorName, Action, Entity, EntityId, Changes,
+    Method, Path, RemoteAddr, UserAgent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT INTO CartLines(AccountId, ProductId, Quantity, Updated) 
+VALUES (?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT INTO FeatureFlags(Name, Description, Enabled, Percentage, KeyBy, Updated)
+VALUES (?, ?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT INTO Jobs(Name, Payload, Status, Attempts, MaxAttempts, RunAt, Created, Updated, LastError)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
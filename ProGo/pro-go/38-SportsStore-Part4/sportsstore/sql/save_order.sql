@@ -1,2 +1,4 @@
-INSERT INTO Orders(Name, StreetAddr, City, Zip, Country, Shipped) 
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO Orders(Name, Email, StreetAddr, City, State, Zip, Country, Shipped, AccountId, Created,
+    ShippingMethodId, ShippingMethod, PromotionCode, Subtotal, Shipping, Discount, Tax,
+    Total, TaxName, TaxRate, TaxIncluded) 
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
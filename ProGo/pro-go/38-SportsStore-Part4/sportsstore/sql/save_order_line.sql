@@ -1,2 +1,2 @@
-INSERT INTO OrderLines(OrderId, ProductId, Quantity) 
-VALUES (?, ?, ?)
+INSERT INTO OrderLines(OrderId, ProductId, Quantity, Price) 
+VALUES (?, ?, ?, ?)
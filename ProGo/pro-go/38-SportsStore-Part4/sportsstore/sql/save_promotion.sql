@@ -0,0 +1,2 @@
+INSERT INTO Promotions(Code, Description, DiscountPercent, Starts, Ends, Active)
+VALUES (?, ?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT INTO Reviews(ProductId, AccountId, Author, Rating, Body, Status, Created)
+VALUES (?, ?, ?, ?, ?, ?, ?)
@@ -0,0 +1,5 @@
+INSERT INTO Addresses(AccountId, Name, StreetAddr, City, State, Zip, Country) 
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(AccountId) DO UPDATE SET Name = excluded.Name, 
+    StreetAddr = excluded.StreetAddr, City = excluded.City, 
+    State = excluded.State, Zip = excluded.Zip, Country = excluded.Country
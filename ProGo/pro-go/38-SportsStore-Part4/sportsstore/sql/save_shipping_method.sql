@@ -0,0 +1,2 @@
+INSERT INTO ShippingMethods(Name, Description, Price, DeliveryDays, Active)
+VALUES (?, ?, ?, ?, ?)
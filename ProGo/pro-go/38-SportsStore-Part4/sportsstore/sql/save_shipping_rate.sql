@@ -0,0 +1,2 @@
+INSERT INTO ShippingRates(MethodId, Country, State, MaxWeight, Price)
+VALUES (?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT INTO TaxRates(Name, Country, State, Rate, Inclusive, TaxShipping)
+VALUES (?, ?, ?, ?, ?, ?)
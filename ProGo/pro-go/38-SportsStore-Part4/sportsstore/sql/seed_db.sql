@@ -1,20 +1,59 @@
 INSERT INTO Categories(Id, Name) VALUES 
-	(1, "Watersports"), (2, "Soccer"), (3, "Chess");
+	(1, 'Watersports'), (2, 'Soccer'), (3, 'Chess');
 	
-INSERT INTO Products(Id, Name, Description, Category, Price) VALUES
-	(1, "Kayak", "A boat for one person", 1, 275),
-	(2, "Lifejacket", "Protective and fashionable", 1, 48.95),
-	(3, "Soccer Ball", "FIFA-approved size and weight", 2, 19.50),
-	(4, "Corner Flags", "Give your playing field a professional touch", 2, 34.95),	
-	(5, "Stadium", "Flat-packed 35,000-seat stadium", 2, 79500),	
-	(6, "Thinking Cap", "Improve brain efficiency by 75%", 3, 16),	
-	(7, "Unsteady Chair", "Secretly give your opponent a disadvantage", 3, 29.95),	
-	(8, "Human Chess Board", "A fun game for the family", 3, 75),	
-	(9, "Bling-Bling King", "Gold-plated, diamond-studded King", 3, 1200);
-
-INSERT INTO Orders(Id, Name, StreetAddr, City, Zip, Country, Shipped) VALUES
-	(1, "Alice", "123 Main St", "New Town", "12345", "USA", false),
-	(2, "Bob", "The Grange", "Upton", "UP12 6YT", "UK", false);
-
-INSERT INTO OrderLines(Id, OrderId, ProductId, Quantity) VALUES
-	(1, 1, 1, 1), (2, 1, 2, 2), (3, 1, 8, 1), (4, 2, 5, 2);
+INSERT INTO Products(Id, Name, Description, Category, Price, Weight) VALUES
+	(1, 'Kayak', 'A boat for one person', 1, 275, 20),
+	(2, 'Lifejacket', 'Protective and fashionable', 1, 48.95, 1.2),
+	(3, 'Soccer Ball', 'FIFA-approved size and weight', 2, 19.50, 0.45),
+	(4, 'Corner Flags', 'Give your playing field a professional touch', 2, 34.95, 2.5),	
+	(5, 'Stadium', 'Flat-packed 35,000-seat stadium', 2, 79500, 5000),	
+	(6, 'Thinking Cap', 'Improve brain efficiency by 75%', 3, 16, 0.2),	
+	(7, 'Unsteady Chair', 'Secretly give your opponent a disadvantage', 3, 29.95, 6),	
+	(8, 'Human Chess Board', 'A fun game for the family', 3, 75, 15),	
+	(9, 'Bling-Bling King', 'Gold-plated, diamond-studded King', 3, 1200, 0.3);
+
+INSERT INTO Orders(Id, Name, StreetAddr, City, State, Zip, Country, Shipped, Created,
+		Subtotal, Total) VALUES
+	(1, 'Alice', '123 Main St', 'New Town', 'NY', '12345', 'USA', false, CURRENT_TIMESTAMP, 
+		447.90, 447.90),
+	(2, 'Bob', 'The Grange', 'Upton', 'Upshire', 'UP12 6YT', 'UK', false, CURRENT_TIMESTAMP, 
+		159000, 159000);
+
+INSERT INTO OrderLines(Id, OrderId, ProductId, Quantity, Price) VALUES
+	(1, 1, 1, 1, 275), (2, 1, 2, 2, 48.95), (3, 1, 8, 1, 75), (4, 2, 5, 2, 79500);
+
+INSERT INTO Accounts(Id, Name, Email, PasswordHash, Roles) VALUES
+	(1, 'Alice', 'alice@example.com', 
+		'pbkdf2-sha256$100000$Tm+YVTWUwNlk8/4Z8DQbXw$XWxzyCOW4Uzn/pQmMjnAylDwRcq7kVbXt9tIeCRCnNA', 
+		'Administrator'),
+	(2, 'Fred', 'fred@example.com', 
+		'pbkdf2-sha256$100000$Ymae+xAS5jmo2SGGbZa3ug$aXJ9QZQPGhT8AZclufFeOZW+H41JIM8wSuZjhu3N54w', 
+		'Fulfilment');
+
+INSERT INTO ShippingMethods(Id, Name, Description, Price, DeliveryDays, Active) VALUES
+	(1, 'Standard', 'Delivered by post', 4.95, 5, true),
+	(2, 'Express', 'Next working day courier', 14.95, 1, true);
+
+INSERT INTO ShippingRates(Id, MethodId, Country, State, MaxWeight, Price) VALUES
+	(1, 1, '', '', 5, 4.95),
+	(2, 1, '', '', 30, 12.95),
+	(3, 1, '', '', 0, 49.95),
+	(4, 1, 'USA,US', 'AK,HI', 5, 9.95),
+	(5, 2, '', '', 5, 14.95),
+	(6, 2, '', '', 0, 59.95);
+
+INSERT INTO TaxRates(Id, Name, Country, State, Rate, Inclusive, TaxShipping) VALUES
+	(1, 'NY Sales Tax', 'USA,US', 'NY', 8.875, false, true),
+	(2, 'VAT', 'UK,GB', '', 20, true, true),
+	(3, 'Consumption Tax', 'Japan,JP', '', 10, true, true);
+
+INSERT INTO Reviews(Id, ProductId, AccountId, Author, Rating, Body, Status, Created) VALUES
+	(1, 1, 1, 'Alice', 5, 'Stable and quick, perfect for calm lakes.', 'approved', 
+		CURRENT_TIMESTAMP),
+	(2, 1, 2, 'Fred', 4, 'Great boat, but heavier to carry than expected.', 'approved', 
+		CURRENT_TIMESTAMP),
+	(3, 2, 1, 'Alice', 4, 'Comfortable enough to wear all day on the water.', 'approved', 
+		CURRENT_TIMESTAMP);
+
+UPDATE Products SET Rating = 4.5, ReviewCount = 2 WHERE Id = 1;
+UPDATE Products SET Rating = 4, ReviewCount = 1 WHERE Id = 2;
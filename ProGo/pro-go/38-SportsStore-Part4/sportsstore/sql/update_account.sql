@@ -0,0 +1,3 @@
+UPDATE Accounts
+SET Name = ?, Email = ?, PasswordHash = ?, Roles = ?
+WHERE Id = ?
@@ -1 +1 @@
-UPDATE Categories SET Name = ? WHERE Id == ?
\ No newline at end of file
+UPDATE Categories SET Name = ? WHERE Id = ?
\ No newline at end of file
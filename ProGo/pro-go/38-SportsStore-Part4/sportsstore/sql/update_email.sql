@@ -0,0 +1,2 @@
+UPDATE Outbox SET Status = ?, Attempts = ?, NextAttempt = ?, LastError = ? 
+WHERE Id = ?
@@ -0,0 +1,3 @@
+UPDATE FeatureFlags SET Description = ?, Enabled = ?, Percentage = ?, KeyBy = ?, 
+    Updated = ?
+WHERE Name = ?
@@ -0,0 +1,2 @@
+UPDATE Jobs SET Status = ?, Attempts = ?, RunAt = ?, Updated = ?, LastError = ?
+WHERE Id = ?
@@ -1 +1 @@
-UPDATE Orders SET Shipped = ? WHERE Id == ?
\ No newline at end of file
+UPDATE Orders SET Shipped = ? WHERE Id = ?
\ No newline at end of file
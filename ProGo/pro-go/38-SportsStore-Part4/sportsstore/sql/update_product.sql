@@ -1,3 +1,3 @@
 UPDATE Products
 SET Name = ?, Description = ?, Category = ?, Price =?
-WHERE Id == ?
+WHERE Id = ?
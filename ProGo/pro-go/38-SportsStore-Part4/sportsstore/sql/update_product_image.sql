@@ -0,0 +1 @@
+UPDATE Products SET Image = ? WHERE Id = ?
@@ -0,0 +1,6 @@
+UPDATE Products SET 
+    Rating = COALESCE((SELECT ROUND(AVG(Reviews.Rating), 2) FROM Reviews 
+        WHERE Reviews.ProductId = ? AND Reviews.Status = ?), 0),
+    ReviewCount = (SELECT COUNT(*) FROM Reviews 
+        WHERE Reviews.ProductId = ? AND Reviews.Status = ?)
+WHERE Id = ?
@@ -0,0 +1 @@
+UPDATE Products SET Weight = ? WHERE Id = ?
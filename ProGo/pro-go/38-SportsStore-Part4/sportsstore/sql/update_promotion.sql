@@ -0,0 +1,3 @@
+UPDATE Promotions SET Code = ?, Description = ?, DiscountPercent = ?, Starts = ?, 
+    Ends = ?, Active = ?
+WHERE Id = ?
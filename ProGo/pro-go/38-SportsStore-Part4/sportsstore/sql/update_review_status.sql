@@ -0,0 +1 @@
+UPDATE Reviews SET Status = ? WHERE Id = ?
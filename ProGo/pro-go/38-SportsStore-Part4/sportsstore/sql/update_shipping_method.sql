@@ -0,0 +1,3 @@
+UPDATE ShippingMethods SET Name = ?, Description = ?, Price = ?, DeliveryDays = ?, 
+    Active = ?
+WHERE Id = ?
@@ -0,0 +1,3 @@
+UPDATE ShippingRates SET MethodId = ?, Country = ?, State = ?, MaxWeight = ?, 
+    Price = ?
+WHERE Id = ?
@@ -0,0 +1,3 @@
+UPDATE TaxRates SET Name = ?, Country = ?, State = ?, Rate = ?, Inclusive = ?, 
+    TaxShipping = ?
+WHERE Id = ?
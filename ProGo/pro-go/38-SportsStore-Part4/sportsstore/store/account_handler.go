@@ -0,0 +1,168 @@
+package store
+
+import (
+	"encoding/json"
+	"platform/authorization"
+	"platform/authorization/identity"
+	"platform/http/actionresults"
+	"platform/http/handling"
+	"platform/sessions"
+	"platform/validation"
+	"sportsstore/admin/auth"
+	"sportsstore/models"
+	"sportsstore/store/cart"
+	"strings"
+)
+
+type AccountHandler struct {
+    identity.User
+    identity.SignInManager
+    sessions.Session
+    validation.Validator
+    Accounts models.AccountRepository
+    URLGenerator handling.URLGenerator
+}
+
+const ACCOUNT_MSG_KEY string = "account_message"
+const REGISTRATION_KEY string = "account_registration"
+
+type AccountTemplateContext struct {
+    Message string
+    Registration
+    ValidationErrors [][]string
+    SignInUrl string
+    RegisterUrl string
+}
+
+type Registration struct {
+    Name string `validation:"required,min:3"`
+    Email string `validation:"required"`
+    Password string `validation:"required,min:6"`
+}
+
+type AccountCredentials struct {
+    Username string
+    Password string
+}
+
+func (handler AccountHandler) GetSignIn() actionresults.ActionResult {
+    return actionresults.NewTemplateAction("account_signin.html", 
+        AccountTemplateContext {
+            Message: handler.takeMessage(),
+            RegisterUrl: mustGenerateUrl(handler.URLGenerator, 
+                AccountHandler.GetRegister),
+        })
+}
+
+func (handler AccountHandler) PostSignIn(
+        creds AccountCredentials) actionresults.ActionResult {
+    user, ok := auth.Authenticate(handler.Accounts, creds.Username, creds.Password)
+    if !ok {
+        handler.Session.SetValue(ACCOUNT_MSG_KEY, "Invalid username or password")
+        return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator, 
+            AccountHandler.GetSignIn))
+    }
+    return handler.signIn(user)
+}
+
+func (handler AccountHandler) GetRegister() actionresults.ActionResult {
+    context := AccountTemplateContext {}
+    jsonData := handler.Session.GetValueDefault(REGISTRATION_KEY, "").(string)
+    if jsonData != "" {
+        json.NewDecoder(strings.NewReader(jsonData)).Decode(&context)
+        handler.Session.SetValue(REGISTRATION_KEY, "")
+    }
+    context.Password = ""
+    context.Message = handler.takeMessage()
+    context.SignInUrl = mustGenerateUrl(handler.URLGenerator, AccountHandler.GetSignIn)
+    return actionresults.NewTemplateAction("account_register.html", context)
+}
+
+func (handler AccountHandler) PostRegister(reg Registration) actionresults.ActionResult {
+    valid, errors := handler.Validator.Validate(reg)
+    ctx := AccountTemplateContext { 
+        Registration: Registration{ Name: reg.Name, Email: reg.Email },
+        ValidationErrors: [][]string {},
+    }
+    for _, err := range errors {
+        ctx.ValidationErrors = append(ctx.ValidationErrors, 
+            []string { err.FieldName, err.Error.Error()})
+    }
+    if _, exists := handler.Accounts.GetAccountByName(reg.Name); valid && exists {
+        ctx.ValidationErrors = append(ctx.ValidationErrors, 
+            []string { "Name", "That name is already registered"})
+    }
+    if len(ctx.ValidationErrors) > 0 {
+        builder := strings.Builder{}
+        json.NewEncoder(&builder).Encode(ctx)
+        handler.Session.SetValue(REGISTRATION_KEY, builder.String())
+        return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator, 
+            AccountHandler.GetRegister))
+    }
+    hash, err := authorization.HashPassword(reg.Password)
+    if err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    account := models.Account {
+        Name: reg.Name, 
+        Email: reg.Email, 
+        PasswordHash: hash,
+        Roles: []string { auth.CUSTOMER_ROLE },
+    }
+    if err := handler.Accounts.SaveAccount(&account); err != nil {
+        return ErrorAction(err)
+    }
+    return handler.signIn(auth.NewAccountUser(account))
+}
+
+func (handler AccountHandler) PostSignOut() actionresults.ActionResult {
+    handler.SignInManager.SignOut(handler.User)
+    return actionresults.NewRedirectAction("/")
+}
+
+func (handler AccountHandler) GetOrders() actionresults.ActionResult {
+    if !handler.User.IsAuthenticated() {
+        return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator, 
+            AccountHandler.GetSignIn))
+    }
+    return actionresults.NewTemplateAction("account_orders.html", struct {
+        identity.User
+        Orders []models.Order
+        ProductListUrl string
+    }{
+        User: handler.User,
+        Orders: handler.Accounts.GetAccountOrders(handler.User.GetID()),
+        ProductListUrl: mustGenerateUrl(handler.URLGenerator, ProductHandler.GetProducts, 
+            0, 1),
+    })
+}
+
+func (handler AccountHandler) GetWidget() actionresults.ActionResult {
+    return actionresults.NewTemplateAction("account_widget.html", struct {
+        identity.User
+        SignInUrl, RegisterUrl, OrdersUrl, SignOutUrl string
+    }{
+        User: handler.User,
+        SignInUrl: mustGenerateUrl(handler.URLGenerator, AccountHandler.GetSignIn),
+        RegisterUrl: mustGenerateUrl(handler.URLGenerator, AccountHandler.GetRegister),
+        OrdersUrl: mustGenerateUrl(handler.URLGenerator, AccountHandler.GetOrders),
+        SignOutUrl: mustGenerateUrl(handler.URLGenerator, AccountHandler.PostSignOut),
+    })
+}
+
+func (handler AccountHandler) signIn(user identity.User) actionresults.ActionResult {
+    if err := handler.SignInManager.SignIn(user); err != nil {
+        return actionresults.NewErrorAction(err)
+    }
+    cart.MergeSessionCart(handler.Session, handler.Accounts, user.GetID())
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator, 
+        ProductHandler.GetProducts, 0, 1))
+}
+
+func (handler AccountHandler) takeMessage() string {
+    message := handler.Session.GetValueDefault(ACCOUNT_MSG_KEY, "").(string)
+    if message != "" {
+        handler.Session.SetValue(ACCOUNT_MSG_KEY, "")
+    }
+    return message
+}
@@ -0,0 +1,62 @@
+package cart
+
+import (
+    "platform/sessions"
+    "sportsstore/models"
+)
+
+func newAccountCart(accountId int, repo models.AccountRepository) *accountCart {
+    cart := &accountCart{ 
+        BasicCart: &BasicCart{ lines: []*CartLine{} },
+        accountId: accountId,
+        AccountRepository: repo,
+    }
+    for _, sel := range repo.GetCartLines(accountId) {
+        cart.addQuantity(sel.Product, sel.Quantity)
+    }
+    return cart
+}
+
+type accountCart struct {
+    *BasicCart
+    accountId int
+    models.AccountRepository
+}
+
+func (ac *accountCart) AddProduct(p models.Product) {
+    ac.BasicCart.AddProduct(p)
+    ac.SaveToAccount()
+}
+
+func (ac *accountCart) RemoveLineForProduct(id int) {
+    ac.BasicCart.RemoveLineForProduct(id)
+    ac.SaveToAccount()
+}
+
+func (ac *accountCart) SaveToAccount() {
+    selections := make([]models.ProductSelection, len(ac.lines))
+    for i, line := range ac.lines {
+        selections[i] = models.ProductSelection{ 
+            Quantity: line.Quantity, Product: line.Product,
+        }
+    }
+    ac.AccountRepository.SaveCartLines(ac.accountId, selections)
+}
+
+func (ac *accountCart) Reset() {
+    ac.lines = []*CartLine{}
+    ac.SaveToAccount()
+}
+
+func MergeSessionCart(session sessions.Session, repo models.AccountRepository, 
+        accountId int) {
+    sessionLines := loadSessionLines(session)
+    if len(sessionLines) > 0 {
+        cart := newAccountCart(accountId, repo)
+        for _, line := range sessionLines {
+            cart.addQuantity(line.Product, line.Quantity)
+        }
+        cart.SaveToAccount()
+        session.SetValue(CART_KEY, "")
+    }
+}
@@ -0,0 +1,91 @@
+package cart
+
+import (
+    "sportsstore/models"
+    "testing"
+)
+
+type testAccountRepo struct {
+    models.AccountRepository
+    lines map[int][]models.ProductSelection
+    saves int
+}
+
+func (repo *testAccountRepo) GetCartLines(accountId int) []models.ProductSelection {
+    return repo.lines[accountId]
+}
+
+func (repo *testAccountRepo) SaveCartLines(accountId int, 
+        lines []models.ProductSelection) {
+    repo.lines[accountId] = lines
+    repo.saves++
+}
+
+type testSession map[string]interface{}
+
+func (s testSession) GetValue(key string) interface{} {
+    return s[key]
+}
+
+func (s testSession) GetValueDefault(key string, defVal interface{}) interface{} {
+    if val, ok := s[key]; ok {
+        return val
+    }
+    return defVal
+}
+
+func (s testSession) SetValue(key string, val interface{}) {
+    s[key] = val
+}
+
+var kayak = models.Product{ ID: 1, Name: "Kayak", Price: 275 }
+var lifejacket = models.Product{ ID: 2, Name: "Lifejacket", Price: 48.95 }
+
+func TestAccountCartPersistence(t *testing.T) {
+    repo := &testAccountRepo{ lines: map[int][]models.ProductSelection {
+        2: { { Quantity: 2, Product: kayak } },
+    }}
+    cart := newAccountCart(2, repo)
+    if cart.GetItemCount() != 2 || cart.GetTotal() != 550 {
+        t.Fatalf("Expected stored lines to be loaded: %v", cart.GetLines())
+    }
+    cart.AddProduct(lifejacket)
+    cart.AddProduct(kayak)
+    if stored := repo.lines[2]; len(stored) != 2 || stored[0].Quantity != 3 ||
+            stored[1].Product.ID != 2 || repo.saves != 2 {
+        t.Fatalf("Unexpected stored lines: %v", stored)
+    }
+    cart.RemoveLineForProduct(1)
+    if stored := repo.lines[2]; len(stored) != 1 || stored[0].Product.ID != 2 {
+        t.Fatalf("Expected removed line to be saved: %v", stored)
+    }
+    cart.Reset()
+    if len(repo.lines[2]) != 0 || len(newAccountCart(2, repo).GetLines()) != 0 {
+        t.Fatal("Expected reset cart to be saved")
+    }
+}
+
+func TestMergeSessionCart(t *testing.T) {
+    repo := &testAccountRepo{ lines: map[int][]models.ProductSelection {
+        2: { { Quantity: 1, Product: kayak } },
+    }}
+    session := testSession {}
+    sessionCart := &sessionCart{ BasicCart: &BasicCart{ lines: []*CartLine {} },
+        Session: session }
+    sessionCart.AddProduct(kayak)
+    sessionCart.AddProduct(lifejacket)
+
+    MergeSessionCart(session, repo, 2)
+    if stored := repo.lines[2]; len(stored) != 2 || stored[0].Quantity != 2 ||
+            stored[1].Quantity != 1 {
+        t.Fatalf("Unexpected merged lines: %v", stored)
+    }
+    if len(loadSessionLines(session)) != 0 {
+        t.Fatal("Expected session cart to be cleared")
+    }
+    saves := repo.saves
+    MergeSessionCart(session, repo, 2)
+    if repo.saves != saves {
+        t.Fatal("Expected empty session cart to leave account cart unchanged")
+    }
+}
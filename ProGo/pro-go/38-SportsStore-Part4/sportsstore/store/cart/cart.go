@@ -26,14 +26,18 @@ type BasicCart struct {
 }
 
 func (cart *BasicCart) AddProduct(p models.Product) {
+    cart.addQuantity(p, 1)
+}
+
+func (cart *BasicCart) addQuantity(p models.Product, quantity int) {
     for _, line := range cart.lines {
         if (line.Product.ID == p.ID) {
-            line.Quantity++
+            line.Quantity += quantity
             return
         }
     }
     cart.lines = append(cart.lines, &CartLine{
-        Product: p, Quantity: 1,
+        Product: p, Quantity: quantity,
     })
 }
 
@@ -0,0 +1,25 @@
+package cart
+
+import (
+    "context"
+    "platform/config"
+    "platform/jobs"
+    "platform/logging"
+    "sportsstore/models"
+    "time"
+)
+
+func CleanupAbandonedCarts(job jobs.Job, ctx context.Context, 
+        carts models.AbandonedCartRepository, cfg config.Configuration, 
+        logger logging.Logger) error {
+    maxAge, err := time.ParseDuration(cfg.GetStringDefault("jobs:carts:maxAge", 
+        "720h"))
+    if err != nil {
+        return err
+    }
+    count, err := carts.DeleteAbandonedCartLines(ctx, time.Now().Add(-maxAge))
+    if err == nil && count > 0 {
+        logger.Infof("Removed %v abandoned cart lines", count)
+    }
+    return err
+}
@@ -1,6 +1,7 @@
 package cart
 
 import (
+    "platform/authorization/identity"
     "platform/services"
     "platform/sessions"
     "sportsstore/models"
@@ -11,19 +12,27 @@ import (
 const CART_KEY string = "cart"
 
 func RegisterCartService() {
-    services.AddScoped(func(session sessions.Session) Cart {
-        lines := []*CartLine {}
-        sessionVal := session.GetValue(CART_KEY)
-        if strVal, ok := sessionVal.(string); ok {
-            json.NewDecoder(strings.NewReader(strVal)).Decode(&lines)
+    services.AddScoped(func(session sessions.Session, user identity.User, 
+            repo models.AccountRepository) Cart {
+        if user.IsAuthenticated() {
+            return newAccountCart(user.GetID(), repo)
         }
         return &sessionCart{ 
-            BasicCart: &BasicCart{ lines: lines},
+            BasicCart: &BasicCart{ lines: loadSessionLines(session)},
             Session: session,
         }
     })
 }
 
+func loadSessionLines(session sessions.Session) []*CartLine {
+    lines := []*CartLine {}
+    sessionVal := session.GetValue(CART_KEY)
+    if strVal, ok := sessionVal.(string); ok {
+        json.NewDecoder(strings.NewReader(strVal)).Decode(&lines)
+    }
+    return lines
+}
+
 type sessionCart struct {
     *BasicCart
     sessions.Session
@@ -1,16 +1,25 @@
 package store
 
 import (
+    "context"
+    "platform/authorization/identity"
+    "platform/config"
     "platform/http/actionresults"
     "platform/http/handling"
     "sportsstore/models"
+    "sportsstore/pricing"
     "sportsstore/store/cart"
 )
 
 type CartHandler struct {
-    models.Repository
+    models.RepositoryV2
     cart.Cart
+    Context context.Context
     handling.URLGenerator
+    identity.User
+    Accounts models.AccountRepository
+    Pricing *pricing.Calculator
+    config.Configuration
 }
 
 type CartTemplateContext struct {
@@ -19,15 +28,52 @@ type CartTemplateContext struct {
     CartUrl string
     CheckoutUrl string
     RemoveUrl string
+    Quote *pricing.Quote
+    Destination string
 }
 
 func (handler CartHandler) GetCart() actionresults.ActionResult {
-    return actionresults.NewTemplateAction("cart.html", CartTemplateContext {
+    context := CartTemplateContext {
         Cart: handler.Cart,
         ProductListUrl: handler.mustGenerateUrl(ProductHandler.GetProducts, 0, 1),
         RemoveUrl: handler.mustGenerateUrl(CartHandler.PostRemoveFromCart),
         CheckoutUrl: handler.mustGenerateUrl(OrderHandler.GetCheckout),                
-    })
+    }
+    if len(handler.Cart.GetLines()) > 0 {
+        country, state := handler.estimateDestination()
+        quote, err := handler.Pricing.Calculate(handler.Context, pricing.Request{
+            Lines: cartPricingLines(handler.Cart), Country: country, State: state,
+        })
+        if err != nil {
+            return ErrorAction(err)
+        }
+        context.Quote = &quote
+        context.Destination = country
+        if state != "" {
+            context.Destination = state + ", " + country
+        }
+    }
+    return actionresults.NewTemplateAction("cart.html", context)
+}
+
+func (handler CartHandler) estimateDestination() (country, state string) {
+    if handler.User.IsAuthenticated() {
+        if details, found := handler.Accounts.GetShippingDetails(
+                handler.User.GetID()); found && details.Country != "" {
+            return details.Country, details.State
+        }
+    }
+    return handler.Configuration.GetStringDefault("pricing:estimate:country", ""),
+        handler.Configuration.GetStringDefault("pricing:estimate:state", "")
+}
+
+func cartPricingLines(c cart.Cart) []pricing.Line {
+    lines := []pricing.Line {}
+    for _, line := range c.GetLines() {
+        lines = append(lines, pricing.Line{ Price: line.Price, 
+            Weight: line.Weight, Quantity: line.Quantity })
+    }
+    return lines
 }
 
 func (handler CartHandler) GetWidget() actionresults.ActionResult {
@@ -42,7 +88,10 @@ type CartProductReference struct {
 }
 
 func (handler CartHandler) PostAddToCart(ref CartProductReference) actionresults.ActionResult {
-    p := handler.Repository.GetProduct(ref.ID)
+    p, err := handler.RepositoryV2.GetProduct(handler.Context, ref.ID)
+    if err != nil {
+        return ErrorAction(err)
+    }
     handler.Cart.AddProduct(p)
     return actionresults.NewRedirectAction(
         handler.mustGenerateUrl(CartHandler.GetCart))
@@ -1,13 +1,15 @@
 package store
 
 import (
+    "context"
     "sportsstore/models"
     "platform/http/actionresults"    
     "platform/http/handling"
 )
 
 type CategoryHandler struct {
-    Repository models.Repository
+    Repository models.RepositoryV2
+    Context context.Context
     URLGenerator handling.URLGenerator
 }
 
@@ -18,9 +20,13 @@ type categoryTemplateContext struct {
 }
 
 func (handler CategoryHandler) GetButtons(selected int) actionresults.ActionResult {
+    categories, err := handler.Repository.GetCategories(handler.Context)
+    if err != nil {
+        return ErrorAction(err)
+    }
     return actionresults.NewTemplateAction("category_buttons.html", 
         categoryTemplateContext {
-            Categories: handler.Repository.GetCategories(),
+            Categories: categories,
             SelectedCategory: selected,
             CategoryUrlFunc: handler.createCategoryFilterFunction(),
         })
@@ -0,0 +1,36 @@
+package store
+
+import (
+    "encoding/base64"
+    "encoding/json"
+    "fmt"
+    "sportsstore/models"
+)
+
+const (
+    cursorNext = "n"
+    cursorPrev = "p"
+)
+
+type productCursor struct {
+    Direction string `json:"d"`
+    SortBy string `json:"s"`
+    CategoryID int `json:"c,omitempty"`
+    Key models.ProductKey `json:"k"`
+}
+
+func encodeCursor(cursor productCursor) string {
+    data, _ := json.Marshal(cursor)
+    return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token string) (cursor productCursor, err error) {
+    data, err := base64.RawURLEncoding.DecodeString(token)
+    if err == nil {
+        err = json.Unmarshal(data, &cursor)
+    }
+    if err != nil || (cursor.Direction != cursorNext && cursor.Direction != cursorPrev) {
+        err = fmt.Errorf("%w: invalid cursor", models.ErrInvalidQuery)
+    }
+    return
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "platform/http/actionresults"
+    "sportsstore/models"
+)
+
+func ErrorAction(err error) actionresults.ActionResult {
+    switch {
+        case errors.Is(err, models.ErrNotFound):
+            return actionresults.NewStatusErrorAction(http.StatusNotFound, err)
+        case errors.Is(err, models.ErrInvalidQuery):
+            return actionresults.NewStatusErrorAction(http.StatusBadRequest, err)
+        case errors.Is(err, models.ErrConflict):
+            return actionresults.NewStatusErrorAction(http.StatusConflict, err)
+        case errors.Is(err, context.Canceled), 
+                errors.Is(err, context.DeadlineExceeded):
+            return actionresults.NewStatusErrorAction(
+                http.StatusServiceUnavailable, err)
+        default:
+            return actionresults.NewErrorAction(err)
+    }
+}
@@ -1,12 +1,17 @@
 package store
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"platform/authorization/identity"
+	"platform/features"
 	"platform/http/actionresults"
 	"platform/http/handling"
 	"platform/sessions"
 	"platform/validation"
 	"sportsstore/models"
+	"sportsstore/pricing"
 	"sportsstore/store/cart"
 	"strings"
 )
@@ -14,47 +19,83 @@ import (
 type OrderHandler struct {
     cart.Cart
     sessions.Session
-    Repository models.Repository
+    Repository models.RepositoryV2
+    Context context.Context
     URLGenerator handling.URLGenerator 
     validation.Validator
+    identity.User
+    Accounts models.AccountRepository
+    Pricing *pricing.Calculator
+    Features features.FeatureFlags
+}
+
+type CheckoutOptions struct {
+    ShippingMethodID int
+    PromotionCode string
 }
 
 type OrderTemplateContext struct {
     models.ShippingDetails
+    CheckoutOptions
     ValidationErrors [][]string
     CancelUrl string   
+    Methods []models.ShippingMethod
+    Cart cart.Cart `json:"-"`
+    Quote *pricing.Quote `json:"-"`
 }
 
+const LAST_ORDER_KEY = "last_order"
+
+const NEW_CHECKOUT_FEATURE = "newCheckout"
+
 func (handler OrderHandler) GetCheckout() actionresults.ActionResult {
     context := OrderTemplateContext {}
     jsonData := handler.Session.GetValueDefault("checkout_details", "")
-    if jsonData != nil {
+    if jsonData != nil && jsonData.(string) != "" {
         json.NewDecoder(strings.NewReader(jsonData.(string))).Decode(&context)
+    } else if handler.User.IsAuthenticated() {
+        if details, found := handler.Accounts.GetShippingDetails(
+                handler.User.GetID()); found {
+            context.ShippingDetails = details
+        }
+        if context.Email == "" {
+            if account, found := handler.Accounts.GetAccount(
+                    handler.User.GetID()); found {
+                context.Email = account.Email
+            }
+        }
+    }
+    methods, err := handler.Pricing.Methods(handler.Context)
+    if err != nil {
+        return ErrorAction(err)
+    }
+    context.Methods = methods
+    if handler.Features.IsEnabled(NEW_CHECKOUT_FEATURE) && 
+            len(handler.Cart.GetLines()) > 0 {
+        context.Cart = handler.Cart
+        quote, err := handler.Pricing.Calculate(handler.Context, pricing.Request{
+            Lines: cartPricingLines(handler.Cart),
+            Country: context.Country, State: context.State,
+            ShippingMethodID: context.ShippingMethodID,
+        })
+        if err == nil {
+            context.Quote = &quote
+        }
     }
     context.CancelUrl = mustGenerateUrl(handler.URLGenerator, CartHandler.GetCart)
     return actionresults.NewTemplateAction("checkout.html", context)
 }
 
-func (handler OrderHandler) PostCheckout(details models.ShippingDetails) actionresults.ActionResult {
-    valid, errors := handler.Validator.Validate(details)
+func (handler OrderHandler) PostCheckout(details models.ShippingDetails, 
+        options CheckoutOptions) actionresults.ActionResult {
+    valid, errs := handler.Validator.Validate(details)
     if (!valid) {
-        ctx := OrderTemplateContext {
-            ShippingDetails: details,
-            ValidationErrors: [][]string {},
-        }   
-        for _, err := range errors {
-            ctx.ValidationErrors = append(ctx.ValidationErrors, 
+        validationErrors := [][]string {}
+        for _, err := range errs {
+            validationErrors = append(validationErrors, 
                 []string { err.FieldName, err.Error.Error()})
         }
-
-        builder := strings.Builder{}
-        json.NewEncoder(&builder).Encode(ctx)
-        handler.Session.SetValue("checkout_details", builder.String())
-        redirectUrl := mustGenerateUrl(handler.URLGenerator, 
-            OrderHandler.GetCheckout)
-        return actionresults.NewRedirectAction(redirectUrl)
-    } else {
-        handler.Session.SetValue("checkout_details", "")
+        return handler.redirectToCheckout(details, options, validationErrors)
     }
     order := models.Order { 
         ShippingDetails: details, 
@@ -66,18 +107,65 @@ func (handler OrderHandler) PostCheckout(details models.ShippingDetails) actionr
             Product: cl.Product,
         })
     }
-    handler.Repository.SaveOrder(&order)
+    quote, err := handler.Pricing.Calculate(handler.Context, pricing.Request{
+        Lines: pricing.SelectionLines(order.Products),
+        Country: details.Country, State: details.State,
+        ShippingMethodID: options.ShippingMethodID,
+        PromotionCode: options.PromotionCode,
+    })
+    if errors.Is(err, pricing.ErrShippingMethod) {
+        return handler.redirectToCheckout(details, options, 
+            [][]string { { "ShippingMethodID", err.Error() }})
+    } else if errors.Is(err, pricing.ErrPromotionCode) {
+        return handler.redirectToCheckout(details, options, 
+            [][]string { { "PromotionCode", err.Error() }})
+    } else if err != nil {
+        return ErrorAction(err)
+    }
+    handler.Session.SetValue("checkout_details", "")
+    quote.Apply(&order)
+    if handler.User.IsAuthenticated() {
+        order.AccountID = handler.User.GetID()
+        handler.Accounts.SaveShippingDetails(order.AccountID, details)
+    }
+    if err := handler.Repository.SaveOrder(handler.Context, &order); err != nil {
+        return ErrorAction(err)
+    }
     handler.Cart.Reset()
+    handler.Session.SetValue(LAST_ORDER_KEY, order.ID)
     targetUrl, _ := handler.URLGenerator.GenerateUrl(OrderHandler.GetSummary, 
         order.ID)
     return actionresults.NewRedirectAction(targetUrl)
 }
 
+func (handler OrderHandler) redirectToCheckout(details models.ShippingDetails,
+        options CheckoutOptions, 
+        validationErrors [][]string) actionresults.ActionResult {
+    ctx := OrderTemplateContext {
+        ShippingDetails: details,
+        CheckoutOptions: options,
+        ValidationErrors: validationErrors,
+    }   
+    builder := strings.Builder{}
+    json.NewEncoder(&builder).Encode(ctx)
+    handler.Session.SetValue("checkout_details", builder.String())
+    redirectUrl := mustGenerateUrl(handler.URLGenerator, 
+        OrderHandler.GetCheckout)
+    return actionresults.NewRedirectAction(redirectUrl)
+}
+
 func (handler OrderHandler) GetSummary(id int) actionresults.ActionResult {
     targetUrl, _ := handler.URLGenerator.GenerateUrl(ProductHandler.GetProducts, 
         0, 1)
+    var order *models.Order
+    if handler.Session.GetValueDefault(LAST_ORDER_KEY, 0) == id {
+        if o, err := handler.Repository.GetOrder(handler.Context, id); err == nil {
+            order = &o
+        }
+    }
     return actionresults.NewTemplateAction("checkout_summary.html", struct {
         ID int
         TargetUrl string
-    }{ ID: id, TargetUrl: targetUrl})
+        Order *models.Order
+    }{ ID: id, TargetUrl: targetUrl, Order: order })
 }
@@ -1,17 +1,25 @@
 package store
 
 import (
+    "context"
+    "fmt"
     "sportsstore/models"
+    "platform/config"
     "platform/http/actionresults"
     "platform/http/handling"
+    "platform/media"
     "math"
+    "net/url"
 )
 
 const pageSize = 4
 
 type ProductHandler struct {
-    Repository models.Repository
+    Repository models.RepositoryV2
+    Context context.Context
     URLGenerator handling.URLGenerator
+    MediaStore media.MediaStore
+    Config config.Configuration
 }
 
 type ProductTemplateContext struct {
@@ -22,12 +30,34 @@ type ProductTemplateContext struct {
     PageUrlFunc func(int) string
     SelectedCategory int
     AddToCartUrl string
+    ImageUrlFunc func(string) string
+    ImageSrcSetFunc func(string) string
+    SortBy string
+    SortOptions []string
+    SortUrlFunc func(string) string
+    ReviewsUrlFunc func(int) string
 }
 
-func (handler ProductHandler) GetProducts(category, 
-        page int) actionresults.ActionResult {
-    prods, total := handler.Repository.GetProductPageCategory(category, 
-        page, pageSize)
+type ProductListOptions struct {
+    Sort string
+}
+
+var productSortOptions = []string { models.SortByID, models.SortByName, 
+    models.SortByPrice, models.SortByRating }
+
+func (handler ProductHandler) GetProducts(category, page int, 
+        options ProductListOptions) actionresults.ActionResult {
+    if options.Sort == "" {
+        options.Sort = models.SortByID
+    } else if !models.ValidSortBy(options.Sort) {
+        return ErrorAction(fmt.Errorf("%w: cannot sort by %v", 
+            models.ErrInvalidQuery, options.Sort))
+    }
+    prods, total, err := handler.Repository.GetProductPageSorted(
+        handler.Context, category, options.Sort, page, pageSize)
+    if err != nil {
+        return ErrorAction(err)
+    }
     pageCount := int(math.Ceil(float64(total) / float64(pageSize)))
     return actionresults.NewTemplateAction("product_list.html", 
         ProductTemplateContext {
@@ -35,19 +65,52 @@ func (handler ProductHandler) GetProducts(category,
             Page: page,
             PageCount: pageCount,
             PageNumbers: handler.generatePageNumbers(pageCount),
-            PageUrlFunc: handler.createPageUrlFunction(category),
+            PageUrlFunc: handler.createPageUrlFunction(category, options.Sort),
             SelectedCategory: category,
             AddToCartUrl: mustGenerateUrl(handler.URLGenerator, 
                  CartHandler.PostAddToCart),
+            ImageUrlFunc: handler.createImageUrlFunction(),
+            ImageSrcSetFunc: handler.createImageSrcSetFunction(),
+            SortBy: options.Sort,
+            SortOptions: productSortOptions,
+            SortUrlFunc: func(sortBy string) string {
+                return handler.productsUrl(category, 1, sortBy)
+            },
+            ReviewsUrlFunc: func(id int) string {
+                return mustGenerateUrl(handler.URLGenerator, ReviewHandler.GetReviews, id)
+            },
         })     
 }
 
-func (handler ProductHandler) createPageUrlFunction(category int) func(int) string {
+func (handler ProductHandler) createImageUrlFunction() func(string) string {
+    widths := media.ImageWidths(handler.Config)
+    return func(image string) string {
+        return handler.MediaStore.URL(media.VariantName(image, 
+            widths[len(widths) / 2]))
+    }
+}
+
+func (handler ProductHandler) createImageSrcSetFunction() func(string) string {
+    widths := media.ImageWidths(handler.Config)
+    return func(image string) string {
+        return media.SrcSet(handler.MediaStore, image, widths)
+    }
+}
+
+func (handler ProductHandler) createPageUrlFunction(category int, 
+        sortBy string) func(int) string {
     return func(page int) string {
-        url, _ := handler.URLGenerator.GenerateUrl(ProductHandler.GetProducts, 
-            category, page)
-        return url
+        return handler.productsUrl(category, page, sortBy)
+    }
+}
+
+func (handler ProductHandler) productsUrl(category, page int, sortBy string) string {
+    target, _ := handler.URLGenerator.GenerateUrl(ProductHandler.GetProducts, 
+        category, page)
+    if sortBy != "" && sortBy != models.SortByID {
+        target += "?" + url.Values{ "sort": { sortBy } }.Encode()
     }
+    return target
 }
 
 func (handler ProductHandler) generatePageNumbers(pageCount int) (pages []int) {
@@ -58,8 +121,9 @@ func (handler ProductHandler) generatePageNumbers(pageCount int) (pages []int) {
     return
 }
 
-func mustGenerateUrl(generator handling.URLGenerator, target interface{}) string {
-    url, err := generator.GenerateUrl(target)    
+func mustGenerateUrl(generator handling.URLGenerator, target interface{}, 
+        data ...interface{}) string {
+    url, err := generator.GenerateUrl(target, data...)    
     if (err != nil) {
         panic(err)
     }
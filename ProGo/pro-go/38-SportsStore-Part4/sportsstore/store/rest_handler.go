@@ -1,9 +1,16 @@
 package store
 
 import (
+    "context"
+    "fmt"
+    "sportsstore/admin/auth"
     "sportsstore/models"
+    "platform/config"
     "platform/http/actionresults"
+    "platform/http/handling"
     "net/http"
+    "net/url"
+    "strconv"
 )
 
 type StatusCodeResult struct {
@@ -16,15 +23,113 @@ func (action *StatusCodeResult) Execute(ctx *actionresults.ActionContext) error
 }
 
 type RestHandler struct {
-    Repository models.Repository
+    Repository models.RepositoryV2
+    Context context.Context
+    URLGenerator handling.URLGenerator
+    Config config.Configuration
+}
+
+func (h RestHandler) Policies() map[string]string {
+    return map[string]string {
+        "PostProduct": auth.AdminManagePolicy,
+        "PutProduct": auth.AdminManagePolicy,
+    }
 }
 
 func (h RestHandler) GetProduct(id int) actionresults.ActionResult {
-    return actionresults.NewJsonAction(h.Repository.GetProduct(id))
+    product, err := h.Repository.GetProduct(h.Context, id)
+    if err != nil {
+        return ErrorAction(err)
+    }
+    return actionresults.NewJsonAction(product)
+}
+
+type ProductListRequest struct {
+    Category int
+    Sort string
+    Limit int
+    Cursor string
+    Total bool
 }
 
-func (h RestHandler) GetProducts() actionresults.ActionResult {
-    return actionresults.NewJsonAction(h.Repository.GetProducts())
+type ProductListResponse struct {
+    Products []models.Product
+    Next string `json:",omitempty"`
+    Prev string `json:",omitempty"`
+    Total *int `json:",omitempty"`
+}
+
+func (h RestHandler) GetProducts(req ProductListRequest) actionresults.ActionResult {
+    query, err := h.productQuery(req)
+    if err != nil {
+        return ErrorAction(err)
+    }
+    page, err := h.Repository.GetProductsKeyset(h.Context, query)
+    if err != nil {
+        return ErrorAction(err)
+    }
+    response := ProductListResponse{ Products: page.Products }
+    if page.TotalIncluded {
+        response.Total = &page.Total
+    }
+    if count := len(page.Products); count > 0 {
+        if page.HasNext {
+            response.Next = h.productsUrl(req, query, cursorNext, 
+                page.Products[count - 1].Key())
+        }
+        if page.HasPrev {
+            response.Prev = h.productsUrl(req, query, cursorPrev, 
+                page.Products[0].Key())
+        }
+    }
+    return actionresults.NewJsonAction(response)
+}
+
+func (h RestHandler) productQuery(req ProductListRequest) (query models.ProductQuery, 
+        err error) {
+    query = models.ProductQuery{ CategoryID: req.Category, SortBy: req.Sort, 
+        Limit: req.Limit, IncludeTotal: req.Total }
+    if query.SortBy == "" {
+        query.SortBy = models.SortByID
+    }
+    if query.Limit == 0 {
+        query.Limit = h.Config.GetIntDefault("api:products:defaultPageSize", 20)
+    }
+    if max := h.Config.GetIntDefault("api:products:maxPageSize", 100); query.Limit > max {
+        query.Limit = max
+    }
+    if req.Cursor != "" {
+        cursor, err := decodeCursor(req.Cursor)
+        if err != nil {
+            return query, err
+        } else if (req.Sort != "" && req.Sort != cursor.SortBy) || 
+                (req.Category != 0 && req.Category != cursor.CategoryID) {
+            return query, fmt.Errorf("%w: cursor does not match sort or category", 
+                models.ErrInvalidQuery)
+        }
+        query.SortBy, query.CategoryID = cursor.SortBy, cursor.CategoryID
+        if cursor.Direction == cursorNext {
+            query.After = &cursor.Key
+        } else {
+            query.Before = &cursor.Key
+        }
+    }
+    return query, query.Validate()
+}
+
+func (h RestHandler) productsUrl(req ProductListRequest, query models.ProductQuery, 
+        direction string, key models.ProductKey) string {
+    values := url.Values{}
+    values.Set("cursor", encodeCursor(productCursor{ Direction: direction, 
+        SortBy: query.SortBy, CategoryID: query.CategoryID, Key: key }))
+    if req.Limit != 0 {
+        values.Set("limit", strconv.Itoa(query.Limit))
+    }
+    if req.Total {
+        values.Set("total", "true")
+    }
+    return mustGenerateUrl(h.URLGenerator, RestHandler.GetProducts) + "?" + 
+        values.Encode()
 }
 
 type ProductReference struct {
@@ -34,7 +139,7 @@ type ProductReference struct {
 
 func (h RestHandler) PostProduct(p ProductReference) actionresults.ActionResult {
     if p.ID == 0 {
-        return actionresults.NewJsonAction(h.processData(p))
+        return h.processData(p)
     } else {
         return &StatusCodeResult{ http.StatusBadRequest }
     }
@@ -42,17 +147,19 @@ func (h RestHandler) PostProduct(p ProductReference) actionresults.ActionResult
 
 func (h RestHandler) PutProduct(p ProductReference) actionresults.ActionResult {
     if p.ID > 0 {
-        return actionresults.NewJsonAction(h.processData(p))
+        return h.processData(p)
     } else {
         return &StatusCodeResult{ http.StatusBadRequest }
     }
 }
 
-func (h RestHandler) processData(p ProductReference) models.Product {
+func (h RestHandler) processData(p ProductReference) actionresults.ActionResult {
     product := p.Product
     product.Category = &models.Category {
         ID: p.CategoryID,
     }
-    h.Repository.SaveProduct(&product)  
-    return h.Repository.GetProduct(product.ID)
+    if err := h.Repository.SaveProduct(h.Context, &product); err != nil {
+        return ErrorAction(err)
+    }
+    return h.GetProduct(product.ID)
 }
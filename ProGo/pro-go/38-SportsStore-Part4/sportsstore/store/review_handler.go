@@ -0,0 +1,162 @@
+package store
+
+import (
+    "context"
+    "encoding/json"
+    "platform/authorization/identity"
+    "platform/config"
+    "platform/http/actionresults"
+    "platform/http/handling"
+    "platform/sessions"
+    "platform/validation"
+    "sportsstore/models"
+    "strings"
+    "time"
+)
+
+const REVIEW_KEY = "review_submission"
+const REVIEW_MSG_KEY = "review_message"
+
+type ReviewHandler struct {
+    Repository models.RepositoryV2
+    Reviews models.ReviewRepository
+    identity.User
+    sessions.Session
+    validation.Validator
+    config.Configuration
+    URLGenerator handling.URLGenerator
+    Context context.Context
+}
+
+type ReviewSubmission struct {
+    ProductID int
+    Rating int `validation:"min:1,max:5"`
+    Body string `validation:"required,min:10,max:2000,maxlinks:0,nospam"`
+}
+
+type ReviewTemplateContext struct {
+    Product models.Product
+    Reviews []models.Review
+    ReviewSubmission
+    ValidationErrors [][]string
+    Message string
+    SignedIn bool
+    CanReview bool
+    Problem string
+    RatingOptions []int
+    SubmitUrl string
+    SignInUrl string
+    ProductListUrl string
+}
+
+func (handler ReviewHandler) GetReviews(id int) actionresults.ActionResult {
+    product, err := handler.Repository.GetProduct(handler.Context, id)
+    if err != nil {
+        return ErrorAction(err)
+    }
+    reviews, err := handler.Reviews.GetProductReviews(handler.Context, id)
+    if err != nil {
+        return ErrorAction(err)
+    }
+    context := ReviewTemplateContext {}
+    jsonData := handler.Session.GetValueDefault(REVIEW_KEY, "").(string)
+    if jsonData != "" {
+        json.NewDecoder(strings.NewReader(jsonData)).Decode(&context)
+        handler.Session.SetValue(REVIEW_KEY, "")
+        if context.ProductID != id {
+            context = ReviewTemplateContext {}
+        }
+    }
+    if context.Rating == 0 {
+        context.Rating = 5
+    }
+    context.Product, context.Reviews, context.ProductID = product, reviews, id
+    context.Message = handler.Session.GetValueDefault(REVIEW_MSG_KEY, "").(string)
+    if context.Message != "" {
+        handler.Session.SetValue(REVIEW_MSG_KEY, "")
+    }
+    context.SignedIn = handler.User.IsAuthenticated()
+    if context.SignedIn {
+        problem, err := handler.checkEligible(id)
+        if err != nil {
+            return ErrorAction(err)
+        }
+        context.CanReview, context.Problem = problem == "", problem
+    }
+    context.RatingOptions = []int { 5, 4, 3, 2, 1 }
+    context.SubmitUrl = mustGenerateUrl(handler.URLGenerator, ReviewHandler.PostReview)
+    context.SignInUrl = mustGenerateUrl(handler.URLGenerator, AccountHandler.GetSignIn)
+    context.ProductListUrl = mustGenerateUrl(handler.URLGenerator, 
+        ProductHandler.GetProducts, 0, 1)
+    return actionresults.NewTemplateAction("product_reviews.html", context)
+}
+
+func (handler ReviewHandler) PostReview(
+        submission ReviewSubmission) actionresults.ActionResult {
+    if !handler.User.IsAuthenticated() {
+        return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator, 
+            AccountHandler.GetSignIn))
+    }
+    submission.Body = strings.TrimSpace(submission.Body)
+    _, errs := handler.Validator.Validate(submission)
+    validationErrors := [][]string {}
+    for _, err := range errs {
+        validationErrors = append(validationErrors, 
+            []string { err.FieldName, err.Error.Error()})
+    }
+    problem, err := handler.checkEligible(submission.ProductID)
+    if err != nil {
+        return ErrorAction(err)
+    } else if problem != "" {
+        validationErrors = append(validationErrors, []string { "Review", problem })
+    } else if len(validationErrors) == 0 {
+        count, err := handler.Reviews.CountRecentReviews(handler.Context, 
+            handler.User.GetID(), time.Now().Add(-24 * time.Hour))
+        if err != nil {
+            return ErrorAction(err)
+        } else if count >= handler.Configuration.GetIntDefault(
+                "reviews:maxPerDay", 5) {
+            validationErrors = append(validationErrors, []string { "Review", 
+                "You have submitted too many reviews today, please try again later" })
+        }
+    }
+    if len(validationErrors) > 0 {
+        builder := strings.Builder{}
+        json.NewEncoder(&builder).Encode(ReviewTemplateContext {
+            ReviewSubmission: submission, 
+            ValidationErrors: validationErrors,
+        })
+        handler.Session.SetValue(REVIEW_KEY, builder.String())
+    } else {
+        err = handler.Reviews.SaveReview(handler.Context, &models.Review {
+            ProductID: submission.ProductID,
+            AccountID: handler.User.GetID(),
+            Author: handler.User.GetDisplayName(),
+            Rating: submission.Rating,
+            Body: submission.Body,
+            Status: models.ReviewPending,
+        })
+        if err != nil {
+            return ErrorAction(err)
+        }
+        handler.Session.SetValue(REVIEW_MSG_KEY, 
+            "Thank you, your review will appear once it has been approved")
+    }
+    return actionresults.NewRedirectAction(mustGenerateUrl(handler.URLGenerator,
+        ReviewHandler.GetReviews, submission.ProductID))
+}
+
+func (handler ReviewHandler) checkEligible(productId int) (problem string, err error) {
+    accountId := handler.User.GetID()
+    purchased, err := handler.Reviews.HasPurchased(handler.Context, accountId, productId)
+    if err != nil {
+        return
+    } else if !purchased {
+        return "Only customers who have purchased this product can review it", nil
+    }
+    reviewed, err := handler.Reviews.HasReviewed(handler.Context, accountId, productId)
+    if err == nil && reviewed {
+        problem = "You have already reviewed this product"
+    }
+    return
+}